@@ -0,0 +1,88 @@
+package bitset
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/spacemagneto/common/slice"
+)
+
+// The benchmarks in this file compare the BitSet with the map and sorted-slice idioms it replaces. Run them with:
+//
+//	go test -run '^$' -bench . -benchmem ./bitset
+
+// benchSizes are the numbers of values drawn from a range four times larger.
+var benchSizes = []int{1024, 65536}
+
+// randomValues returns n random values below 4n, with possible duplicates.
+func randomValues(n int, seed uint64) []uint32 {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	values := make([]uint32, n)
+	for i := range values {
+		values[i] = rng.Uint32N(uint32(4 * n))
+	}
+	return values
+}
+
+// BenchmarkDedup collects the distinct values, with a BitSet and with slice.Unique.
+func BenchmarkDedup(b *testing.B) {
+	for _, size := range benchSizes {
+		values := randomValues(size, 1)
+		b.Run(fmt.Sprintf("BitSet/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				FromUint32s(values).Uint32s()
+			}
+		})
+		b.Run(fmt.Sprintf("Unique/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				slice.Unique(values)
+			}
+		})
+	}
+}
+
+// BenchmarkIntersect intersects two sets of values, with BitSet.And and with slice.SortedIntersect over sorted
+// values.
+func BenchmarkIntersect(b *testing.B) {
+	for _, size := range benchSizes {
+		left, right := FromUint32s(randomValues(size, 1)), FromUint32s(randomValues(size, 2))
+		sortedLeft, sortedRight := left.Uint32s(), right.Uint32s()
+		b.Run(fmt.Sprintf("BitSet/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				left.And(right)
+			}
+		})
+		b.Run(fmt.Sprintf("SortedIntersect/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				slice.SortedIntersect(sortedLeft, sortedRight)
+			}
+		})
+	}
+}
+
+// BenchmarkTest looks values up, with a BitSet and with a map.
+func BenchmarkTest(b *testing.B) {
+	for _, size := range benchSizes {
+		values := randomValues(size, 1)
+		set := FromUint32s(values)
+		m := make(map[uint32]struct{}, size)
+		for _, v := range values {
+			m[v] = struct{}{}
+		}
+		b.Run(fmt.Sprintf("BitSet/size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				set.Test(values[i%size] + 1)
+			}
+		})
+		b.Run(fmt.Sprintf("Map/size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = m[values[i%size]+1]
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// Package bitset provides BitSet, a compact set of small non-negative integers stored one bit per value. It suits
+// dense ranges such as feature flags and ID membership, where a map or a sorted slice costs tens of bytes per
+// element: membership tests and updates are O(1), and the set algebra, counting and iteration process 64 values
+// per machine word.
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// ErrInvalidEncoding is reported by UnmarshalBinary when the data is not a BitSet encoded by MarshalBinary.
+var ErrInvalidEncoding = errors.New("invalid bitset encoding")
+
+// wordSize is the number of bits in a word.
+const wordSize = 64
+
+// BitSet is a set of uint32 values, holding value i as bit i%64 of word i/64. Its memory grows with the largest
+// value it ever held, not with the number of values, so it should hold values of a dense range.
+// The zero value is an empty set that is ready to use. A BitSet is not safe for concurrent use.
+type BitSet struct {
+	// words stores the bits; the words past the last set bit may be zero.
+	words []uint64
+}
+
+// New creates an empty set with room for the values below length, so that setting them does not allocate.
+func New(length uint32) *BitSet {
+	return &BitSet{words: make([]uint64, 0, wordsFor(length))}
+}
+
+// FromUint32s creates a set holding the values, with duplicates collapsed.
+func FromUint32s(values []uint32) *BitSet {
+	// Size the words for the largest value up front.
+	if len(values) == 0 {
+		return &BitSet{}
+	}
+	b := &BitSet{words: make([]uint64, int(slices.Max(values)/wordSize)+1)}
+
+	// Set the bit of every value.
+	for _, v := range values {
+		b.words[v/wordSize] |= 1 << (v % wordSize)
+	}
+	return b
+}
+
+// wordsFor returns the number of words holding the values below length.
+func wordsFor(length uint32) int {
+	return int((uint64(length) + wordSize - 1) / wordSize)
+}
+
+// Set adds the value to the set, growing it when needed.
+func (b *BitSet) Set(i uint32) {
+	w := int(i / wordSize)
+	if w >= len(b.words) {
+		b.words = append(b.words, make([]uint64, w+1-len(b.words))...)
+	}
+	b.words[w] |= 1 << (i % wordSize)
+}
+
+// Clear removes the value from the set. Clearing an absent value is a no-op.
+func (b *BitSet) Clear(i uint32) {
+	if w := int(i / wordSize); w < len(b.words) {
+		b.words[w] &^= 1 << (i % wordSize)
+	}
+}
+
+// Test reports whether the value is in the set.
+func (b *BitSet) Test(i uint32) bool {
+	w := int(i / wordSize)
+	return w < len(b.words) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// Count returns the number of values in the set.
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Empty reports whether the set holds no value.
+func (b *BitSet) Empty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset removes every value, keeping the memory for reuse.
+func (b *BitSet) Reset() {
+	b.words = b.words[:0]
+}
+
+// Clone returns a copy of the set.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: slices.Clone(b.trimmed())}
+}
+
+// Equal reports whether both sets hold the same values.
+func (b *BitSet) Equal(other *BitSet) bool {
+	return slices.Equal(b.trimmed(), other.trimmed())
+}
+
+// trimmed returns the words up to the last non-zero one.
+func (b *BitSet) trimmed() []uint64 {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	return b.words[:n]
+}
+
+// And returns a new set holding the values present in both sets.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	// Only the words both sets have can hold common values.
+	n := min(len(b.words), len(other.words))
+	result := &BitSet{words: make([]uint64, n)}
+	for i := range n {
+		result.words[i] = b.words[i] & other.words[i]
+	}
+	return result
+}
+
+// Or returns a new set holding the values present in either set.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	// Start from a copy of the longer set and merge the shorter one into it.
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	result := &BitSet{words: slices.Clone(long)}
+	for i, w := range short {
+		result.words[i] |= w
+	}
+	return result
+}
+
+// Xor returns a new set holding the values present in exactly one of the sets.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	// Start from a copy of the longer set and toggle the bits of the shorter one.
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	result := &BitSet{words: slices.Clone(long)}
+	for i, w := range short {
+		result.words[i] ^= w
+	}
+	return result
+}
+
+// AndNot returns a new set holding the values of the receiver that are not present in the other set.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	// Start from a copy of the receiver; the words past the end of the other set are kept whole.
+	result := &BitSet{words: slices.Clone(b.words)}
+	for i := range min(len(b.words), len(other.words)) {
+		result.words[i] &^= other.words[i]
+	}
+	return result
+}
+
+// NextSet returns the smallest value of the set that is greater than or equal to i, and false when there is none.
+// Iterating with NextSet(v+1) from NextSet(0) visits the values in increasing order, skipping 64 absent values
+// per step.
+func (b *BitSet) NextSet(i uint32) (uint32, bool) {
+	w := int(i / wordSize)
+	if w >= len(b.words) {
+		return 0, false
+	}
+
+	// Look in the rest of the first word, then for the next non-zero word.
+	if rest := b.words[w] >> (i % wordSize); rest != 0 {
+		return i + uint32(bits.TrailingZeros64(rest)), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return uint32(w*wordSize + bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// All returns an iterator over the values of the set in increasing order. The set must not be modified while
+// iterating.
+func (b *BitSet) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for w, word := range b.words {
+			// Yield the set bits of the word, lowest first, clearing each one in turn.
+			for word != 0 {
+				if !yield(uint32(w*wordSize + bits.TrailingZeros64(word))) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// Rank returns the number of values of the set strictly below i. It runs in O(i/64).
+func (b *BitSet) Rank(i uint32) int {
+	w := min(int(i/wordSize), len(b.words))
+	rank := 0
+	for _, word := range b.words[:w] {
+		rank += bits.OnesCount64(word)
+	}
+
+	// Count the bits of the partial word below i.
+	if w < len(b.words) {
+		rank += bits.OnesCount64(b.words[w] & (1<<(i%wordSize) - 1))
+	}
+	return rank
+}
+
+// Select returns the value of rank k, the k-th smallest value of the set counting from zero, and false when the
+// set holds k values or fewer. It is the inverse of Rank: Rank(v) == k for the returned value v. It runs in
+// O(v/64).
+func (b *BitSet) Select(k int) (uint32, bool) {
+	if k < 0 {
+		return 0, false
+	}
+
+	// Skip whole words until the one holding the value.
+	for w, word := range b.words {
+		count := bits.OnesCount64(word)
+		if k >= count {
+			k -= count
+			continue
+		}
+
+		// Clear the k lowest set bits of the word; the value is its lowest remaining bit.
+		for range k {
+			word &= word - 1
+		}
+		return uint32(w*wordSize + bits.TrailingZeros64(word)), true
+	}
+	return 0, false
+}
+
+// Uint32s returns the values of the set in increasing order, in a newly allocated slice that can be passed to the
+// functions of the slice package.
+func (b *BitSet) Uint32s() []uint32 {
+	return slices.AppendSeq(make([]uint32, 0, b.Count()), b.All())
+}
+
+// MarshalBinary encodes the set as a little-endian uint32 holding the number of words, followed by the words as
+// little-endian uint64 values, without the zero words past the largest value.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	words := b.trimmed()
+	data := make([]byte, 4, 4+8*len(words))
+	binary.LittleEndian.PutUint32(data, uint32(len(words)))
+	for _, w := range words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes data encoded by MarshalBinary into the set, replacing its previous content. It reports
+// ErrInvalidEncoding when the length of the data does not match its header.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return fmt.Errorf("%w: %d bytes is too short for the header", ErrInvalidEncoding, len(data))
+	}
+	n := binary.LittleEndian.Uint32(data)
+	if uint64(len(data)-4) != 8*uint64(n) {
+		return fmt.Errorf("%w: %d words need %d bytes, got %d", ErrInvalidEncoding, n, 8*uint64(n), len(data)-4)
+	}
+
+	// Decode the words, reusing the memory of the set when it is large enough.
+	words := slices.Grow(b.words[:0], int(n))
+	for rest := data[4:]; len(rest) > 0; rest = rest[8:] {
+		words = append(words, binary.LittleEndian.Uint64(rest))
+	}
+	b.words = words
+	return nil
+}
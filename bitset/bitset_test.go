@@ -0,0 +1,239 @@
+package bitset
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/spacemagneto/common/slice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSet(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases with the values set, across word boundaries and at the ends of the range.
+	cases := []struct {
+		name   string
+		values []uint32
+	}{
+		{name: "Empty", values: nil},
+		{name: "Zero", values: []uint32{0}},
+		{name: "Word boundaries", values: []uint32{63, 64, 127, 128}},
+		{name: "Duplicates", values: []uint32{5, 1, 5, 3, 1}},
+		{name: "Sparse", values: []uint32{1, 1000, 100000}},
+		{name: "Large", values: []uint32{0, 1<<20 - 1}},
+	}
+
+	// Iterate over the test cases, building the set both ways and checking every accessor.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expected := append([]uint32{}, slice.SortedDedup(slices.Sorted(slices.Values(tt.values)))...)
+
+			var b BitSet
+			for _, v := range tt.values {
+				b.Set(v)
+			}
+			assert.True(t, b.Equal(FromUint32s(tt.values)), "Set and FromUint32s agree for case %q", tt.name)
+			assert.Equal(t, expected, b.Uint32s(), "Values for case %q", tt.name)
+			assert.Equal(t, expected, append([]uint32{}, slices.Collect(b.All())...), "All for case %q", tt.name)
+			assert.Equal(t, len(expected), b.Count(), "Count for case %q", tt.name)
+			assert.Equal(t, len(expected) == 0, b.Empty(), "Empty for case %q", tt.name)
+
+			for _, v := range expected {
+				assert.True(t, b.Test(v), "Test(%d) for case %q", v, tt.name)
+				if v > 0 && !slices.Contains(expected, v-1) {
+					assert.False(t, b.Test(v-1), "Test(%d) for case %q", v-1, tt.name)
+				}
+			}
+
+			// Clearing every value leaves an empty set, equal to the zero value.
+			for _, v := range tt.values {
+				b.Clear(v)
+			}
+			assert.True(t, b.Empty(), "Empty after Clear for case %q", tt.name)
+			assert.True(t, b.Equal(&BitSet{}), "Equal to the zero value for case %q", tt.name)
+		})
+	}
+
+	// ClearAbsent tests that clearing values past the end does not grow the set.
+	t.Run("ClearAbsent", func(t *testing.T) {
+		t.Parallel()
+		b := New(64)
+		b.Clear(1 << 20)
+		assert.Empty(t, b.words, "Words after clearing an absent value")
+		assert.False(t, b.Test(1<<20), "Test of an absent value")
+	})
+
+	// CloneAndReset tests that a clone is independent and that Reset empties the set.
+	t.Run("CloneAndReset", func(t *testing.T) {
+		t.Parallel()
+		b := FromUint32s([]uint32{1, 2, 300})
+		c := b.Clone()
+		b.Reset()
+		assert.True(t, b.Empty(), "Empty after Reset")
+		assert.Equal(t, []uint32{1, 2, 300}, c.Uint32s(), "Clone after Reset of the original")
+	})
+}
+
+func TestAlgebra(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases with sets of different lengths, so that every operation handles missing words.
+	cases := []struct {
+		name        string
+		left, right []uint32
+	}{
+		{name: "Both empty", left: nil, right: nil},
+		{name: "Empty left", left: nil, right: []uint32{1, 70}},
+		{name: "Empty right", left: []uint32{1, 70}, right: nil},
+		{name: "Overlap", left: []uint32{1, 2, 3, 64}, right: []uint32{2, 3, 4, 65}},
+		{name: "Longer right", left: []uint32{1, 2}, right: []uint32{2, 500, 1000}},
+		{name: "Longer left", left: []uint32{2, 500, 1000}, right: []uint32{1, 2}},
+		{name: "Identical", left: []uint32{7, 77, 777}, right: []uint32{7, 77, 777}},
+	}
+
+	// Iterate over the test cases, checking every operation against the sorted slice helpers.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			left, right := FromUint32s(tt.left), FromUint32s(tt.right)
+			union := slice.SortedUnion(tt.left, tt.right)
+			intersection := slice.SortedIntersect(tt.left, tt.right)
+
+			assert.Equal(t, append([]uint32{}, intersection...), left.And(right).Uint32s(), "And for case %q", tt.name)
+			assert.Equal(t, append([]uint32{}, union...), left.Or(right).Uint32s(), "Or for case %q", tt.name)
+			assert.Equal(t, append([]uint32{}, slice.SortedDifference(union, intersection)...), left.Xor(right).Uint32s(), "Xor for case %q", tt.name)
+			assert.Equal(t, append([]uint32{}, slice.SortedDifference(tt.left, tt.right)...), left.AndNot(right).Uint32s(), "AndNot for case %q", tt.name)
+
+			// The operations leave their operands unchanged.
+			assert.Equal(t, append([]uint32{}, tt.left...), left.Uint32s(), "Left operand for case %q", tt.name)
+			assert.Equal(t, append([]uint32{}, tt.right...), right.Uint32s(), "Right operand for case %q", tt.name)
+		})
+	}
+}
+
+func TestNextSetRankSelect(t *testing.T) {
+	t.Parallel()
+
+	values := []uint32{0, 3, 63, 64, 200, 1 << 16}
+	b := FromUint32s(values)
+
+	// Define test cases probing NextSet on, between and past the values.
+	cases := []struct {
+		name     string
+		from     uint32
+		expected uint32
+		found    bool
+	}{
+		{name: "On the first value", from: 0, expected: 0, found: true},
+		{name: "Within a word", from: 1, expected: 3, found: true},
+		{name: "Last bit of a word", from: 4, expected: 63, found: true},
+		{name: "Across empty words", from: 65, expected: 200, found: true},
+		{name: "Last value", from: 201, expected: 1 << 16, found: true},
+		{name: "Past the last value", from: 1<<16 + 1, found: false},
+		{name: "Past the words", from: math.MaxUint32, found: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v, ok := b.NextSet(tt.from)
+			assert.Equal(t, tt.found, ok, "Found for case %q", tt.name)
+			assert.Equal(t, tt.expected, v, "Value for case %q", tt.name)
+		})
+	}
+
+	// RankAndSelect tests that Select inverts Rank for every value, and that Rank counts the values below.
+	t.Run("RankAndSelect", func(t *testing.T) {
+		t.Parallel()
+		for k, v := range values {
+			selected, ok := b.Select(k)
+			assert.True(t, ok, "Select(%d)", k)
+			assert.Equal(t, v, selected, "Select(%d)", k)
+			assert.Equal(t, k, b.Rank(v), "Rank(%d)", v)
+			assert.Equal(t, k+1, b.Rank(v+1), "Rank(%d)", v+1)
+		}
+		assert.Equal(t, len(values), b.Rank(math.MaxUint32), "Rank past the words")
+		_, ok := b.Select(len(values))
+		assert.False(t, ok, "Select past the last value")
+		_, ok = b.Select(-1)
+		assert.False(t, ok, "Select of a negative rank")
+	})
+
+	// Random tests Rank and Select against the sorted values of random sets.
+	t.Run("Random", func(t *testing.T) {
+		t.Parallel()
+		rng := rand.New(rand.NewPCG(1, 2))
+		for range 50 {
+			var b BitSet
+			for range rng.IntN(300) {
+				b.Set(rng.Uint32N(2000))
+			}
+			sorted := b.Uint32s()
+			for k, v := range sorted {
+				selected, _ := b.Select(k)
+				assert.Equal(t, v, selected, "Select(%d) of a random set", k)
+			}
+			for range 20 {
+				i := rng.Uint32N(2100)
+				expected, _ := slices.BinarySearch(sorted, i)
+				assert.Equal(t, expected, b.Rank(i), "Rank(%d) of a random set", i)
+			}
+		}
+	})
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases round-tripping sets through the binary encoding.
+	cases := []struct {
+		name          string
+		values        []uint32
+		expectedBytes int
+	}{
+		{name: "Empty", values: nil, expectedBytes: 4},
+		{name: "One word", values: []uint32{0, 63}, expectedBytes: 12},
+		{name: "Several words", values: []uint32{1, 129}, expectedBytes: 28},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := FromUint32s(tt.values)
+			// Trailing zero words are not encoded.
+			b.Set(1000)
+			b.Clear(1000)
+
+			data, err := b.MarshalBinary()
+			assert.NoError(t, err, "Marshal error for case %q", tt.name)
+			assert.Len(t, data, tt.expectedBytes, "Encoded length for case %q", tt.name)
+
+			decoded := FromUint32s([]uint32{5, 5000})
+			assert.NoError(t, decoded.UnmarshalBinary(data), "Unmarshal error for case %q", tt.name)
+			assert.True(t, b.Equal(decoded), "Round trip for case %q", tt.name)
+		})
+	}
+
+	// Layout tests the documented encoding byte by byte.
+	t.Run("Layout", func(t *testing.T) {
+		t.Parallel()
+		data, _ := FromUint32s([]uint32{0, 9, 64}).MarshalBinary()
+		expected := []byte{2, 0, 0, 0, 0x01, 0x02, 0, 0, 0, 0, 0, 0, 0x01, 0, 0, 0, 0, 0, 0, 0}
+		assert.Equal(t, expected, data, "Encoded bytes")
+	})
+
+	// Invalid tests that malformed data is rejected and leaves the set unchanged.
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, data := range [][]byte{nil, {1, 0}, {1, 0, 0, 0, 1, 2, 3}, {0, 0, 0, 0, 1}} {
+			b := FromUint32s([]uint32{42})
+			err := b.UnmarshalBinary(data)
+			assert.ErrorIs(t, err, ErrInvalidEncoding, "Error for data %v", data)
+			assert.Equal(t, []uint32{42}, b.Uint32s(), "Set after an error for data %v", data)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+module github.com/spacemagneto/common/bitset
+
+go 1.24.3
+
+require (
+	github.com/spacemagneto/common/slice v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20250911091902-df9299821621 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/spacemagneto/common/slice => ../slice
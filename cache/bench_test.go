@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The benchmarks in this file compare the caches with a plain map behind a mutex, which never evicts. Run them
+// with:
+//
+//	go test -run '^$' -bench . -benchmem ./cache
+
+// benchSizes are the numbers of distinct keys looked up, against caches holding 1024 entries.
+var benchSizes = []int{512, 4096}
+
+// mutexMap is the baseline: an unbounded map behind a mutex.
+type mutexMap struct {
+	mu    sync.Mutex
+	items map[int]int
+}
+
+// BenchmarkGetSet looks keys up and stores them on a miss, the read-through pattern the caches serve.
+func BenchmarkGetSet(b *testing.B) {
+	const capacity = 1024
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("LRU/keys=%d", size), func(b *testing.B) {
+			c := NewLRU[int, int](capacity)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, ok := c.Get(i % size); !ok {
+					c.Set(i%size, i)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("LFU/keys=%d", size), func(b *testing.B) {
+			c := NewLFU[int, int](capacity)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, ok := c.Get(i % size); !ok {
+					c.Set(i%size, i)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("TTL/keys=%d", size), func(b *testing.B) {
+			c := NewTTL[int, int](time.Hour)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, ok := c.Get(i % size); !ok {
+					c.Set(i%size, i)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("MutexMap/keys=%d", size), func(b *testing.B) {
+			m := &mutexMap{items: make(map[int]int)}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.mu.Lock()
+				if _, ok := m.items[i%size]; !ok {
+					m.items[i%size] = i
+				}
+				m.mu.Unlock()
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Package cache provides generic in-memory caches that are safe for concurrent use: LRU evicts the least
+// recently used entry, LFU the least frequently used one, and TTL expires entries after a time to live, lazily on
+// access and in the background. Every cache counts hits and misses, reports removed entries to an eviction
+// callback, and offers GetOrLoad, which runs a single load for concurrent misses of the same key.
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrLoaderPanicked is returned by GetOrLoad to the callers waiting for a load whose loader panicked. The caller
+// that ran the loader gets the panic itself.
+var ErrLoaderPanicked = errors.New("cache: loader panicked")
+
+// Clock tells the time to the caches that expire entries. Injecting a ManualClock makes expiry testable without
+// sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// systemClock is the default Clock, which reads the system time.
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a Clock that only moves when told to. It is safe for concurrent use.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock creates a clock stopped at the start time.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the duration.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to the time.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// EvictionReason tells the eviction callback why an entry left the cache.
+type EvictionReason int
+
+const (
+	// ReasonCapacity means the entry was evicted to make room for a new one.
+	ReasonCapacity EvictionReason = iota
+	// ReasonExpired means the time to live of the entry ran out.
+	ReasonExpired
+	// ReasonDeleted means the entry was removed by Delete or Clear.
+	ReasonDeleted
+)
+
+// String returns the lower-case name of the reason.
+func (r EvictionReason) String() string {
+	switch r {
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonExpired:
+		return "expired"
+	case ReasonDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("EvictionReason(%d)", int(r))
+	}
+}
+
+// Stats is a snapshot of the counters of a cache.
+type Stats struct {
+	// Hits counts the lookups that found an entry.
+	Hits uint64
+	// Misses counts the lookups that found no entry, including expired ones.
+	Misses uint64
+	// Evictions counts the entries evicted to make room for new ones.
+	Evictions uint64
+	// Expirations counts the entries removed because their time to live ran out.
+	Expirations uint64
+	// Loads counts the calls of the loaders of GetOrLoad, which is less than the misses when loads are shared.
+	Loads uint64
+	// LoadErrors counts the loads that returned an error.
+	LoadErrors uint64
+}
+
+// HitRatio returns the share of lookups that found an entry, or 0 before the first lookup.
+func (s Stats) HitRatio() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// counters holds the live counters behind Stats, updated without holding the lock of the cache.
+type counters struct {
+	hits, misses, evictions, expirations, loads, loadErrors atomic.Uint64
+}
+
+// snapshot reads every counter.
+func (c *counters) snapshot() Stats {
+	return Stats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Evictions:   c.evictions.Load(),
+		Expirations: c.expirations.Load(),
+		Loads:       c.loads.Load(),
+		LoadErrors:  c.loadErrors.Load(),
+	}
+}
+
+// record counts a lookup as a hit or a miss and passes its result through.
+func (c *counters) record(found bool) bool {
+	if found {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return found
+}
+
+// options holds the settings of a cache, as modified by the Option functions.
+type options[K comparable, V any] struct {
+	// onEvict is called for every entry that leaves the cache, or nil.
+	onEvict func(key K, value V, reason EvictionReason)
+	// clock tells the time for expiry.
+	clock Clock
+	// cleanupInterval is the period of the background expiry of TTL, or zero to only expire lazily.
+	cleanupInterval time.Duration
+}
+
+// Option configures a cache created by NewLRU, NewLFU or NewTTL.
+type Option[K comparable, V any] func(options[K, V]) options[K, V]
+
+// WithOnEvict sets a callback called for every entry that leaves the cache, with the reason it left. Replacing
+// the value of a live key does not call it. The callback runs after the cache released its lock, so it may use the
+// cache, but it delays the operation that removed the entry.
+func WithOnEvict[K comparable, V any](fn func(key K, value V, reason EvictionReason)) Option[K, V] {
+	return func(o options[K, V]) options[K, V] {
+		o.onEvict = fn
+		return o
+	}
+}
+
+// WithClock replaces the system clock used for expiry, which makes expiry testable with a ManualClock.
+// It only affects TTL caches.
+func WithClock[K comparable, V any](clock Clock) Option[K, V] {
+	return func(o options[K, V]) options[K, V] {
+		o.clock = clock
+		return o
+	}
+}
+
+// WithCleanupInterval makes a TTL cache remove its expired entries in the background at every interval, on top
+// of the lazy expiry on access. The background goroutine stops when the cache is closed. It only affects TTL
+// caches.
+func WithCleanupInterval[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(o options[K, V]) options[K, V] {
+		o.cleanupInterval = interval
+		return o
+	}
+}
+
+// buildOptions applies the options to the defaults.
+func buildOptions[K comparable, V any](opts []Option[K, V]) options[K, V] {
+	o := options[K, V]{clock: systemClock{}}
+	for _, opt := range opts {
+		o = opt(o)
+	}
+	return o
+}
+
+// eviction is an entry removed under the lock of a cache, reported to the callback once the lock is released.
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// notify counts the evictions and reports them to the callback. It must be called without holding the lock.
+func notify[K comparable, V any](o *options[K, V], c *counters, evictions []eviction[K, V]) {
+	for _, e := range evictions {
+		switch e.reason {
+		case ReasonCapacity:
+			c.evictions.Add(1)
+		case ReasonExpired:
+			c.expirations.Add(1)
+		}
+		if o.onEvict != nil {
+			o.onEvict(e.key, e.value, e.reason)
+		}
+	}
+}
+
+// loadCall is a load in flight, shared by every GetOrLoad call that missed the same key meanwhile.
+type loadCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// loadGroup deduplicates the concurrent loads of the same key.
+type loadGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*loadCall[V]
+}
+
+// getOrLoad implements GetOrLoad for every cache: it returns the cached value when get finds one, and otherwise
+// runs load once for all concurrent callers of the same key, stores its value with set, and shares the result.
+// peek looks the key up again without counting a lookup, for callers that missed just before a load finished.
+func getOrLoad[K comparable, V any](g *loadGroup[K, V], c *counters, key K, get, peek func(K) (V, bool), set func(K, V), load func(K) (V, error)) (V, error) {
+	if v, ok := get(key); ok {
+		return v, nil
+	}
+
+	// Join the load in flight for the key, or start one.
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	if v, ok := peek(key); ok {
+		// A load finished between the miss and the lock.
+		g.mu.Unlock()
+		return v, nil
+	}
+	if g.calls == nil {
+		g.calls = make(map[K]*loadCall[V])
+	}
+	call := &loadCall[V]{done: make(chan struct{}), err: ErrLoaderPanicked}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	// Release the waiters however the loader returns, including by panicking.
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	// Load the value and cache it on success; errors are not cached.
+	c.loads.Add(1)
+	value, err := load(key)
+	if err != nil {
+		c.loadErrors.Add(1)
+	} else {
+		set(key, value)
+	}
+	call.value, call.err = value, err
+	return value, err
+}
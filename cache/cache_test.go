@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	assert.Equal(t, start, clock.Now(), "Now of a new clock")
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now(), "Now after Advance")
+
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now(), "Now after Set")
+}
+
+func TestEvictionReasonString(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases covering every reason and an unknown one.
+	cases := []struct {
+		name     string
+		reason   EvictionReason
+		expected string
+	}{
+		{name: "Capacity", reason: ReasonCapacity, expected: "capacity"},
+		{name: "Expired", reason: ReasonExpired, expected: "expired"},
+		{name: "Deleted", reason: ReasonDeleted, expected: "deleted"},
+		{name: "Unknown", reason: EvictionReason(7), expected: "EvictionReason(7)"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.reason.String(), "String for case %q", tt.name)
+		})
+	}
+}
+
+func TestStatsHitRatio(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0.0, Stats{}.HitRatio(), "Ratio before any lookup")
+	assert.Equal(t, 0.75, Stats{Hits: 3, Misses: 1}.HitRatio(), "Ratio of three hits out of four")
+}
+
+// loader is the part of the caches exercised by the GetOrLoad tests.
+type loader interface {
+	GetOrLoad(key string, load func(string) (int, error)) (int, error)
+	Peek(key string) (int, bool)
+	Stats() Stats
+}
+
+func TestGetOrLoad(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases running the same scenarios against every cache.
+	cases := []struct {
+		name     string
+		newCache func() loader
+	}{
+		{name: "LRU", newCache: func() loader { return NewLRU[string, int](8) }},
+		{name: "LFU", newCache: func() loader { return NewLFU[string, int](8) }},
+		{name: "TTL", newCache: func() loader { return NewTTL[string, int](time.Hour) }},
+	}
+
+	for _, tt := range cases {
+		// Dedup tests that concurrent misses of the same key share a single load.
+		t.Run(tt.name+"/Dedup", func(t *testing.T) {
+			t.Parallel()
+			c := tt.newCache()
+			release := make(chan struct{})
+			var calls atomic.Int32
+			load := func(key string) (int, error) {
+				calls.Add(1)
+				<-release
+				return len(key), nil
+			}
+
+			const callers = 16
+			var started, wg sync.WaitGroup
+			results := make([]int, callers)
+			for i := range callers {
+				started.Add(1)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					started.Done()
+					v, err := c.GetOrLoad("four", load)
+					assert.NoError(t, err, "GetOrLoad error for case %q", tt.name)
+					results[i] = v
+				}()
+			}
+			started.Wait()
+			// Give the callers time to join the load before it finishes.
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			assert.Equal(t, int32(1), calls.Load(), "Loader calls for case %q", tt.name)
+			for i, v := range results {
+				assert.Equal(t, 4, v, "Result of caller %d for case %q", i, tt.name)
+			}
+			v, ok := c.Peek("four")
+			assert.True(t, ok, "Loaded key cached for case %q", tt.name)
+			assert.Equal(t, 4, v, "Cached value for case %q", tt.name)
+			assert.Equal(t, uint64(1), c.Stats().Loads, "Loads counted for case %q", tt.name)
+		})
+
+		// Hit tests that a cached key does not call the loader.
+		t.Run(tt.name+"/Hit", func(t *testing.T) {
+			t.Parallel()
+			c := tt.newCache()
+			_, _ = c.GetOrLoad("a", func(string) (int, error) { return 1, nil })
+			v, err := c.GetOrLoad("a", func(string) (int, error) {
+				t.Errorf("loader called on a hit for case %q", tt.name)
+				return 0, nil
+			})
+			assert.NoError(t, err, "GetOrLoad error for case %q", tt.name)
+			assert.Equal(t, 1, v, "Cached value for case %q", tt.name)
+			stats := c.Stats()
+			assert.Equal(t, uint64(1), stats.Hits, "Hits for case %q", tt.name)
+			assert.Equal(t, uint64(1), stats.Misses, "Misses for case %q", tt.name)
+		})
+
+		// Error tests that a failed load is returned and not cached.
+		t.Run(tt.name+"/Error", func(t *testing.T) {
+			t.Parallel()
+			c := tt.newCache()
+			errLoad := errors.New("unavailable")
+			_, err := c.GetOrLoad("a", func(string) (int, error) { return 0, errLoad })
+			assert.ErrorIs(t, err, errLoad, "Load error for case %q", tt.name)
+			_, ok := c.Peek("a")
+			assert.False(t, ok, "Failed load cached for case %q", tt.name)
+
+			v, err := c.GetOrLoad("a", func(string) (int, error) { return 2, nil })
+			assert.NoError(t, err, "Retry error for case %q", tt.name)
+			assert.Equal(t, 2, v, "Retry value for case %q", tt.name)
+			assert.Equal(t, uint64(1), c.Stats().LoadErrors, "Load errors for case %q", tt.name)
+		})
+
+		// Panic tests that the waiters of a panicking loader get ErrLoaderPanicked while the panic reaches the
+		// caller that ran it.
+		t.Run(tt.name+"/Panic", func(t *testing.T) {
+			t.Parallel()
+			c := tt.newCache()
+			entered, release := make(chan struct{}), make(chan struct{})
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() { panicked <- recover() }()
+				_, _ = c.GetOrLoad("a", func(string) (int, error) {
+					close(entered)
+					<-release
+					panic("boom")
+				})
+			}()
+			<-entered
+
+			waiterErr := make(chan error, 1)
+			go func() {
+				_, err := c.GetOrLoad("a", func(string) (int, error) { return 0, nil })
+				waiterErr <- err
+			}()
+			// Wait for the waiter to miss and give it time to join the load.
+			assert.Eventually(t, func() bool { return c.Stats().Misses == 2 }, time.Second, time.Millisecond)
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+
+			assert.Equal(t, "boom", <-panicked, "Panic of the loader for case %q", tt.name)
+			assert.ErrorIs(t, <-waiterErr, ErrLoaderPanicked, "Waiter error for case %q", tt.name)
+			_, ok := c.Peek("a")
+			assert.False(t, ok, "Panicked load cached for case %q", tt.name)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// LFU is a cache holding at most a fixed number of entries, which evicts the least frequently used entry to
+// make room for a new one, and the least recently used among entries used equally often. Get, Set and Delete run
+// in O(1): the entries are kept in buckets of equal use counts, ordered by count. An LFU must be created with
+// NewLFU and is safe for concurrent use.
+type LFU[K comparable, V any] struct {
+	mu sync.Mutex
+	// capacity is the maximum number of entries.
+	capacity int
+	// buckets lists the buckets by ascending use count; every element holds a *lfuBucket.
+	buckets *list.List
+	// items indexes the entries by key.
+	items map[K]*lfuEntry[K, V]
+
+	options  options[K, V]
+	counters counters
+	loads    loadGroup[K, V]
+}
+
+// lfuBucket holds the entries used the same number of times, from the most to the least recently used.
+type lfuBucket struct {
+	count   int
+	entries *list.List
+}
+
+// lfuEntry is an entry of an LFU.
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	// bucket is the element of LFU.buckets holding the bucket of the entry.
+	bucket *list.Element
+	// element is the element of the entries of the bucket holding the entry.
+	element *list.Element
+}
+
+// NewLFU creates an empty LFU cache holding at most capacity entries. It panics when the capacity is not
+// positive.
+func NewLFU[K comparable, V any](capacity int, opts ...Option[K, V]) *LFU[K, V] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("cache: NewLFU capacity must be positive, got %d", capacity))
+	}
+	return &LFU[K, V]{
+		capacity: capacity,
+		buckets:  list.New(),
+		items:    make(map[K]*lfuEntry[K, V], capacity),
+		options:  buildOptions(opts),
+	}
+}
+
+// Get returns the value of the key and true, counting a use of the entry, or the zero value and false when the
+// key is absent. It counts a hit or a miss.
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !c.counters.record(ok) {
+		var zero V
+		return zero, false
+	}
+	c.touch(e)
+	return e.value, true
+}
+
+// Peek returns the value of the key like Get, without counting a use of the entry or a lookup.
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores the value under the key. Replacing the value of a present key counts as a use; a new key starts
+// with a single use, after evicting the least frequently used entry when the cache is full.
+func (c *LFU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	evicted := c.set(key, value)
+	c.mu.Unlock()
+	notify(&c.options, &c.counters, evicted)
+}
+
+// set stores the entry and returns the evicted one, if any. It must be called with the lock held.
+func (c *LFU[K, V]) set(key K, value V) []eviction[K, V] {
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(e)
+		return nil
+	}
+
+	// Make room by evicting the least recently used entry of the lowest bucket.
+	var evicted []eviction[K, V]
+	if len(c.items) >= c.capacity {
+		bucket := c.buckets.Front().Value.(*lfuBucket)
+		victim := bucket.entries.Back().Value.(*lfuEntry[K, V])
+		c.unlink(victim)
+		evicted = append(evicted, eviction[K, V]{key: victim.key, value: victim.value, reason: ReasonCapacity})
+	}
+
+	// Add the entry to the bucket of single uses, creating it at the front when needed.
+	front := c.buckets.Front()
+	if front == nil || front.Value.(*lfuBucket).count != 1 {
+		front = c.buckets.PushFront(&lfuBucket{count: 1, entries: list.New()})
+	}
+	e := &lfuEntry[K, V]{key: key, value: value, bucket: front}
+	e.element = front.Value.(*lfuBucket).entries.PushFront(e)
+	c.items[key] = e
+	return evicted
+}
+
+// touch moves the entry to the bucket of the next use count, creating it when needed.
+func (c *LFU[K, V]) touch(e *lfuEntry[K, V]) {
+	current := e.bucket
+	count := current.Value.(*lfuBucket).count + 1
+
+	next := current.Next()
+	if next == nil || next.Value.(*lfuBucket).count != count {
+		next = c.buckets.InsertAfter(&lfuBucket{count: count, entries: list.New()}, current)
+	}
+
+	// Move the entry, then drop the bucket it left if it is empty.
+	current.Value.(*lfuBucket).entries.Remove(e.element)
+	e.bucket = next
+	e.element = next.Value.(*lfuBucket).entries.PushFront(e)
+	if current.Value.(*lfuBucket).entries.Len() == 0 {
+		c.buckets.Remove(current)
+	}
+}
+
+// unlink removes the entry from its bucket and the index, dropping the bucket if it becomes empty.
+func (c *LFU[K, V]) unlink(e *lfuEntry[K, V]) {
+	bucket := e.bucket.Value.(*lfuBucket)
+	bucket.entries.Remove(e.element)
+	if bucket.entries.Len() == 0 {
+		c.buckets.Remove(e.bucket)
+	}
+	delete(c.items, e.key)
+}
+
+// Delete removes the key and reports whether it was present.
+func (c *LFU[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	e, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	c.unlink(e)
+	c.mu.Unlock()
+
+	notify(&c.options, &c.counters, []eviction[K, V]{{key: e.key, value: e.value, reason: ReasonDeleted}})
+	return true
+}
+
+// Clear removes every entry. The counters are kept.
+func (c *LFU[K, V]) Clear() {
+	c.mu.Lock()
+	evicted := make([]eviction[K, V], 0, len(c.items))
+	for _, e := range c.items {
+		evicted = append(evicted, eviction[K, V]{key: e.key, value: e.value, reason: ReasonDeleted})
+	}
+	c.buckets.Init()
+	clear(c.items)
+	c.mu.Unlock()
+	notify(&c.options, &c.counters, evicted)
+}
+
+// Len returns the number of entries.
+func (c *LFU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Frequency returns the number of uses of the key, or 0 when it is absent.
+func (c *LFU[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		return e.bucket.Value.(*lfuBucket).count
+	}
+	return 0
+}
+
+// Stats returns a snapshot of the counters.
+func (c *LFU[K, V]) Stats() Stats {
+	return c.counters.snapshot()
+}
+
+// GetOrLoad returns the value of the key, calling load to produce and cache it on a miss. Concurrent calls that
+// miss the same key share a single call of load and its result. Errors are returned to every caller sharing the
+// load and are not cached.
+func (c *LFU[K, V]) GetOrLoad(key K, load func(K) (V, error)) (V, error) {
+	return getOrLoad(&c.loads, &c.counters, key, c.Get, c.Peek, c.Set, load)
+}
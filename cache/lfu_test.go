@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFU(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "cache: NewLFU capacity must be positive, got -1", func() { NewLFU[string, int](-1) }, "LFU with a negative capacity")
+
+	// Define test cases that run operations against an LFU of capacity two; "+k" sets k, "?k" gets it and "-k"
+	// deletes it.
+	cases := []struct {
+		name            string
+		ops             []string
+		expectedPresent []string
+		expectedEvicted []string
+	}{
+		{name: "Below capacity", ops: []string{"+a", "+b"}, expectedPresent: []string{"a", "b"}},
+		{name: "Evicts the least used", ops: []string{"+a", "+b", "?a", "+c"}, expectedPresent: []string{"a", "c"}, expectedEvicted: []string{"b:capacity"}},
+		{name: "Ties evict the oldest", ops: []string{"+a", "+b", "+c"}, expectedPresent: []string{"b", "c"}, expectedEvicted: []string{"a:capacity"}},
+		{name: "Ties follow use order", ops: []string{"+a", "+b", "?b", "?a", "+c"}, expectedPresent: []string{"a", "c"}, expectedEvicted: []string{"b:capacity"}},
+		{name: "Set counts as a use", ops: []string{"+a", "+b", "+a", "+c"}, expectedPresent: []string{"a", "c"}, expectedEvicted: []string{"b:capacity"}},
+		{name: "New keys replace each other", ops: []string{"+a", "?a", "?a", "+b", "+c"}, expectedPresent: []string{"a", "c"}, expectedEvicted: []string{"b:capacity"}},
+		{name: "Delete frees room", ops: []string{"+a", "?a", "+b", "-a", "+c"}, expectedPresent: []string{"b", "c"}, expectedEvicted: []string{"a:deleted"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var r recorder
+			c := NewLFU(2, WithOnEvict(r.onEvict))
+			for i, op := range tt.ops {
+				switch key := op[1:]; op[0] {
+				case '+':
+					c.Set(key, i)
+				case '?':
+					c.Get(key)
+				case '-':
+					c.Delete(key)
+				}
+			}
+			for _, key := range tt.expectedPresent {
+				_, ok := c.Peek(key)
+				assert.True(t, ok, "Key %q present for case %q", key, tt.name)
+			}
+			assert.Equal(t, tt.expectedEvicted, r.evicted(), "Evictions for case %q", tt.name)
+			assert.Equal(t, len(tt.expectedPresent), c.Len(), "Length for case %q", tt.name)
+		})
+	}
+
+	// Frequency tests that gets and sets count uses, and that Peek does not.
+	t.Run("Frequency", func(t *testing.T) {
+		t.Parallel()
+		c := NewLFU[string, int](2)
+		assert.Equal(t, 0, c.Frequency("a"), "Frequency of an absent key")
+		c.Set("a", 1)
+		assert.Equal(t, 1, c.Frequency("a"), "Frequency of a new key")
+		c.Get("a")
+		c.Set("a", 2)
+		c.Peek("a")
+		assert.Equal(t, 3, c.Frequency("a"), "Frequency after a get and a set")
+		v, _ := c.Get("a")
+		assert.Equal(t, 2, v, "Replaced value")
+	})
+
+	// Clear tests that every entry is reported and that the cache is usable again.
+	t.Run("Clear", func(t *testing.T) {
+		t.Parallel()
+		var r recorder
+		c := NewLFU(2, WithOnEvict(r.onEvict))
+		c.Set("a", 1)
+		c.Get("a")
+		c.Clear()
+		assert.Equal(t, 0, c.Len(), "Length after Clear")
+		assert.Equal(t, []string{"a:deleted"}, r.evicted(), "Evictions of Clear")
+		c.Set("b", 1)
+		assert.Equal(t, 1, c.Frequency("b"), "Frequency after Clear")
+	})
+
+	// Concurrent tests that parallel use keeps the capacity and counts every lookup.
+	t.Run("Concurrent", func(t *testing.T) {
+		t.Parallel()
+		c := NewLFU[string, int](16)
+		var wg sync.WaitGroup
+		for g := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 200 {
+					key := fmt.Sprint((g + i) % 32)
+					if _, ok := c.Get(key); !ok {
+						c.Set(key, i)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		stats := c.Stats()
+		assert.Equal(t, 16, c.Len(), "Length after concurrent use")
+		assert.Equal(t, uint64(8*200), stats.Hits+stats.Misses, "Lookups after concurrent use")
+	})
+}
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// LRU is a cache holding at most a fixed number of entries, which evicts the least recently used entry to make
+// room for a new one. Get, Set and Delete run in O(1). An LRU must be created with NewLRU and is safe for
+// concurrent use.
+type LRU[K comparable, V any] struct {
+	mu sync.Mutex
+	// capacity is the maximum number of entries.
+	capacity int
+	// order lists the entries from the most to the least recently used; every element holds a *lruEntry.
+	order *list.List
+	// items indexes the elements of order by key.
+	items map[K]*list.Element
+
+	options  options[K, V]
+	counters counters
+	loads    loadGroup[K, V]
+}
+
+// lruEntry is an entry of an LRU.
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU creates an empty LRU cache holding at most capacity entries. It panics when the capacity is not
+// positive.
+func NewLRU[K comparable, V any](capacity int, opts ...Option[K, V]) *LRU[K, V] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("cache: NewLRU capacity must be positive, got %d", capacity))
+	}
+	return &LRU[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[K]*list.Element, capacity),
+		options:  buildOptions(opts),
+	}
+}
+
+// Get returns the value of the key and true, marking the entry as the most recently used, or the zero value and
+// false when the key is absent. It counts a hit or a miss.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !c.counters.record(ok) {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+// Peek returns the value of the key like Get, without marking the entry as used or counting a lookup.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		return e.Value.(*lruEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores the value under the key as the most recently used entry, evicting the least recently used entry
+// when the cache is full.
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	evicted := c.set(key, value)
+	c.mu.Unlock()
+	notify(&c.options, &c.counters, evicted)
+}
+
+// set stores the entry and returns the evicted one, if any. It must be called with the lock held.
+func (c *LRU[K, V]) set(key K, value V) []eviction[K, V] {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(e)
+		return nil
+	}
+
+	// Make room by evicting the least recently used entry.
+	var evicted []eviction[K, V]
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Remove(c.order.Back()).(*lruEntry[K, V])
+		delete(c.items, oldest.key)
+		evicted = append(evicted, eviction[K, V]{key: oldest.key, value: oldest.value, reason: ReasonCapacity})
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	return evicted
+}
+
+// Delete removes the key and reports whether it was present.
+func (c *LRU[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	e, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	entry := c.order.Remove(e).(*lruEntry[K, V])
+	delete(c.items, key)
+	c.mu.Unlock()
+
+	notify(&c.options, &c.counters, []eviction[K, V]{{key: entry.key, value: entry.value, reason: ReasonDeleted}})
+	return true
+}
+
+// Clear removes every entry. The counters are kept.
+func (c *LRU[K, V]) Clear() {
+	c.mu.Lock()
+	evicted := make([]eviction[K, V], 0, c.order.Len())
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*lruEntry[K, V])
+		evicted = append(evicted, eviction[K, V]{key: entry.key, value: entry.value, reason: ReasonDeleted})
+	}
+	c.order.Init()
+	clear(c.items)
+	c.mu.Unlock()
+	notify(&c.options, &c.counters, evicted)
+}
+
+// Len returns the number of entries.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Keys returns the keys from the most to the least recently used.
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, len(c.items))
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*lruEntry[K, V]).key)
+	}
+	return keys
+}
+
+// Stats returns a snapshot of the counters.
+func (c *LRU[K, V]) Stats() Stats {
+	return c.counters.snapshot()
+}
+
+// GetOrLoad returns the value of the key, calling load to produce and cache it on a miss. Concurrent calls that
+// miss the same key share a single call of load and its result. Errors are returned to every caller sharing the
+// load and are not cached.
+func (c *LRU[K, V]) GetOrLoad(key K, load func(K) (V, error)) (V, error) {
+	return getOrLoad(&c.loads, &c.counters, key, c.Get, c.Peek, c.Set, load)
+}
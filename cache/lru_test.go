@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder collects the calls of an eviction callback.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+// onEvict records the key and the reason of an eviction.
+func (r *recorder) onEvict(key string, _ int, reason EvictionReason) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, key+":"+reason.String())
+}
+
+// evicted returns the recorded evictions.
+func (r *recorder) evicted() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+func TestLRU(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "cache: NewLRU capacity must be positive, got 0", func() { NewLRU[string, int](0) }, "LRU with a zero capacity")
+
+	// Define test cases that run operations against an LRU of capacity two; "+k" sets k, "?k" gets it and "-k"
+	// deletes it.
+	cases := []struct {
+		name            string
+		ops             []string
+		expectedKeys    []string
+		expectedEvicted []string
+	}{
+		{name: "Empty", ops: nil, expectedKeys: []string{}},
+		{name: "Below capacity", ops: []string{"+a", "+b"}, expectedKeys: []string{"b", "a"}},
+		{name: "Evicts the oldest", ops: []string{"+a", "+b", "+c"}, expectedKeys: []string{"c", "b"}, expectedEvicted: []string{"a:capacity"}},
+		{name: "Get refreshes", ops: []string{"+a", "+b", "?a", "+c"}, expectedKeys: []string{"c", "a"}, expectedEvicted: []string{"b:capacity"}},
+		{name: "Set refreshes", ops: []string{"+a", "+b", "+a", "+c"}, expectedKeys: []string{"c", "a"}, expectedEvicted: []string{"b:capacity"}},
+		{name: "Delete frees room", ops: []string{"+a", "+b", "-a", "+c"}, expectedKeys: []string{"c", "b"}, expectedEvicted: []string{"a:deleted"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var r recorder
+			c := NewLRU(2, WithOnEvict(r.onEvict))
+			for i, op := range tt.ops {
+				switch key := op[1:]; op[0] {
+				case '+':
+					c.Set(key, i)
+				case '?':
+					c.Get(key)
+				case '-':
+					c.Delete(key)
+				}
+			}
+			assert.Equal(t, tt.expectedKeys, c.Keys(), "Keys for case %q", tt.name)
+			assert.Equal(t, tt.expectedEvicted, r.evicted(), "Evictions for case %q", tt.name)
+			assert.Equal(t, len(tt.expectedKeys), c.Len(), "Length for case %q", tt.name)
+		})
+	}
+
+	// Stats tests that lookups and evictions are counted, and that Peek is not.
+	t.Run("Stats", func(t *testing.T) {
+		t.Parallel()
+		c := NewLRU[string, int](1)
+		c.Set("a", 1)
+		v, ok := c.Get("a")
+		assert.True(t, ok, "Get of a present key")
+		assert.Equal(t, 1, v, "Value of a present key")
+		_, ok = c.Get("b")
+		assert.False(t, ok, "Get of an absent key")
+		c.Peek("a")
+		c.Set("b", 2)
+		assert.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 1}, c.Stats(), "Stats")
+	})
+
+	// Clear tests that every entry is reported as deleted.
+	t.Run("Clear", func(t *testing.T) {
+		t.Parallel()
+		var r recorder
+		c := NewLRU(4, WithOnEvict(r.onEvict))
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Clear()
+		assert.Equal(t, 0, c.Len(), "Length after Clear")
+		assert.Equal(t, []string{"b:deleted", "a:deleted"}, r.evicted(), "Evictions of Clear")
+		assert.False(t, c.Delete("a"), "Delete after Clear")
+	})
+
+	// Reentrant tests that the callback may use the cache.
+	t.Run("Reentrant", func(t *testing.T) {
+		t.Parallel()
+		var c *LRU[string, int]
+		c = NewLRU(1, WithOnEvict(func(key string, value int, _ EvictionReason) {
+			c.Len()
+		}))
+		c.Set("a", 1)
+		c.Set("b", 2)
+		assert.Equal(t, []string{"b"}, c.Keys(), "Keys after a reentrant eviction")
+	})
+}
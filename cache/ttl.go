@@ -0,0 +1,244 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spacemagneto/common/heap"
+)
+
+// TTL is a cache whose entries expire after a time to live. Expired entries are removed lazily when they are
+// looked up, by DeleteExpired, and in the background when the cache is created with WithCleanupInterval. The
+// entries are kept in a heap ordered by expiry, so removing the expired ones costs O(log n) each, whatever the
+// size of the cache. A TTL must be created with NewTTL and is safe for concurrent use; call Close to stop the
+// background expiry.
+type TTL[K comparable, V any] struct {
+	mu sync.Mutex
+	// ttl is the time to live of the entries stored with Set.
+	ttl time.Duration
+	// items indexes the entries by key.
+	items map[K]*heap.Handle[*ttlEntry[K, V]]
+	// expiry orders the entries by expiry time.
+	expiry *heap.Heap[*ttlEntry[K, V]]
+
+	options  options[K, V]
+	counters counters
+	loads    loadGroup[K, V]
+
+	// stop ends the background expiry, and stopped waits for its goroutine to return.
+	stop      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// ttlEntry is an entry of a TTL cache.
+type ttlEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// NewTTL creates an empty cache whose entries live for the ttl. With WithCleanupInterval it also starts a
+// goroutine that removes the expired entries periodically, until Close is called. It panics when the ttl is not
+// positive.
+func NewTTL[K comparable, V any](ttl time.Duration, opts ...Option[K, V]) *TTL[K, V] {
+	if ttl <= 0 {
+		panic(fmt.Sprintf("cache: NewTTL ttl must be positive, got %s", ttl))
+	}
+	c := &TTL[K, V]{
+		ttl:   ttl,
+		items: make(map[K]*heap.Handle[*ttlEntry[K, V]]),
+		expiry: heap.New(func(a, b *ttlEntry[K, V]) bool {
+			return a.expires.Before(b.expires)
+		}),
+		options: buildOptions(opts),
+	}
+
+	// Start the background expiry.
+	if interval := c.options.cleanupInterval; interval > 0 {
+		c.stop, c.stopped = make(chan struct{}), make(chan struct{})
+		go c.cleanup(interval)
+	}
+	return c
+}
+
+// cleanup removes the expired entries at every tick until the cache is closed.
+func (c *TTL[K, V]) cleanup(interval time.Duration) {
+	defer close(c.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Close stops the background expiry and waits for it to return. The cache stays usable with lazy expiry.
+// Calling Close more than once, or on a cache without background expiry, is a no-op.
+func (c *TTL[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		if c.stop != nil {
+			close(c.stop)
+			<-c.stopped
+		}
+	})
+}
+
+// Get returns the value of the key and true, or the zero value and false when the key is absent or expired.
+// An expired entry is removed on the spot. It counts a hit or a miss.
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	v, ok, evicted := c.lookup(key)
+	notify(&c.options, &c.counters, evicted)
+	c.counters.record(ok)
+	return v, ok
+}
+
+// Peek returns the value of the key like Get, without counting a lookup.
+func (c *TTL[K, V]) Peek(key K) (V, bool) {
+	v, ok, evicted := c.lookup(key)
+	notify(&c.options, &c.counters, evicted)
+	return v, ok
+}
+
+// lookup finds the live entry of the key, removing it when it expired.
+func (c *TTL[K, V]) lookup(key K) (V, bool, []eviction[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero V
+	handle, ok := c.items[key]
+	if !ok {
+		return zero, false, nil
+	}
+	entry := handle.Value()
+	if c.options.clock.Now().Before(entry.expires) {
+		return entry.value, true, nil
+	}
+
+	// Expire the entry lazily.
+	c.expiry.Remove(handle)
+	delete(c.items, key)
+	return zero, false, []eviction[K, V]{{key: key, value: entry.value, reason: ReasonExpired}}
+}
+
+// TTL returns the time the entry of the key has left to live and true, or zero and false when the key is absent
+// or expired.
+func (c *TTL[K, V]) TTL(key K) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	handle, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	left := handle.Value().expires.Sub(c.options.clock.Now())
+	if left <= 0 {
+		return 0, false
+	}
+	return left, true
+}
+
+// Set stores the value under the key with the time to live of the cache, replacing any previous entry and
+// resetting its expiry.
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores the value under the key with its own time to live. A ttl that is not positive stores an
+// entry that is already expired. Replacing an entry that expired but was not removed yet counts as its
+// expiration, as if the expiry had removed it first.
+func (c *TTL[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	now := c.options.clock.Now()
+	entry := &ttlEntry[K, V]{key: key, value: value, expires: now.Add(ttl)}
+	handle, ok := c.items[key]
+	if !ok {
+		c.items[key] = c.expiry.Push(entry)
+		c.mu.Unlock()
+		return
+	}
+
+	// Record the expiration of the previous entry before overwriting it.
+	var evicted []eviction[K, V]
+	if previous := handle.Value(); !now.Before(previous.expires) {
+		evicted = []eviction[K, V]{{key: key, value: previous.value, reason: ReasonExpired}}
+	}
+	c.expiry.Update(handle, entry)
+	c.mu.Unlock()
+
+	notify(&c.options, &c.counters, evicted)
+}
+
+// Delete removes the key and reports whether it was present, expired or not.
+func (c *TTL[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	handle, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	c.expiry.Remove(handle)
+	delete(c.items, key)
+	c.mu.Unlock()
+
+	entry := handle.Value()
+	notify(&c.options, &c.counters, []eviction[K, V]{{key: entry.key, value: entry.value, reason: ReasonDeleted}})
+	return true
+}
+
+// DeleteExpired removes every expired entry and returns how many it removed. The background expiry calls it
+// periodically; it is exported for callers that drive the expiry themselves, such as tests with a ManualClock.
+func (c *TTL[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	now := c.options.clock.Now()
+	var evicted []eviction[K, V]
+
+	// The root of the heap expires first, so stop at the first entry that is still alive.
+	for {
+		entry, ok := c.expiry.Peek()
+		if !ok || now.Before(entry.expires) {
+			break
+		}
+		c.expiry.Pop()
+		delete(c.items, entry.key)
+		evicted = append(evicted, eviction[K, V]{key: entry.key, value: entry.value, reason: ReasonExpired})
+	}
+	c.mu.Unlock()
+
+	notify(&c.options, &c.counters, evicted)
+	return len(evicted)
+}
+
+// Clear removes every entry. The counters are kept.
+func (c *TTL[K, V]) Clear() {
+	c.mu.Lock()
+	evicted := make([]eviction[K, V], 0, len(c.items))
+	for entry := range c.expiry.Drain() {
+		evicted = append(evicted, eviction[K, V]{key: entry.key, value: entry.value, reason: ReasonDeleted})
+	}
+	clear(c.items)
+	c.mu.Unlock()
+	notify(&c.options, &c.counters, evicted)
+}
+
+// Len returns the number of entries, including the expired ones that were not removed yet.
+func (c *TTL[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Stats returns a snapshot of the counters.
+func (c *TTL[K, V]) Stats() Stats {
+	return c.counters.snapshot()
+}
+
+// GetOrLoad returns the value of the key, calling load to produce and cache it with the time to live of the
+// cache on a miss. Concurrent calls that miss the same key share a single call of load and its result. Errors
+// are returned to every caller sharing the load and are not cached.
+func (c *TTL[K, V]) GetOrLoad(key K, load func(K) (V, error)) (V, error) {
+	return getOrLoad(&c.loads, &c.counters, key, c.Get, c.Peek, c.Set, load)
+}
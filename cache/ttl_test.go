@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTL(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "cache: NewTTL ttl must be positive, got 0s", func() { NewTTL[string, int](0) }, "TTL with a zero time to live")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Define test cases that store keys, move the clock and check which keys are still alive.
+	cases := []struct {
+		name          string
+		ttls          map[string]time.Duration
+		elapsed       time.Duration
+		expectedAlive []string
+		expectedDead  []string
+	}{
+		{name: "Fresh", ttls: map[string]time.Duration{"a": 0}, elapsed: 59 * time.Second, expectedAlive: []string{"a"}},
+		{name: "Expires at the deadline", ttls: map[string]time.Duration{"a": 0}, elapsed: time.Minute, expectedDead: []string{"a"}},
+		{name: "Own TTLs", ttls: map[string]time.Duration{"a": time.Second, "b": time.Hour, "c": 0}, elapsed: 2 * time.Second, expectedAlive: []string{"b", "c"}, expectedDead: []string{"a"}},
+		{name: "Non-positive TTL", ttls: map[string]time.Duration{"a": -time.Second}, elapsed: 0, expectedDead: []string{"a"}},
+	}
+
+	for _, tt := range cases {
+		// Lazy tests that lookups expire the entries.
+		t.Run(tt.name+"/Lazy", func(t *testing.T) {
+			t.Parallel()
+			clock := NewManualClock(start)
+			var r recorder
+			c := NewTTL(time.Minute, WithClock[string, int](clock), WithOnEvict(r.onEvict))
+			for key, ttl := range tt.ttls {
+				if ttl == 0 {
+					c.Set(key, 1)
+				} else {
+					c.SetWithTTL(key, 1, ttl)
+				}
+			}
+			clock.Advance(tt.elapsed)
+
+			for _, key := range tt.expectedAlive {
+				_, ok := c.Get(key)
+				assert.True(t, ok, "Key %q alive for case %q", key, tt.name)
+			}
+			for _, key := range tt.expectedDead {
+				_, ok := c.Get(key)
+				assert.False(t, ok, "Key %q expired for case %q", key, tt.name)
+			}
+			assert.Equal(t, len(tt.expectedAlive), c.Len(), "Length for case %q", tt.name)
+			assert.Len(t, r.evicted(), len(tt.expectedDead), "Evictions for case %q", tt.name)
+			assert.Equal(t, uint64(len(tt.expectedDead)), c.Stats().Expirations, "Expirations for case %q", tt.name)
+		})
+
+		// DeleteExpired tests that the sweep removes exactly the expired entries.
+		t.Run(tt.name+"/DeleteExpired", func(t *testing.T) {
+			t.Parallel()
+			clock := NewManualClock(start)
+			c := NewTTL[string, int](time.Minute, WithClock[string, int](clock))
+			for key, ttl := range tt.ttls {
+				if ttl == 0 {
+					c.Set(key, 1)
+				} else {
+					c.SetWithTTL(key, 1, ttl)
+				}
+			}
+			clock.Advance(tt.elapsed)
+
+			assert.Equal(t, len(tt.expectedDead), c.DeleteExpired(), "Removed entries for case %q", tt.name)
+			assert.Equal(t, len(tt.expectedAlive), c.Len(), "Length for case %q", tt.name)
+			for _, key := range tt.expectedAlive {
+				_, ok := c.Peek(key)
+				assert.True(t, ok, "Key %q alive for case %q", key, tt.name)
+			}
+		})
+	}
+
+	// Reset tests that setting a key again restarts its time to live.
+	t.Run("Reset", func(t *testing.T) {
+		t.Parallel()
+		clock := NewManualClock(start)
+		c := NewTTL[string, int](time.Minute, WithClock[string, int](clock))
+		c.Set("a", 1)
+		c.SetWithTTL("b", 1, time.Hour)
+		clock.Advance(50 * time.Second)
+		c.Set("a", 2)
+		c.SetWithTTL("b", 2, time.Second)
+		clock.Advance(50 * time.Second)
+
+		v, ok := c.Get("a")
+		assert.True(t, ok, "Reset key alive")
+		assert.Equal(t, 2, v, "Reset value")
+		left, ok := c.TTL("a")
+		assert.True(t, ok, "TTL of a live key")
+		assert.Equal(t, 10*time.Second, left, "Time left of a live key")
+		_, ok = c.TTL("b")
+		assert.False(t, ok, "TTL of a shortened key")
+		assert.Equal(t, 1, c.DeleteExpired(), "Shortened key swept")
+	})
+
+	// OverwriteExpired tests that setting a key whose entry expired but was not swept yet reports the expiration,
+	// while replacing a live entry reports nothing.
+	t.Run("OverwriteExpired", func(t *testing.T) {
+		t.Parallel()
+		var r recorder
+		clock := NewManualClock(start)
+		c := NewTTL(time.Minute, WithClock[string, int](clock), WithOnEvict(r.onEvict))
+		c.Set("a", 1)
+		c.Set("b", 1)
+		clock.Advance(30 * time.Second)
+		c.Set("b", 2)
+		clock.Advance(time.Minute)
+		c.Set("a", 2)
+
+		v, ok := c.Get("a")
+		assert.True(t, ok, "Overwritten key alive")
+		assert.Equal(t, 2, v, "Overwritten value")
+		assert.Equal(t, []string{"a:expired"}, r.evicted(), "Evictions")
+		assert.Equal(t, uint64(1), c.Stats().Expirations, "Expirations")
+	})
+
+	// Delete tests that deleting reports the entry with the deleted reason.
+	t.Run("Delete", func(t *testing.T) {
+		t.Parallel()
+		var r recorder
+		c := NewTTL(time.Minute, WithOnEvict(r.onEvict))
+		c.Set("a", 1)
+		c.Set("b", 2)
+		assert.True(t, c.Delete("a"), "Delete of a present key")
+		assert.False(t, c.Delete("a"), "Delete of a deleted key")
+		c.Clear()
+		assert.Equal(t, 0, c.Len(), "Length after Clear")
+		assert.Equal(t, []string{"a:deleted", "b:deleted"}, r.evicted(), "Evictions")
+		assert.Equal(t, 0, c.DeleteExpired(), "Sweep of an empty cache")
+	})
+
+	// Background tests that the cleanup goroutine removes expired entries without lookups, and stops on Close.
+	t.Run("Background", func(t *testing.T) {
+		t.Parallel()
+		clock := NewManualClock(start)
+		c := NewTTL(time.Minute, WithClock[string, int](clock), WithCleanupInterval[string, int](time.Millisecond))
+		defer c.Close()
+		c.Set("a", 1)
+		c.SetWithTTL("b", 2, time.Hour)
+		clock.Advance(2 * time.Minute)
+
+		assert.Eventually(t, func() bool { return c.Len() == 1 }, time.Second, time.Millisecond, "Background expiry")
+		assert.Equal(t, uint64(1), c.Stats().Expirations, "Expirations")
+
+		c.Close()
+		c.Close()
+		c.Set("c", 3)
+		clock.Advance(2 * time.Hour)
+		time.Sleep(5 * time.Millisecond)
+		assert.Equal(t, 2, c.Len(), "No background expiry after Close")
+		_, ok := c.Get("c")
+		assert.False(t, ok, "Lazy expiry after Close")
+	})
+}
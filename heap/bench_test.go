@@ -0,0 +1,126 @@
+package heap
+
+import (
+	stdheap "container/heap"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// The benchmarks in this file compare the heaps with the container/heap boilerplate they replace, on the same
+// random inputs. Run them with:
+//
+//	go test -run '^$' -bench . -benchmem ./heap
+
+// benchSizes are the heap sizes of the benchmarks.
+var benchSizes = []int{16, 1024, 65536}
+
+// benchInts generates size random integers, the same ones for every benchmark of a size.
+func benchInts(size int) []int {
+	r := rand.New(rand.NewPCG(uint64(size), 42))
+	result := make([]int, size)
+	for i := range result {
+		result[i] = r.IntN(size)
+	}
+	return result
+}
+
+// intHeap is the usual container/heap adapter for integers.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return v
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	for _, size := range benchSizes {
+		xs := benchInts(size)
+		b.Run(fmt.Sprintf("Heap/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := New(intLess)
+				for _, v := range xs {
+					h.Push(v)
+				}
+				for h.Len() > 0 {
+					h.Pop()
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("container/heap/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := &intHeap{}
+				for _, v := range xs {
+					stdheap.Push(h, v)
+				}
+				for h.Len() > 0 {
+					stdheap.Pop(h)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFromSlice(b *testing.B) {
+	for _, size := range benchSizes {
+		xs := benchInts(size)
+		b.Run(fmt.Sprintf("Heap/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				FromSlice(xs, intLess)
+			}
+		})
+		b.Run(fmt.Sprintf("container/heap/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := intHeap(append([]int(nil), xs...))
+				stdheap.Init(&h)
+			}
+		})
+	}
+}
+
+func BenchmarkIndexedHeap(b *testing.B) {
+	for _, size := range benchSizes {
+		xs := benchInts(size)
+		b.Run(fmt.Sprintf("DecreaseKey/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := NewIndexed[int](intLess)
+				for k, v := range xs {
+					h.Set(k, v+size)
+				}
+				for k, v := range xs {
+					h.DecreaseKey(k, v)
+				}
+				for h.Len() > 0 {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMerge(b *testing.B) {
+	for _, size := range benchSizes {
+		// Split a sorted input into eight interleaved sorted lists.
+		lists := make([][]int, 8)
+		for v := range size {
+			lists[v%8] = append(lists[v%8], v)
+		}
+		b.Run(fmt.Sprintf("Merge/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Merge(intLess, lists...)
+			}
+		})
+	}
+}
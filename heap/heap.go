@@ -0,0 +1,219 @@
+// Package heap provides type-safe binary heaps ordered by a less function, as a replacement for wrapping
+// container/heap in interface{} boilerplate. Heap is a priority queue whose elements can be updated or removed
+// through the handle returned by Push, IndexedHeap addresses its elements by key for decrease-key algorithms
+// such as Dijkstra's, and Merge and MergeSeq build k-way merges on top of them.
+package heap
+
+import "iter"
+
+// Handle identifies an element pushed onto a Heap, so the element can be updated or removed later in O(log n)
+// without searching for it. A handle becomes detached once its element leaves the heap.
+type Handle[T any] struct {
+	// value is the element.
+	value T
+	// index is the position of the element in the heap, or -1 once it left the heap.
+	index int
+}
+
+// Value returns the element of the handle.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// InHeap reports whether the element of the handle is still in its heap.
+func (h *Handle[T]) InHeap() bool {
+	return h.index >= 0
+}
+
+// Heap is a binary heap whose root is the smallest element according to the less function, so Pop returns the
+// elements in ascending order; use a greater-than function for a max-heap. Push, Pop, Fix, Update and Remove run
+// in O(log n) and Peek in O(1). Elements that compare equal are popped in an unspecified order.
+// A Heap must be created with New or FromSlice and is not safe for concurrent use.
+type Heap[T any] struct {
+	// items holds the handles in heap order: items[i] is not less than items[(i-1)/2].
+	items []*Handle[T]
+	// less orders the elements.
+	less func(a, b T) bool
+}
+
+// New creates an empty heap ordered by the less function.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// FromSlice creates a heap holding the elements of the slice, ordered by the less function. It heapifies a copy
+// of the elements in O(n), which is cheaper than n calls of Push, and leaves the slice untouched. The handles of
+// these elements are not exposed, so push the elements that must be updated or removed later with Push.
+func FromSlice[T any](elements []T, less func(a, b T) bool) *Heap[T] {
+	// Allocate the handles in a single block rather than one by one.
+	handles := make([]Handle[T], len(elements))
+	h := &Heap[T]{items: make([]*Handle[T], len(elements)), less: less}
+	for i, v := range elements {
+		handles[i] = Handle[T]{value: v, index: i}
+		h.items[i] = &handles[i]
+	}
+
+	// Sift down every inner node, from the last one up to the root.
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds the element to the heap and returns its handle.
+func (h *Heap[T]) Push(v T) *Handle[T] {
+	handle := &Handle[T]{value: v, index: len(h.items)}
+	h.items = append(h.items, handle)
+	h.up(handle.index)
+	return handle
+}
+
+// Peek returns the smallest element without removing it, or the zero value and false when the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0].value, true
+}
+
+// Pop removes and returns the smallest element, or the zero value and false when the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(0), true
+}
+
+// Fix restores the heap order after the element of the handle changed in a way that affects its order, for
+// example through a pointer held in the element. It reports false, and does nothing, when the handle does not
+// belong to the heap.
+func (h *Heap[T]) Fix(handle *Handle[T]) bool {
+	if !h.owns(handle) {
+		return false
+	}
+	h.fix(handle.index)
+	return true
+}
+
+// Update replaces the element of the handle and moves it to its new position, which decreases or increases
+// its priority. It reports false, and does nothing, when the handle does not belong to the heap.
+func (h *Heap[T]) Update(handle *Handle[T], v T) bool {
+	if !h.owns(handle) {
+		return false
+	}
+	handle.value = v
+	h.fix(handle.index)
+	return true
+}
+
+// Remove removes the element of the handle from the heap and reports whether it was there. The handle is
+// detached afterwards.
+func (h *Heap[T]) Remove(handle *Handle[T]) bool {
+	if !h.owns(handle) {
+		return false
+	}
+	h.removeAt(handle.index)
+	return true
+}
+
+// Clear removes every element from the heap and detaches their handles.
+func (h *Heap[T]) Clear() {
+	for i, handle := range h.items {
+		handle.index = -1
+		h.items[i] = nil
+	}
+	h.items = h.items[:0]
+}
+
+// Drain returns a sequence that pops the elements in ascending order. Stopping the iteration early leaves the
+// remaining elements in the heap.
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(h.items) > 0 {
+			if !yield(h.removeAt(0)) {
+				return
+			}
+		}
+	}
+}
+
+// owns reports whether the handle refers to an element of this heap.
+func (h *Heap[T]) owns(handle *Handle[T]) bool {
+	return handle != nil && handle.index >= 0 && handle.index < len(h.items) && h.items[handle.index] == handle
+}
+
+// removeAt removes the element at position i by moving the last element into its place, and returns it.
+func (h *Heap[T]) removeAt(i int) T {
+	handle := h.items[i]
+	last := len(h.items) - 1
+	if i != last {
+		h.swap(i, last)
+	}
+
+	// Drop the removed handle and clear its slot, so the backing array does not retain it.
+	h.items[last] = nil
+	h.items = h.items[:last]
+	handle.index = -1
+
+	// The element moved into position i may belong higher or lower.
+	if i < last {
+		h.fix(i)
+	}
+	return handle.value
+}
+
+// fix moves the element at position i up or down to its place.
+func (h *Heap[T]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// up moves the element at position i towards the root while it is less than its parent.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i].value, h.items[parent].value) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at position i towards the leaves while a child is less than it, and reports whether
+// it moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	n := len(h.items)
+	for {
+		// Pick the smaller child.
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && h.less(h.items[right].value, h.items[child].value) {
+			child = right
+		}
+		if !h.less(h.items[child].value, h.items[i].value) {
+			break
+		}
+		h.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// swap exchanges the elements at positions i and j and updates their handles.
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
@@ -0,0 +1,191 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// intLess orders integers ascending, which makes the heaps of the tests min-heaps.
+func intLess(a, b int) bool { return a < b }
+
+// drain pops every element of the heap in order.
+func drain[T any](h *Heap[T]) []T {
+	var result []T
+	for v := range h.Drain() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// checkInvariant fails the test when an element is less than its parent or a handle has a stale index.
+func checkInvariant[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	for i, handle := range h.items {
+		assert.Equal(t, i, handle.index, "Handle index at position %d", i)
+		if i > 0 {
+			assert.False(t, h.less(handle.value, h.items[(i-1)/2].value), "Heap order at position %d", i)
+		}
+	}
+}
+
+func TestHeap(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases that push elements one by one and pop them back.
+	cases := []struct {
+		name     string
+		elements []int
+		expected []int
+	}{
+		{name: "Empty", elements: nil, expected: nil},
+		{name: "Single element", elements: []int{7}, expected: []int{7}},
+		{name: "Unsorted with duplicates", elements: []int{5, 3, 8, 1, 3, 9, 2}, expected: []int{1, 2, 3, 3, 5, 8, 9}},
+		{name: "Descending input", elements: []int{5, 4, 3, 2, 1}, expected: []int{1, 2, 3, 4, 5}},
+	}
+
+	// Iterate over the test cases, checking Push, Peek and Pop.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(intLess)
+			for _, v := range tt.elements {
+				h.Push(v)
+			}
+			checkInvariant(t, h)
+			assert.Equal(t, len(tt.elements), h.Len(), "Length for case %q", tt.name)
+
+			if len(tt.expected) > 0 {
+				top, ok := h.Peek()
+				assert.True(t, ok, "Peek for case %q", tt.name)
+				assert.Equal(t, tt.expected[0], top, "Peek for case %q", tt.name)
+			}
+			assert.Equal(t, tt.expected, drain(h), "Popped elements for case %q", tt.name)
+
+			_, ok := h.Pop()
+			assert.False(t, ok, "Pop of an empty heap for case %q", tt.name)
+			_, ok = h.Peek()
+			assert.False(t, ok, "Peek of an empty heap for case %q", tt.name)
+		})
+	}
+
+	// MaxHeap tests that a greater-than function turns the heap into a max-heap.
+	t.Run("MaxHeap", func(t *testing.T) {
+		h := FromSlice([]string{"b", "d", "a", "c"}, func(a, b string) bool { return a > b })
+		assert.Equal(t, []string{"d", "c", "b", "a"}, drain(h), "Descending order")
+	})
+
+	// FromSlice tests that heapifying gives a valid heap and leaves the input untouched.
+	t.Run("FromSlice", func(t *testing.T) {
+		input := []int{9, 4, 7, 1, 8, 2, 2, 6}
+		h := FromSlice(input, intLess)
+		checkInvariant(t, h)
+		assert.Equal(t, []int{9, 4, 7, 1, 8, 2, 2, 6}, input, "The input should not be modified")
+		assert.Equal(t, []int{1, 2, 2, 4, 6, 7, 8, 9}, drain(h), "Popped elements")
+	})
+
+	// Handles tests Update, Fix and Remove through the handles returned by Push.
+	t.Run("Handles", func(t *testing.T) {
+		type job struct {
+			name     string
+			priority int
+		}
+		h := New(func(a, b *job) bool { return a.priority < b.priority })
+		build := h.Push(&job{"build", 5})
+		test := h.Push(&job{"test", 3})
+		deploy := h.Push(&job{"deploy", 8})
+		lint := h.Push(&job{"lint", 4})
+
+		// Decrease a priority by replacing the element.
+		assert.True(t, h.Update(deploy, &job{"deploy", 1}), "Update of a present handle")
+		top, _ := h.Peek()
+		assert.Equal(t, "deploy", top.name, "Decreased priority")
+
+		// Increase a priority in place and fix the heap.
+		deploy.Value().priority = 10
+		assert.True(t, h.Fix(deploy), "Fix of a present handle")
+		top, _ = h.Peek()
+		assert.Equal(t, "test", top.name, "Increased priority")
+
+		// Remove an element in the middle of the heap.
+		assert.True(t, h.Remove(build), "Remove of a present handle")
+		assert.False(t, build.InHeap(), "A removed handle should be detached")
+		assert.False(t, h.Remove(build), "Remove of a detached handle")
+		assert.False(t, h.Update(build, &job{"build", 0}), "Update of a detached handle")
+		checkInvariant(t, h)
+
+		names := make([]string, 0, 3)
+		for j := range h.Drain() {
+			names = append(names, j.name)
+		}
+		assert.Equal(t, []string{"test", "lint", "deploy"}, names, "Remaining jobs")
+		assert.False(t, lint.InHeap(), "A popped handle should be detached")
+		assert.False(t, test.InHeap(), "A popped handle should be detached")
+	})
+
+	// ForeignHandle tests that a handle of another heap is rejected.
+	t.Run("ForeignHandle", func(t *testing.T) {
+		a, b := New(intLess), New(intLess)
+		handle := a.Push(1)
+		b.Push(2)
+		assert.False(t, b.Fix(handle), "Fix of a foreign handle")
+		assert.False(t, b.Remove(handle), "Remove of a foreign handle")
+		assert.False(t, b.Fix(nil), "Fix of a nil handle")
+		assert.Equal(t, 1, b.Len(), "The heap should be unchanged")
+	})
+
+	// Clear tests that clearing detaches every handle and keeps the heap usable.
+	t.Run("Clear", func(t *testing.T) {
+		h := New(intLess)
+		handle := h.Push(1)
+		h.Push(2)
+		h.Clear()
+		assert.Zero(t, h.Len(), "Cleared heap")
+		assert.False(t, handle.InHeap(), "A cleared handle should be detached")
+		h.Push(3)
+		assert.Equal(t, []int{3}, drain(h), "Usable after Clear")
+	})
+
+	// DrainEarlyExit tests that stopping the iteration leaves the remaining elements in the heap.
+	t.Run("DrainEarlyExit", func(t *testing.T) {
+		h := FromSlice([]int{3, 1, 2}, intLess)
+		for v := range h.Drain() {
+			assert.Equal(t, 1, v, "First drained element")
+			break
+		}
+		assert.Equal(t, []int{2, 3}, drain(h), "Remaining elements")
+	})
+
+	// Random tests random mixes of operations against a sorted slice as a model.
+	t.Run("Random", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		for round := range 50 {
+			h := FromSlice(nil, intLess)
+			var handles []*Handle[int]
+			for range 200 {
+				switch op := r.IntN(10); {
+				case op < 5:
+					handles = append(handles, h.Push(r.IntN(100)))
+				case op < 7 && len(handles) > 0:
+					h.Update(handles[r.IntN(len(handles))], r.IntN(100))
+				case op < 8 && len(handles) > 0:
+					h.Remove(handles[r.IntN(len(handles))])
+				default:
+					h.Pop()
+				}
+			}
+			checkInvariant(t, h)
+
+			// The live handles hold exactly the elements left in the heap.
+			var live []int
+			for _, handle := range handles {
+				if handle.InHeap() {
+					live = append(live, handle.Value())
+				}
+			}
+			slices.Sort(live)
+			assert.Equal(t, live, drain(h), "Remaining elements in round %d", round)
+		}
+	})
+}
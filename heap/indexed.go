@@ -0,0 +1,188 @@
+package heap
+
+// IndexedHeap is a binary heap of keys ordered by a priority, where every key appears at most once and can be
+// looked up, re-prioritised or removed by key in O(log n). It is the priority queue of decrease-key algorithms
+// such as Dijkstra's or Prim's, where the queue is addressed by vertex rather than by handle. Its root is the key
+// with the smallest priority according to the less function.
+// An IndexedHeap must be created with NewIndexed and is not safe for concurrent use.
+type IndexedHeap[K comparable, P any] struct {
+	// keys and priorities hold the entries in heap order.
+	keys       []K
+	priorities []P
+	// positions maps every key to its index in keys and priorities.
+	positions map[K]int
+	// less orders the priorities.
+	less func(a, b P) bool
+}
+
+// NewIndexed creates an empty indexed heap whose priorities are ordered by the less function.
+func NewIndexed[K comparable, P any](less func(a, b P) bool) *IndexedHeap[K, P] {
+	return &IndexedHeap[K, P]{positions: make(map[K]int), less: less}
+}
+
+// Len returns the number of keys in the heap.
+func (h *IndexedHeap[K, P]) Len() int {
+	return len(h.keys)
+}
+
+// Contains reports whether the key is in the heap.
+func (h *IndexedHeap[K, P]) Contains(key K) bool {
+	_, ok := h.positions[key]
+	return ok
+}
+
+// Get returns the priority of the key and true, or the zero value and false when the key is not in the heap.
+func (h *IndexedHeap[K, P]) Get(key K) (P, bool) {
+	i, ok := h.positions[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return h.priorities[i], true
+}
+
+// Set adds the key with the priority, or changes the priority of a key already in the heap, in either direction.
+func (h *IndexedHeap[K, P]) Set(key K, priority P) {
+	if i, ok := h.positions[key]; ok {
+		h.priorities[i] = priority
+		h.fix(i)
+		return
+	}
+
+	// Append the new key as a leaf and move it up to its place.
+	h.keys = append(h.keys, key)
+	h.priorities = append(h.priorities, priority)
+	h.positions[key] = len(h.keys) - 1
+	h.up(len(h.keys) - 1)
+}
+
+// DecreaseKey adds the key with the priority, or lowers the priority of a key already in the heap, and reports
+// whether the heap changed. A priority that is not less than the current one is ignored, which is the relaxation
+// step of Dijkstra's algorithm.
+func (h *IndexedHeap[K, P]) DecreaseKey(key K, priority P) bool {
+	if i, ok := h.positions[key]; ok {
+		if !h.less(priority, h.priorities[i]) {
+			return false
+		}
+		h.priorities[i] = priority
+		h.up(i)
+		return true
+	}
+	h.Set(key, priority)
+	return true
+}
+
+// Peek returns the key with the smallest priority and its priority without removing them, or zero values and
+// false when the heap is empty.
+func (h *IndexedHeap[K, P]) Peek() (K, P, bool) {
+	if len(h.keys) == 0 {
+		var key K
+		var priority P
+		return key, priority, false
+	}
+	return h.keys[0], h.priorities[0], true
+}
+
+// Pop removes and returns the key with the smallest priority and its priority, or zero values and false when
+// the heap is empty.
+func (h *IndexedHeap[K, P]) Pop() (K, P, bool) {
+	if len(h.keys) == 0 {
+		var key K
+		var priority P
+		return key, priority, false
+	}
+	key, priority := h.removeAt(0)
+	return key, priority, true
+}
+
+// Remove removes the key from the heap and returns its priority and true, or the zero value and false when the
+// key is not in the heap.
+func (h *IndexedHeap[K, P]) Remove(key K) (P, bool) {
+	i, ok := h.positions[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	_, priority := h.removeAt(i)
+	return priority, true
+}
+
+// Clear removes every key from the heap.
+func (h *IndexedHeap[K, P]) Clear() {
+	clear(h.keys)
+	clear(h.priorities)
+	clear(h.positions)
+	h.keys, h.priorities = h.keys[:0], h.priorities[:0]
+}
+
+// removeAt removes the entry at position i by moving the last entry into its place, and returns it.
+func (h *IndexedHeap[K, P]) removeAt(i int) (K, P) {
+	key, priority := h.keys[i], h.priorities[i]
+	last := len(h.keys) - 1
+	if i != last {
+		h.swap(i, last)
+	}
+
+	// Drop the removed entry and clear its slots, so the backing arrays do not retain it.
+	var zeroKey K
+	var zeroPriority P
+	h.keys[last], h.priorities[last] = zeroKey, zeroPriority
+	h.keys, h.priorities = h.keys[:last], h.priorities[:last]
+	delete(h.positions, key)
+
+	// The entry moved into position i may belong higher or lower.
+	if i < last {
+		h.fix(i)
+	}
+	return key, priority
+}
+
+// fix moves the entry at position i up or down to its place.
+func (h *IndexedHeap[K, P]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// up moves the entry at position i towards the root while its priority is less than the one of its parent.
+func (h *IndexedHeap[K, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.priorities[i], h.priorities[parent]) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the entry at position i towards the leaves while a child has a smaller priority, and reports
+// whether it moved.
+func (h *IndexedHeap[K, P]) down(i int) bool {
+	start := i
+	n := len(h.keys)
+	for {
+		// Pick the child with the smaller priority.
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && h.less(h.priorities[right], h.priorities[child]) {
+			child = right
+		}
+		if !h.less(h.priorities[child], h.priorities[i]) {
+			break
+		}
+		h.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// swap exchanges the entries at positions i and j and updates their positions.
+func (h *IndexedHeap[K, P]) swap(i, j int) {
+	h.keys[i], h.keys[j] = h.keys[j], h.keys[i]
+	h.priorities[i], h.priorities[j] = h.priorities[j], h.priorities[i]
+	h.positions[h.keys[i]] = i
+	h.positions[h.keys[j]] = j
+}
@@ -0,0 +1,108 @@
+package heap
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	t.Parallel()
+
+	// Basics tests Set, Get, Peek, Pop and Remove by key.
+	t.Run("Basics", func(t *testing.T) {
+		h := NewIndexed[string](intLess)
+		_, _, ok := h.Pop()
+		assert.False(t, ok, "Pop of an empty heap")
+
+		h.Set("a", 5)
+		h.Set("b", 3)
+		h.Set("c", 8)
+		assert.Equal(t, 3, h.Len(), "Length")
+		assert.True(t, h.Contains("a"), "Contains")
+
+		key, priority, ok := h.Peek()
+		assert.True(t, ok, "Peek")
+		assert.Equal(t, "b", key, "Peeked key")
+		assert.Equal(t, 3, priority, "Peeked priority")
+
+		// Changing a priority in both directions.
+		h.Set("c", 1)
+		key, _, _ = h.Peek()
+		assert.Equal(t, "c", key, "Decreased priority")
+		h.Set("c", 9)
+		key, _, _ = h.Peek()
+		assert.Equal(t, "b", key, "Increased priority")
+		priority, _ = h.Get("c")
+		assert.Equal(t, 9, priority, "Get after Set")
+		assert.Equal(t, 3, h.Len(), "Set of a present key should not add it again")
+
+		// Removing by key.
+		priority, ok = h.Remove("a")
+		assert.True(t, ok, "Remove of a present key")
+		assert.Equal(t, 5, priority, "Removed priority")
+		_, ok = h.Remove("a")
+		assert.False(t, ok, "Remove of an absent key")
+		_, ok = h.Get("a")
+		assert.False(t, ok, "Get of an absent key")
+
+		var keys []string
+		for h.Len() > 0 {
+			key, _, _ := h.Pop()
+			keys = append(keys, key)
+		}
+		assert.Equal(t, []string{"b", "c"}, keys, "Popped keys")
+
+		h.Set("d", 1)
+		h.Clear()
+		assert.Zero(t, h.Len(), "Clear")
+		assert.False(t, h.Contains("d"), "Contains after Clear")
+	})
+
+	// DecreaseKey tests that only lower priorities are applied.
+	t.Run("DecreaseKey", func(t *testing.T) {
+		h := NewIndexed[int](intLess)
+		assert.True(t, h.DecreaseKey(1, 10), "Adding a key")
+		assert.True(t, h.DecreaseKey(1, 4), "Lowering a priority")
+		assert.False(t, h.DecreaseKey(1, 4), "An equal priority is ignored")
+		assert.False(t, h.DecreaseKey(1, 7), "A higher priority is ignored")
+		priority, _ := h.Get(1)
+		assert.Equal(t, 4, priority, "Priority after the relaxations")
+	})
+
+	// Dijkstra tests the heap in the algorithm it is designed for.
+	t.Run("Dijkstra", func(t *testing.T) {
+		graph := map[string]map[string]int{
+			"A": {"B": 7, "C": 9, "F": 14},
+			"B": {"A": 7, "C": 10, "D": 15},
+			"C": {"A": 9, "B": 10, "D": 11, "F": 2},
+			"D": {"B": 15, "C": 11, "E": 6},
+			"E": {"D": 6, "F": 9},
+			"F": {"A": 14, "C": 2, "E": 9},
+		}
+		dist := map[string]int{}
+		h := NewIndexed[string](intLess)
+		h.Set("A", 0)
+		for h.Len() > 0 {
+			u, d, _ := h.Pop()
+			dist[u] = d
+			for v, w := range graph[u] {
+				if _, done := dist[v]; !done {
+					h.DecreaseKey(v, d+w)
+				}
+			}
+		}
+		assert.Equal(t, map[string]int{"A": 0, "B": 7, "C": 9, "D": 20, "E": 20, "F": 11}, dist, "Shortest distances")
+	})
+
+	// Float tests priorities of another type, ordered as a max-heap.
+	t.Run("Float", func(t *testing.T) {
+		h := NewIndexed[string](func(a, b float64) bool { return a > b })
+		h.Set("low", 0.5)
+		h.Set("high", math.Pi)
+		h.Set("mid", 1.5)
+		key, _, _ := h.Pop()
+		assert.Equal(t, "high", key, "Largest priority first")
+	})
+}
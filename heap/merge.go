@@ -0,0 +1,102 @@
+package heap
+
+import "iter"
+
+// mergeHead is the current element of one input of a k-way merge.
+type mergeHead[T any] struct {
+	// value is the element.
+	value T
+	// source is the position of the input among all inputs, which breaks ties to keep the merge stable.
+	source int
+}
+
+// newMergeHeap creates the heap of input heads used by Merge and MergeSeq. Heads are ordered by value, then by
+// source, so equal elements come out in the order of their inputs.
+func newMergeHeap[T any](less func(a, b T) bool) *Heap[mergeHead[T]] {
+	return New(func(a, b mergeHead[T]) bool {
+		if less(a.value, b.value) {
+			return true
+		}
+		if less(b.value, a.value) {
+			return false
+		}
+		return a.source < b.source
+	})
+}
+
+// Merge merges any number of slices sorted by the less function into a single new sorted slice, in
+// O(n log k) time for n elements spread over k inputs. Equal elements keep the order of the inputs they come
+// from, so the merge is stable. It returns nil when every input is empty.
+func Merge[T any](less func(a, b T) bool, lists ...[]T) []T {
+	// Count the elements of every input to allocate the result once.
+	total := 0
+	for _, list := range lists {
+		total += len(list)
+	}
+	if total == 0 {
+		return nil
+	}
+	result := make([]T, 0, total)
+
+	// Seed the heap with the first element of every non-empty input, remembering the handle of every input.
+	h := newMergeHeap(less)
+	handles := make([]*Handle[mergeHead[T]], len(lists))
+	positions := make([]int, len(lists))
+	for i, list := range lists {
+		if len(list) > 0 {
+			handles[i] = h.Push(mergeHead[T]{value: list[0], source: i})
+		}
+	}
+
+	// Repeatedly take the smallest head and replace it with the next element of its input, which moves a single
+	// handle instead of popping and pushing.
+	for h.Len() > 0 {
+		head, _ := h.Peek()
+		result = append(result, head.value)
+		source := head.source
+		positions[source]++
+		if positions[source] == len(lists[source]) {
+			h.Remove(handles[source])
+		} else {
+			h.Update(handles[source], mergeHead[T]{value: lists[source][positions[source]], source: source})
+		}
+	}
+	return result
+}
+
+// MergeSeq lazily merges any number of sequences sorted by the less function into a single sorted sequence, with
+// the same ordering rules as Merge. Only the current element of every input is held in memory, so it can merge
+// inputs that do not fit in memory or never end. Stopping the iteration early stops every input.
+func MergeSeq[T any](less func(a, b T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Pull every input, making sure each one is stopped however the iteration ends.
+		nexts := make([]func() (T, bool), len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+		}
+
+		// Seed the heap with the first element of every non-empty input.
+		h := newMergeHeap(less)
+		handles := make([]*Handle[mergeHead[T]], len(seqs))
+		for i, next := range nexts {
+			if v, ok := next(); ok {
+				handles[i] = h.Push(mergeHead[T]{value: v, source: i})
+			}
+		}
+
+		// Yield the smallest head and advance its input.
+		for h.Len() > 0 {
+			head, _ := h.Peek()
+			if !yield(head.value) {
+				return
+			}
+			if v, ok := nexts[head.source](); ok {
+				h.Update(handles[head.source], mergeHead[T]{value: v, source: head.source})
+			} else {
+				h.Remove(handles[head.source])
+			}
+		}
+	}
+}
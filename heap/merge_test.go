@@ -0,0 +1,78 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases with sorted inputs.
+	cases := []struct {
+		name     string
+		lists    [][]int
+		expected []int
+	}{
+		{name: "No input", lists: nil, expected: nil},
+		{name: "Only empty inputs", lists: [][]int{nil, {}}, expected: nil},
+		{name: "Single input", lists: [][]int{{1, 2, 3}}, expected: []int{1, 2, 3}},
+		{name: "Interleaved inputs", lists: [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "Duplicates and empty inputs", lists: [][]int{{1, 3, 3}, nil, {0, 3, 5}}, expected: []int{0, 1, 3, 3, 3, 5}},
+	}
+
+	// Iterate over the test cases, checking the slice and the sequence versions.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Merge(intLess, tt.lists...), "Merge for case %q", tt.name)
+
+			seqs := make([]iter.Seq[int], len(tt.lists))
+			for i, list := range tt.lists {
+				seqs[i] = slices.Values(list)
+			}
+			assert.Equal(t, tt.expected, slices.Collect(MergeSeq(intLess, seqs...)), "MergeSeq for case %q", tt.name)
+		})
+	}
+
+	// Stable tests that equal elements keep the order of their inputs.
+	t.Run("Stable", func(t *testing.T) {
+		type entry struct {
+			key    int
+			source string
+		}
+		less := func(a, b entry) bool { return a.key < b.key }
+		a := []entry{{1, "a"}, {2, "a"}}
+		b := []entry{{1, "b"}, {2, "b"}}
+		expected := []entry{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}
+		assert.Equal(t, expected, Merge(less, a, b), "Merge")
+		assert.Equal(t, expected, slices.Collect(MergeSeq(less, slices.Values(a), slices.Values(b))), "MergeSeq")
+	})
+
+	// EarlyExit tests that stopping a lazy merge stops every input, including endless ones.
+	t.Run("EarlyExit", func(t *testing.T) {
+		stopped := 0
+		counter := func(start, step int) iter.Seq[int] {
+			return func(yield func(int) bool) {
+				defer func() { stopped++ }()
+				for v := start; ; v += step {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}
+
+		var result []int
+		for v := range MergeSeq(intLess, counter(0, 3), counter(1, 3), counter(2, 3)) {
+			if v >= 6 {
+				break
+			}
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, result, "Merged prefix of endless inputs")
+		assert.Equal(t, 3, stopped, "Every input should be stopped")
+	})
+}
@@ -0,0 +1,54 @@
+package maps
+
+// Change holds the two values of a key whose value differs between the maps compared by Diff.
+type Change[V any] struct {
+	// Old is the value in the first map.
+	Old V
+	// New is the value in the second map.
+	New V
+}
+
+// MapDiff describes how a first map turns into a second map. Its maps are never nil.
+type MapDiff[K comparable, V any] struct {
+	// Added holds the entries of the second map whose key is absent from the first map.
+	Added map[K]V
+	// Removed holds the entries of the first map whose key is absent from the second map.
+	Removed map[K]V
+	// Changed holds the keys present in both maps with different values.
+	Changed map[K]Change[V]
+}
+
+// Empty reports whether the maps compared are equal, with no added, removed or changed key.
+func (d MapDiff[K, V]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares two maps and returns the keys that were added, removed or changed between them.
+// Keys present in both maps with equal values appear nowhere in the result.
+func Diff[K, V comparable](before, after map[K]V) MapDiff[K, V] {
+	return DiffFunc(before, after, func(a, b V) bool { return a == b })
+}
+
+// DiffFunc is the variant of Diff for value types that are not comparable or need a custom equality.
+func DiffFunc[K comparable, V any](before, after map[K]V, eq func(a, b V) bool) MapDiff[K, V] {
+	d := MapDiff[K, V]{Added: make(map[K]V), Removed: make(map[K]V), Changed: make(map[K]Change[V])}
+
+	// Every key of the first map is either removed, changed or unchanged.
+	for k, old := range before {
+		current, ok := after[k]
+		switch {
+		case !ok:
+			d.Removed[k] = old
+		case !eq(old, current):
+			d.Changed[k] = Change[V]{Old: old, New: current}
+		}
+	}
+
+	// The keys of the second map that are absent from the first one are added.
+	for k, current := range after {
+		if _, ok := before[k]; !ok {
+			d.Added[k] = current
+		}
+	}
+	return d
+}
@@ -0,0 +1,76 @@
+package maps
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases with the expected added, removed and changed entries.
+	cases := []struct {
+		name            string
+		before, after   map[string]int
+		expectedAdded   map[string]int
+		expectedRemoved map[string]int
+		expectedChanged map[string]Change[int]
+	}{
+		{
+			name: "Both nil", before: nil, after: nil,
+			expectedAdded: map[string]int{}, expectedRemoved: map[string]int{}, expectedChanged: map[string]Change[int]{},
+		},
+		{
+			name: "Equal maps", before: map[string]int{"a": 1}, after: map[string]int{"a": 1},
+			expectedAdded: map[string]int{}, expectedRemoved: map[string]int{}, expectedChanged: map[string]Change[int]{},
+		},
+		{
+			name: "Everything added", before: nil, after: map[string]int{"a": 1, "b": 2},
+			expectedAdded: map[string]int{"a": 1, "b": 2}, expectedRemoved: map[string]int{}, expectedChanged: map[string]Change[int]{},
+		},
+		{
+			name:            "Mixed",
+			before:          map[string]int{"keep": 1, "drop": 2, "bump": 3},
+			after:           map[string]int{"keep": 1, "bump": 4, "new": 5},
+			expectedAdded:   map[string]int{"new": 5},
+			expectedRemoved: map[string]int{"drop": 2},
+			expectedChanged: map[string]Change[int]{"bump": {Old: 3, New: 4}},
+		},
+	}
+
+	// Iterate over the test cases, checking every part of the result.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Diff(tt.before, tt.after)
+			assert.Equal(t, tt.expectedAdded, d.Added, "Added entries for case %q", tt.name)
+			assert.Equal(t, tt.expectedRemoved, d.Removed, "Removed entries for case %q", tt.name)
+			assert.Equal(t, tt.expectedChanged, d.Changed, "Changed entries for case %q", tt.name)
+			assert.Equal(t, len(tt.expectedAdded)+len(tt.expectedRemoved)+len(tt.expectedChanged) == 0, d.Empty(), "Empty for case %q", tt.name)
+		})
+	}
+
+	// Apply tests that applying the diff to the first map gives back the second one.
+	t.Run("Apply", func(t *testing.T) {
+		before := map[string]int{"a": 1, "b": 2, "c": 3}
+		after := map[string]int{"b": 20, "c": 3, "d": 4}
+		d := Diff(before, after)
+
+		result := MergeMaps(FilterMap(before, func(k string, _ int) bool {
+			_, removed := d.Removed[k]
+			return !removed
+		}), MergeMaps(d.Added, MapValues(d.Changed, func(c Change[int]) int { return c.New }), nil), nil)
+		assert.Equal(t, after, result, "Applied diff")
+	})
+
+	// Func tests the variant for values that are not comparable.
+	t.Run("Func", func(t *testing.T) {
+		before := map[string][]int{"a": {1, 2}, "b": {3}}
+		after := map[string][]int{"a": {1, 2}, "b": {3, 4}}
+		d := DiffFunc(before, after, slices.Equal[[]int])
+		assert.Equal(t, map[string]Change[[]int]{"b": {Old: []int{3}, New: []int{3, 4}}}, d.Changed, "Changed entries")
+		assert.Empty(t, d.Added, "Added entries")
+		assert.Empty(t, d.Removed, "Removed entries")
+	})
+}
@@ -0,0 +1,146 @@
+// Package maps provides generic helpers for Go maps that complement the slice package: deterministic
+// iteration in key order, transformation and filtering, inversion, merging with conflict resolution, diffing,
+// and an insertion-ordered OrderedMap. None of the functions modifies its input maps.
+package maps
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrDuplicateKey is reported by Invert under the RejectDuplicates policy when two keys share a value, which
+// would become a duplicate key of the inverted map.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// DuplicateKeyPolicy controls which key Invert keeps when several keys share a value. It mirrors the policy of
+// slice.KeyBy, without making this module depend on the slice module.
+type DuplicateKeyPolicy int
+
+const (
+	// KeepFirst keeps the first key visited for every value and ignores the later ones.
+	KeepFirst DuplicateKeyPolicy = iota
+	// KeepLast keeps the last key visited for every value, overwriting the earlier ones.
+	KeepLast
+	// RejectDuplicates fails with ErrDuplicateKey as soon as a value is visited a second time.
+	RejectDuplicates
+)
+
+// Keys returns the keys of the map in ascending order, so the result is the same on every call.
+func Keys[K cmp.Ordered, V any](m map[K]V) []K {
+	return KeysFunc(m, cmp.Compare[K])
+}
+
+// KeysFunc is the variant of Keys for key types that are not ordered, returning the keys sorted by the
+// comparison function.
+func KeysFunc[K comparable, V any](m map[K]V, compare func(a, b K) int) []K {
+	// Collect the keys in map order, then sort them.
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, compare)
+	return keys
+}
+
+// Values returns the values of the map in the ascending order of their keys, so Values(m)[i] is the value of
+// Keys(m)[i].
+func Values[K cmp.Ordered, V any](m map[K]V) []V {
+	return ValuesFunc(m, cmp.Compare[K])
+}
+
+// ValuesFunc is the variant of Values for key types that are not ordered, returning the values in the order
+// of their keys sorted by the comparison function.
+func ValuesFunc[K comparable, V any](m map[K]V, compare func(a, b K) int) []V {
+	keys := KeysFunc(m, compare)
+	values := make([]V, len(keys))
+	for i, k := range keys {
+		values[i] = m[k]
+	}
+	return values
+}
+
+// MapValues returns a new map with the same keys, holding the result of the transformation function applied to
+// every value. It is the map counterpart of slice.Map.
+func MapValues[K comparable, V, R any](m map[K]V, fn func(V) R) map[K]R {
+	// Create the result with room for every entry.
+	result := make(map[K]R, len(m))
+
+	// Transform every value under its key.
+	for k, v := range m {
+		result[k] = fn(v)
+	}
+	return result
+}
+
+// FilterMap returns a new map holding the entries for which the predicate returns true. It is the map
+// counterpart of slice.Filter. The result is never nil, even when no entry is kept.
+func FilterMap[K comparable, V any](m map[K]V, keep func(K, V) bool) map[K]V {
+	result := make(map[K]V)
+
+	// Copy the entries accepted by the predicate.
+	for k, v := range m {
+		if keep(k, v) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Invert returns a new map from every value to its key. Several keys may share a value, and the policy decides
+// which one is kept, the same way slice.KeyBy decides between elements producing the same key. The keys are
+// visited in ascending order, so KeepFirst keeps the smallest of the colliding keys and KeepLast the largest one,
+// whatever the iteration order of the map. Under RejectDuplicates the first collision is reported as an error
+// wrapping ErrDuplicateKey, together with a nil map.
+func Invert[K cmp.Ordered, V comparable](m map[K]V, policy DuplicateKeyPolicy) (map[V]K, error) {
+	result := make(map[V]K, len(m))
+
+	// Visit the keys in ascending order, so the kept key does not depend on the map order.
+	for _, k := range Keys(m) {
+		v := m[k]
+		if previous, exists := result[v]; exists {
+			switch policy {
+			case KeepFirst:
+				continue
+			case RejectDuplicates:
+				return nil, fmt.Errorf("%w: %v is the value of both %v and %v", ErrDuplicateKey, v, previous, k)
+			}
+		}
+		result[v] = k
+	}
+	return result, nil
+}
+
+// InvertAll returns a new map from every value to all of its keys, in ascending order, so no key is lost when
+// several keys share a value.
+func InvertAll[K cmp.Ordered, V comparable](m map[K]V) map[V][]K {
+	result := make(map[V][]K)
+
+	// Appending the keys in ascending order keeps every group sorted.
+	for _, k := range Keys(m) {
+		result[m[k]] = append(result[m[k]], k)
+	}
+	return result
+}
+
+// MergeMaps returns a new map holding the entries of both maps. It is the map counterpart of slice.Merge.
+// For a key present in both maps the resolver receives the key, the value of the first map and the value of
+// the second map, and returns the value to keep. A nil resolver keeps the value of the second map, like
+// assigning the entries of second over a copy of first.
+func MergeMaps[K comparable, V any](first, second map[K]V, resolve func(key K, a, b V) V) map[K]V {
+	// Start from a copy of the first map with room for both.
+	result := make(map[K]V, len(first)+len(second))
+	for k, v := range first {
+		result[k] = v
+	}
+
+	// Add the entries of the second map, resolving the keys present in both.
+	for k, v := range second {
+		if existing, ok := result[k]; ok && resolve != nil {
+			v = resolve(k, existing, v)
+		}
+		result[k] = v
+	}
+	return result
+}
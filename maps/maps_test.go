@@ -0,0 +1,171 @@
+package maps
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysAndValues(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases with the expected keys and values in key order.
+	cases := []struct {
+		name           string
+		m              map[string]int
+		expectedKeys   []string
+		expectedValues []int
+	}{
+		{name: "Nil map", m: nil, expectedKeys: []string{}, expectedValues: []int{}},
+		{name: "Single entry", m: map[string]int{"a": 1}, expectedKeys: []string{"a"}, expectedValues: []int{1}},
+		{
+			name:           "Several entries",
+			m:              map[string]int{"delta": 4, "alpha": 1, "charlie": 3, "bravo": 2},
+			expectedKeys:   []string{"alpha", "bravo", "charlie", "delta"},
+			expectedValues: []int{1, 2, 3, 4},
+		},
+	}
+
+	// Iterate over the test cases; repeating the calls catches any dependency on the map order.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 10 {
+				assert.Equal(t, tt.expectedKeys, Keys(tt.m), "Keys for case %q", tt.name)
+				assert.Equal(t, tt.expectedValues, Values(tt.m), "Values for case %q", tt.name)
+			}
+		})
+	}
+
+	// Func tests the variants for keys sorted by a custom comparison.
+	t.Run("Func", func(t *testing.T) {
+		type point struct{ X, Y int }
+		m := map[point]string{{2, 1}: "c", {1, 5}: "b", {1, 2}: "a"}
+		compare := func(a, b point) int {
+			if a.X != b.X {
+				return a.X - b.X
+			}
+			return a.Y - b.Y
+		}
+		assert.Equal(t, []point{{1, 2}, {1, 5}, {2, 1}}, KeysFunc(m, compare), "KeysFunc")
+		assert.Equal(t, []string{"a", "b", "c"}, ValuesFunc(m, compare), "ValuesFunc")
+	})
+}
+
+func TestMapValues(t *testing.T) {
+	t.Parallel()
+
+	result := MapValues(map[string]string{"a": "x", "b": "yy"}, func(v string) int { return len(v) })
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, result, "Transformed values")
+	assert.Empty(t, MapValues(map[string]int(nil), func(v int) int { return v }), "Nil map")
+}
+
+func TestFilterMap(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	original := MergeMaps(m, nil, nil)
+
+	// Keep the entries by value and by key.
+	assert.Equal(t, map[string]int{"b": 2, "d": 4}, FilterMap(m, func(_ string, v int) bool { return v%2 == 0 }), "Filtered by value")
+	assert.Equal(t, map[string]int{"a": 1}, FilterMap(m, func(k string, _ int) bool { return k == "a" }), "Filtered by key")
+
+	// An empty result is an empty map, not nil.
+	none := FilterMap(m, func(string, int) bool { return false })
+	assert.NotNil(t, none, "Empty result")
+	assert.Empty(t, none, "Empty result")
+	assert.Equal(t, original, m, "The input should not be modified")
+}
+
+func TestInvert(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"one": 1, "uno": 1, "eins": 1, "two": 2}
+
+	// Define test cases for every collision policy.
+	cases := []struct {
+		name     string
+		policy   DuplicateKeyPolicy
+		expected map[int]string
+		err      error
+	}{
+		{name: "Keep first", policy: KeepFirst, expected: map[int]string{1: "eins", 2: "two"}},
+		{name: "Keep last", policy: KeepLast, expected: map[int]string{1: "uno", 2: "two"}},
+		{name: "Reject duplicates", policy: RejectDuplicates, err: ErrDuplicateKey},
+	}
+
+	// Iterate over the test cases; repeating the calls catches any dependency on the map order.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 10 {
+				result, err := Invert(m, tt.policy)
+				assert.ErrorIs(t, err, tt.err, "Error for case %q", tt.name)
+				assert.Equal(t, tt.expected, result, "Inverted map for case %q", tt.name)
+			}
+		})
+	}
+
+	// NoCollision tests that a one-to-one map is inverted under every policy, including the rejecting one.
+	t.Run("NoCollision", func(t *testing.T) {
+		result, err := Invert(map[string]int{"a": 1, "b": 2}, RejectDuplicates)
+		assert.NoError(t, err, "No collision")
+		assert.Equal(t, map[int]string{1: "a", 2: "b"}, result, "Inverted map")
+	})
+
+	// Message tests that the error names the value and both keys.
+	t.Run("Message", func(t *testing.T) {
+		_, err := Invert(map[string]int{"b": 7, "a": 7}, RejectDuplicates)
+		assert.True(t, errors.Is(err, ErrDuplicateKey), "Sentinel")
+		assert.Equal(t, "duplicate key: 7 is the value of both a and b", err.Error(), "Error message")
+	})
+
+	// All tests that InvertAll keeps every key, in ascending order.
+	t.Run("All", func(t *testing.T) {
+		assert.Equal(t, map[int][]string{1: {"eins", "one", "uno"}, 2: {"two"}}, InvertAll(m), "Every key should be kept")
+	})
+}
+
+func TestMergeMaps(t *testing.T) {
+	t.Parallel()
+
+	first := map[string]int{"a": 1, "b": 2}
+	second := map[string]int{"b": 20, "c": 30}
+
+	// Define test cases with different resolvers.
+	cases := []struct {
+		name     string
+		resolve  func(key string, a, b int) int
+		expected map[string]int
+	}{
+		{name: "Nil resolver keeps the second value", resolve: nil, expected: map[string]int{"a": 1, "b": 20, "c": 30}},
+		{name: "Keep the first value", resolve: func(_ string, a, _ int) int { return a }, expected: map[string]int{"a": 1, "b": 2, "c": 30}},
+		{name: "Sum both values", resolve: func(_ string, a, b int) int { return a + b }, expected: map[string]int{"a": 1, "b": 22, "c": 30}},
+	}
+
+	// Iterate over the test cases, checking that the inputs are left untouched.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MergeMaps(first, second, tt.resolve), "Merged map for case %q", tt.name)
+			assert.Equal(t, map[string]int{"a": 1, "b": 2}, first, "The first map should not be modified for case %q", tt.name)
+			assert.Equal(t, map[string]int{"b": 20, "c": 30}, second, "The second map should not be modified for case %q", tt.name)
+		})
+	}
+
+	// Keys tests that the resolver only runs for the shared keys and receives them.
+	t.Run("Keys", func(t *testing.T) {
+		var resolved []string
+		MergeMaps(first, second, func(key string, a, b int) int {
+			resolved = append(resolved, key)
+			return a
+		})
+		assert.Equal(t, []string{"b"}, resolved, "Resolved keys")
+	})
+
+	// Nil tests that nil maps merge like empty ones.
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, map[string]int{}, MergeMaps[string, int](nil, nil, nil), "Both nil")
+		result := MergeMaps(nil, map[string]string{"k": "v"}, func(_ string, a, b string) string { return strings.ToUpper(b) })
+		assert.Equal(t, map[string]string{"k": "v"}, result, "The resolver should not run without shared keys")
+	})
+}
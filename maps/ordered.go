@@ -0,0 +1,307 @@
+package maps
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// OrderedMap is a map that remembers the order in which its keys were first inserted. Iteration, Keys, Values
+// and the JSON encoding all follow that order. Get, Set and Delete run in O(1).
+// The zero value is an empty map that is ready to use. An OrderedMap is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	// entries indexes the list nodes by key.
+	entries map[K]*entry[K, V]
+	// head and tail are the oldest and newest nodes of the list, or nil when the map is empty.
+	head, tail *entry[K, V]
+	// inserted counts the nodes ever appended, numbering them in insertion order.
+	inserted uint64
+}
+
+// entry is a node of the doubly linked list that keeps the insertion order of an OrderedMap.
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+	// seq numbers the node in insertion order, so that an iteration can tell the nodes it already visited.
+	seq uint64
+	// removed marks a node unlinked by Delete or Clear, which an ongoing iteration must skip.
+	removed bool
+}
+
+// NewOrderedMap creates an empty ordered map.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{entries: make(map[K]*entry[K, V])}
+}
+
+// Set stores the value under the key. A new key is appended at the end of the order, while an existing key
+// keeps its position and only has its value replaced.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		return
+	}
+
+	// Lazily allocate the index so the zero value of OrderedMap is usable.
+	if m.entries == nil {
+		m.entries = make(map[K]*entry[K, V])
+	}
+
+	// Append the new key at the end of the list.
+	m.inserted++
+	e := &entry[K, V]{key: key, value: value, prev: m.tail, seq: m.inserted}
+	if m.tail == nil {
+		m.head = e
+	} else {
+		m.tail.next = e
+	}
+	m.tail = e
+	m.entries[key] = e
+}
+
+// Get returns the value stored under the key and true, or the zero value and false when the key is absent.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.entries[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has reports whether the key is present in the map.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Delete removes the key from the map and reports whether it was present. Setting the key again later appends
+// it at the end of the order.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+
+	// Unlink the node from its neighbours.
+	if e.prev == nil {
+		m.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		m.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	e.removed = true
+	delete(m.entries, key)
+	return true
+}
+
+// Len returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Clear removes every key from the map.
+func (m *OrderedMap[K, V]) Clear() {
+	for e := m.head; e != nil; e = e.next {
+		e.removed = true
+	}
+	clear(m.entries)
+	m.head, m.tail = nil, nil
+}
+
+// Keys returns the keys of the map in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	for e := m.head; e != nil; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Values returns the values of the map in the insertion order of their keys.
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Len())
+	for e := m.head; e != nil; e = e.next {
+		values = append(values, e.value)
+	}
+	return values
+}
+
+// All returns a sequence over the entries of the map in insertion order. The map may be modified during the
+// iteration: deleted entries that were not visited yet are skipped, and new keys are visited as well since they
+// are appended at the end, even when the entry being visited was deleted or the map was cleared.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.head; e != nil; e = m.after(e) {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// after returns the first entry of the map inserted after the visited node, or nil when there is none. The
+// node may have been removed since it was visited, in which case its links are stale.
+func (m *OrderedMap[K, V]) after(e *entry[K, V]) *entry[K, V] {
+	if !e.removed {
+		return e.next
+	}
+
+	// Walk back to the closest node that is still in the list; removed nodes keep pointing to their former
+	// predecessor, which was inserted earlier.
+	p := e.prev
+	for p != nil && p.removed {
+		p = p.prev
+	}
+
+	// Walk forward from there to the first node inserted after the visited one.
+	next := m.head
+	if p != nil {
+		next = p.next
+	}
+	for next != nil && next.seq <= e.seq {
+		next = next.next
+	}
+	return next
+}
+
+// Clone returns a new ordered map holding the same entries in the same order.
+func (m *OrderedMap[K, V]) Clone() *OrderedMap[K, V] {
+	result := &OrderedMap[K, V]{entries: make(map[K]*entry[K, V], m.Len())}
+	for e := m.head; e != nil; e = e.next {
+		result.Set(e.key, e.value)
+	}
+	return result
+}
+
+// MarshalJSON encodes the map as a JSON object with the keys in insertion order. Keys are encoded the same way
+// encoding/json encodes the keys of a plain map: strings as they are, then encoding.TextMarshaler
+// implementations, then integers in decimal. Any other key type is an error.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for e := m.head; e != nil; e = e.next {
+		if e != m.head {
+			buf.WriteByte(',')
+		}
+
+		// Encode the key as a JSON string.
+		name, err := encodeKey(e.key)
+		if err != nil {
+			return nil, err
+		}
+		quoted, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(quoted)
+		buf.WriteByte(':')
+
+		// Encode the value with the standard rules.
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map, replacing its previous content and keeping the keys in the
+// order of the document. When a key appears several times, the last value wins and the key keeps the position of
+// its first occurrence. The JSON null leaves the map empty.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.Clear()
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	// Expect the opening brace of an object, or null.
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("maps: cannot unmarshal %v into an OrderedMap, want a JSON object", token)
+	}
+
+	// Read the members one by one, in document order.
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := decodeKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Set(key, value)
+	}
+
+	// Consume the closing brace.
+	_, err = dec.Token()
+	return err
+}
+
+// encodeKey converts a map key to the name of a JSON object member.
+func encodeKey[K comparable](key K) (string, error) {
+	v := reflect.ValueOf(key)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("maps: unsupported JSON key type %T", key)
+}
+
+// decodeKey converts the name of a JSON object member back to a map key, following the rules of encodeKey.
+func decodeKey[K comparable](name string) (K, error) {
+	var key K
+	v := reflect.ValueOf(&key).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(name)
+		return key, nil
+	}
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(name))
+		return key, err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("maps: invalid JSON key %q: %w", name, err)
+		}
+		v.SetInt(n)
+		return key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, v.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("maps: invalid JSON key %q: %w", name, err)
+		}
+		v.SetUint(n)
+		return key, nil
+	}
+	return key, fmt.Errorf("maps: unsupported JSON key type %T", key)
+}
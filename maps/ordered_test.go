@@ -0,0 +1,240 @@
+package maps
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	// Basics tests the Set, Get, Has, Delete, Len and Clear operations, starting from the zero value.
+	t.Run("Basics", func(t *testing.T) {
+		// The zero value must be usable without a constructor.
+		var m OrderedMap[string, int]
+		assert.Zero(t, m.Len(), "The zero value should be empty")
+		_, ok := m.Get("a")
+		assert.False(t, ok, "The zero value should not contain anything")
+		assert.False(t, m.Delete("a"), "Deleting from the zero value")
+
+		m.Set("c", 3)
+		m.Set("a", 1)
+		m.Set("b", 2)
+		assert.Equal(t, []string{"c", "a", "b"}, m.Keys(), "Keys in insertion order")
+		assert.Equal(t, []int{3, 1, 2}, m.Values(), "Values in insertion order")
+
+		// Replacing a value keeps the position of its key.
+		m.Set("c", 30)
+		v, ok := m.Get("c")
+		assert.True(t, ok, "Present key")
+		assert.Equal(t, 30, v, "Replaced value")
+		assert.Equal(t, []string{"c", "a", "b"}, m.Keys(), "Replacing should not move the key")
+
+		// Deleting and setting again moves the key to the end.
+		assert.True(t, m.Delete("c"), "Deleting a present key")
+		assert.False(t, m.Has("c"), "Deleted key")
+		m.Set("c", 3)
+		assert.Equal(t, []string{"a", "b", "c"}, m.Keys(), "Re-inserted key at the end")
+
+		// Deleting the last and the only keys keeps the list consistent.
+		m.Delete("c")
+		m.Delete("a")
+		assert.Equal(t, []string{"b"}, m.Keys(), "Single key left")
+		m.Set("d", 4)
+		assert.Equal(t, []string{"b", "d"}, m.Keys(), "Appended after deletions")
+
+		m.Clear()
+		assert.Zero(t, m.Len(), "Clear should drop every key")
+		assert.Empty(t, m.Keys(), "No key after Clear")
+		m.Set("e", 5)
+		assert.Equal(t, []string{"e"}, m.Keys(), "Usable after Clear")
+	})
+
+	// All tests the iteration order and that the map can be modified while iterating.
+	t.Run("All", func(t *testing.T) {
+		m := NewOrderedMap[int, string]()
+		for i, s := range []string{"zero", "one", "two", "three", "four"} {
+			m.Set(i, s)
+		}
+
+		var visited []int
+		for k := range m.All() {
+			visited = append(visited, k)
+			switch k {
+			case 0:
+				// Deleting the current and a later entry.
+				m.Delete(0)
+				m.Delete(1)
+			case 2:
+				// Deleting the next entry, which was not visited yet.
+				m.Delete(3)
+			case 4:
+				// A key added during the iteration is visited as well.
+				m.Set(5, "five")
+			}
+		}
+		assert.Equal(t, []int{0, 2, 4, 5}, visited, "Visited keys")
+		assert.Equal(t, []int{2, 4, 5}, m.Keys(), "Remaining keys")
+
+		// Breaking out of the loop stops the iteration.
+		count := 0
+		for range m.All() {
+			count++
+			break
+		}
+		assert.Equal(t, 1, count, "Early exit")
+	})
+
+	// AllAfterRemovingCurrent tests that keys added after deleting the entry being visited, or after clearing the
+	// map, are still visited, while re-added keys are visited again at their new position.
+	t.Run("AllAfterRemovingCurrent", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			modify   func(m *OrderedMap[string, int], key string)
+			expected []string
+		}{
+			{name: "Delete the tail then set", modify: func(m *OrderedMap[string, int], key string) {
+				if key == "b" {
+					m.Delete("b")
+					m.Set("c", 3)
+				}
+			}, expected: []string{"a", "b", "c"}},
+			{name: "Delete the tail and its predecessor then set", modify: func(m *OrderedMap[string, int], key string) {
+				if key == "b" {
+					m.Delete("b")
+					m.Delete("a")
+					m.Set("c", 3)
+				}
+			}, expected: []string{"a", "b", "c"}},
+			{name: "Clear then set", modify: func(m *OrderedMap[string, int], key string) {
+				if key == "a" {
+					m.Clear()
+					m.Set("c", 3)
+				}
+			}, expected: []string{"a", "c"}},
+			{name: "Re-add the current key", modify: func(m *OrderedMap[string, int], key string) {
+				if key == "a" {
+					m.Delete("a")
+					m.Set("a", 1)
+				}
+			}, expected: []string{"a", "b", "a"}},
+		}
+
+		for _, tt := range cases {
+			m := NewOrderedMap[string, int]()
+			m.Set("a", 1)
+			m.Set("b", 2)
+
+			var visited []string
+			for k := range m.All() {
+				// Only modify the map while visiting the original keys, so re-added keys do not loop forever.
+				if len(visited) < 2 {
+					tt.modify(m, k)
+				}
+				visited = append(visited, k)
+			}
+			assert.Equal(t, tt.expected, visited, "Visited keys for case %q", tt.name)
+		}
+	})
+
+	// Clone tests that the copy is independent of the original.
+	t.Run("Clone", func(t *testing.T) {
+		m := NewOrderedMap[string, int]()
+		m.Set("b", 2)
+		m.Set("a", 1)
+		c := m.Clone()
+		c.Set("c", 3)
+		c.Delete("b")
+		assert.Equal(t, []string{"b", "a"}, m.Keys(), "Original keys")
+		assert.Equal(t, []string{"a", "c"}, c.Keys(), "Cloned keys")
+	})
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	t.Parallel()
+
+	// Marshal tests that the keys are encoded in insertion order for every supported key type.
+	t.Run("Marshal", func(t *testing.T) {
+		strs := NewOrderedMap[string, any]()
+		strs.Set("zeta", 1)
+		strs.Set("alpha", []int{1, 2})
+		strs.Set("quote\"d", nil)
+		data, err := json.Marshal(strs)
+		assert.NoError(t, err, "String keys")
+		assert.Equal(t, `{"zeta":1,"alpha":[1,2],"quote\"d":null}`, string(data), "String keys")
+
+		ints := NewOrderedMap[int8, bool]()
+		ints.Set(10, true)
+		ints.Set(-2, false)
+		data, err = json.Marshal(ints)
+		assert.NoError(t, err, "Integer keys")
+		assert.Equal(t, `{"10":true,"-2":false}`, string(data), "Integer keys")
+
+		addrs := NewOrderedMap[netip.Addr, string]()
+		addrs.Set(netip.MustParseAddr("10.0.0.2"), "b")
+		addrs.Set(netip.MustParseAddr("10.0.0.1"), "a")
+		data, err = json.Marshal(addrs)
+		assert.NoError(t, err, "Text marshaler keys")
+		assert.Equal(t, `{"10.0.0.2":"b","10.0.0.1":"a"}`, string(data), "Text marshaler keys")
+
+		data, err = json.Marshal(&OrderedMap[string, int]{})
+		assert.NoError(t, err, "Empty map")
+		assert.Equal(t, `{}`, string(data), "Empty map")
+	})
+
+	// Unmarshal tests that the keys are decoded in document order.
+	t.Run("Unmarshal", func(t *testing.T) {
+		var m OrderedMap[string, int]
+		m.Set("stale", 0)
+		assert.NoError(t, json.Unmarshal([]byte(`{"c":3,"a":1,"b":2,"a":10}`), &m), "Valid object")
+		assert.Equal(t, []string{"c", "a", "b"}, m.Keys(), "Keys in document order, the stale key dropped")
+		assert.Equal(t, []int{3, 10, 2}, m.Values(), "The last duplicate wins")
+
+		var ints OrderedMap[uint16, string]
+		assert.NoError(t, json.Unmarshal([]byte(`{"443":"https","22":"ssh"}`), &ints), "Integer keys")
+		assert.Equal(t, []uint16{443, 22}, ints.Keys(), "Integer keys")
+
+		var addrs OrderedMap[netip.Addr, int]
+		assert.NoError(t, json.Unmarshal([]byte(`{"::1":6,"127.0.0.1":4}`), &addrs), "Text unmarshaler keys")
+		assert.Equal(t, []netip.Addr{netip.MustParseAddr("::1"), netip.MustParseAddr("127.0.0.1")}, addrs.Keys(), "Text unmarshaler keys")
+
+		assert.NoError(t, json.Unmarshal([]byte(`null`), &m), "Null")
+		assert.Zero(t, m.Len(), "Null leaves the map empty")
+	})
+
+	// RoundTrip tests that encoding and decoding keeps the order and the values.
+	t.Run("RoundTrip", func(t *testing.T) {
+		m := NewOrderedMap[string, []string]()
+		m.Set("web", []string{"80", "443"})
+		m.Set("db", []string{"5432"})
+		m.Set("admin", nil)
+		data, err := json.Marshal(m)
+		assert.NoError(t, err, "Marshal")
+
+		decoded := NewOrderedMap[string, []string]()
+		assert.NoError(t, json.Unmarshal(data, decoded), "Unmarshal")
+		assert.Equal(t, m.Keys(), decoded.Keys(), "Round-tripped keys")
+		assert.Equal(t, m.Values(), decoded.Values(), "Round-tripped values")
+	})
+
+	// Errors tests the documents and key types that cannot be decoded or encoded.
+	t.Run("Errors", func(t *testing.T) {
+		var m OrderedMap[string, int]
+		assert.Error(t, json.Unmarshal([]byte(`[1,2]`), &m), "An array is not an object")
+		assert.Error(t, json.Unmarshal([]byte(`{"a":"x"}`), &m), "Mismatched value type")
+
+		var ints OrderedMap[int8, int]
+		assert.Error(t, json.Unmarshal([]byte(`{"300":1}`), &ints), "Integer key out of range")
+		assert.Error(t, json.Unmarshal([]byte(`{"x":1}`), &ints), "Non-numeric integer key")
+
+		floats := NewOrderedMap[float64, int]()
+		floats.Set(1.5, 1)
+		_, err := json.Marshal(floats)
+		assert.Error(t, err, "Float keys are not supported")
+		var decodedFloats OrderedMap[float64, int]
+		assert.Error(t, json.Unmarshal([]byte(`{"1.5":1}`), &decodedFloats), "Float keys are not supported")
+	})
+}
@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"fmt"
+	"testing"
+)
+
+// The benchmarks in this file compare the containers with the plain-slice idioms they replace. Run them with:
+//
+//	go test -run '^$' -bench . -benchmem ./queue
+
+// benchSizes are the numbers of elements that go through the containers.
+var benchSizes = []int{16, 1024, 65536}
+
+// BenchmarkLastN keeps the last 64 of size elements, with a Ring and with a slice that appends and drops its
+// first element.
+func BenchmarkLastN(b *testing.B) {
+	const n = 64
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("Ring/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r := NewRing[int](n, OverwriteOldest)
+				for v := range size {
+					r.Push(v)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Slice/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var s []int
+				for v := range size {
+					s = append(s, v)
+					if len(s) > n {
+						s = s[1:]
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkQueue pushes size elements and pops them in FIFO order, with a Deque and with a slice that appends
+// and reslices.
+func BenchmarkQueue(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("Deque/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var d Deque[int]
+				for v := range size {
+					d.PushBack(v)
+				}
+				for d.Len() > 0 {
+					d.PopFront()
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Slice/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var s []int
+				for v := range size {
+					s = append(s, v)
+				}
+				for len(s) > 0 {
+					s = s[1:]
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSteadyQueue keeps about 32 elements in flight while size elements go through the queue, the shape of
+// a BFS work queue, where a reslicing slice keeps reallocating as its start moves forward.
+func BenchmarkSteadyQueue(b *testing.B) {
+	const inFlight = 32
+	for _, size := range benchSizes {
+		b.Run(fmt.Sprintf("Deque/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var d Deque[int]
+				for v := range size {
+					d.PushBack(v)
+					if d.Len() > inFlight {
+						d.PopFront()
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Slice/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var s []int
+				for v := range size {
+					s = append(s, v)
+					if len(s) > inFlight {
+						s = s[1:]
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPushFront adds size elements at the front, with a Deque and with a slice that prepends.
+func BenchmarkPushFront(b *testing.B) {
+	for _, size := range benchSizes[:2] {
+		b.Run(fmt.Sprintf("Deque/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var d Deque[int]
+				for v := range size {
+					d.PushFront(v)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Slice/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var s []int
+				for v := range size {
+					s = append([]int{v}, s...)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSlice converts a full Ring and Deque of size elements to a slice.
+func BenchmarkSlice(b *testing.B) {
+	for _, size := range benchSizes {
+		r := NewRing[int](size, OverwriteOldest)
+		d := NewDeque[int](size)
+		for v := range size + size/2 {
+			r.Push(v)
+			d.PushFront(v)
+		}
+		b.Run(fmt.Sprintf("Ring/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r.Slice()
+			}
+		})
+		b.Run(fmt.Sprintf("Deque/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				d.Slice()
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package queue
+
+import (
+	"fmt"
+	"iter"
+	"math"
+)
+
+// minDequeCapacity is the smallest buffer a Deque allocates, and the size below which it never shrinks unless
+// NewDeque was given a larger capacity.
+const minDequeCapacity = 16
+
+// Deque is a double-ended queue backed by a growable circular buffer. Pushing and popping at either end run in
+// amortized O(1). The buffer doubles when it is full and halves when it is less than a quarter full, so a Deque
+// that once held many elements gives the memory back as it drains, down to the capacity given to NewDeque.
+// The zero value is an empty deque that is ready to use. A Deque is not safe for concurrent use.
+type Deque[T any] struct {
+	// buf holds the elements; its length is always zero or a power of two.
+	buf []T
+	// head is the index of the front element in buf.
+	head int
+	// size is the number of elements.
+	size int
+	// floor is the capacity requested by NewDeque, rounded to a power of two, below which the buffer never
+	// shrinks; zero means minDequeCapacity.
+	floor int
+}
+
+// NewDeque creates an empty deque with room for at least capacity elements before it grows. The buffer never
+// shrinks below that capacity, so a deque that repeatedly fills and drains, such as a BFS work queue, keeps its
+// preallocation.
+func NewDeque[T any](capacity int) *Deque[T] {
+	d := &Deque[T]{}
+	if capacity > 0 {
+		d.floor = roundCapacity(capacity)
+		d.buf = make([]T, d.floor)
+	}
+	return d
+}
+
+// Len returns the number of elements in the deque.
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// PushBack adds the element at the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.size)] = v
+	d.size++
+}
+
+// PushFront adds the element at the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.size++
+}
+
+// PopFront removes and returns the front element, or the zero value and false when the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+
+	// Clear the slot, so the deque does not retain the element.
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes and returns the back element, or the zero value and false when the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	i := d.index(d.size - 1)
+	v := d.buf[i]
+
+	// Clear the slot, so the deque does not retain the element.
+	d.buf[i] = zero
+	d.size--
+	d.shrink()
+	return v, true
+}
+
+// Front returns the front element without removing it, or the zero value and false when the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	return d.At(0)
+}
+
+// Back returns the back element without removing it, or the zero value and false when the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	return d.At(d.size - 1)
+}
+
+// At returns the element at position i, counted from the front element at position 0, or the zero value and
+// false when i is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.size {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(i)], true
+}
+
+// Clear removes every element from the deque and releases its buffer. The next push allocates the capacity
+// given to NewDeque again.
+func (d *Deque[T]) Clear() {
+	d.buf, d.head, d.size = nil, 0, 0
+}
+
+// All returns a sequence over the elements from the front to the back. The deque must not be modified during
+// the iteration.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.size {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence over the elements from the back to the front. The deque must not be modified
+// during the iteration.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the elements from the front to the back in a newly allocated slice.
+func (d *Deque[T]) Slice() []T {
+	result := make([]T, d.size)
+	d.copyTo(result)
+	return result
+}
+
+// index converts a position counted from the front element to an index in buf.
+// The length of buf is a power of two, so the wrap-around is a mask.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// copyTo copies the elements from the front to the back into dst, which must hold at least Len elements.
+func (d *Deque[T]) copyTo(dst []T) {
+	// Copy the part up to the end of the buffer, then the part that wrapped around to its start.
+	n := copy(dst, d.buf[d.head:min(d.head+d.size, len(d.buf))])
+	copy(dst[n:], d.buf[:d.size-n])
+}
+
+// grow doubles the buffer when it is full, so one more element fits.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	d.resize(max(d.minCapacity(), 2*len(d.buf)))
+}
+
+// shrink halves the buffer when it is less than a quarter full, which keeps every later push cheap while
+// releasing most of the memory of a deque that drained.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > d.minCapacity() && d.size < len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// minCapacity returns the size below which the buffer never shrinks.
+func (d *Deque[T]) minCapacity() int {
+	return max(minDequeCapacity, d.floor)
+}
+
+// resize moves the elements to the front of a new buffer with the given capacity.
+func (d *Deque[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	d.copyTo(buf)
+	d.buf, d.head = buf, 0
+}
+
+// roundCapacity returns the smallest power of two that is at least the capacity and minDequeCapacity. It panics
+// when that power of two does not fit in an int, rather than doubling past math.MaxInt forever.
+func roundCapacity(capacity int) int {
+	n := minDequeCapacity
+	for n < capacity {
+		if n > math.MaxInt/2 {
+			panic(fmt.Sprintf("queue: NewDeque capacity is too large, got %d", capacity))
+		}
+		n *= 2
+	}
+	return n
+}
@@ -0,0 +1,178 @@
+package queue
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque(t *testing.T) {
+	t.Parallel()
+
+	// Basics tests both ends, starting from the zero value.
+	t.Run("Basics", func(t *testing.T) {
+		var d Deque[int]
+		_, ok := d.PopFront()
+		assert.False(t, ok, "PopFront of an empty deque")
+		_, ok = d.PopBack()
+		assert.False(t, ok, "PopBack of an empty deque")
+		_, ok = d.Front()
+		assert.False(t, ok, "Front of an empty deque")
+		assert.Equal(t, []int{}, d.Slice(), "Slice of an empty deque")
+
+		d.PushBack(2)
+		d.PushBack(3)
+		d.PushFront(1)
+		d.PushFront(0)
+		assert.Equal(t, []int{0, 1, 2, 3}, d.Slice(), "Elements from front to back")
+		assert.Equal(t, []int{3, 2, 1, 0}, slices.Collect(d.Backward()), "Elements from back to front")
+		front, _ := d.Front()
+		back, _ := d.Back()
+		assert.Equal(t, 0, front, "Front element")
+		assert.Equal(t, 3, back, "Back element")
+		at, ok := d.At(2)
+		assert.True(t, ok, "At in range")
+		assert.Equal(t, 2, at, "At in range")
+		_, ok = d.At(4)
+		assert.False(t, ok, "At out of range")
+
+		v, _ := d.PopFront()
+		assert.Equal(t, 0, v, "PopFront")
+		v, _ = d.PopBack()
+		assert.Equal(t, 3, v, "PopBack")
+		assert.Equal(t, []int{1, 2}, slices.Collect(d.All()), "Remaining elements")
+
+		d.Clear()
+		assert.Zero(t, d.Len(), "Clear")
+		d.PushFront(5)
+		assert.Equal(t, []int{5}, d.Slice(), "Usable after Clear")
+	})
+
+	// Capacity tests that the buffer grows to fit and shrinks again when the deque drains.
+	t.Run("Capacity", func(t *testing.T) {
+		d := NewDeque[int](100)
+		assert.Equal(t, 128, len(d.buf), "Initial capacity rounded to a power of two")
+
+		for v := range 10000 {
+			d.PushBack(v)
+		}
+		assert.Equal(t, 16384, len(d.buf), "Grown capacity")
+
+		for range 9990 {
+			d.PopFront()
+		}
+		assert.Equal(t, 128, len(d.buf), "The buffer should shrink down to the requested capacity as the deque drains")
+		assert.Equal(t, []int{9990, 9991, 9992, 9993, 9994, 9995, 9996, 9997, 9998, 9999}, d.Slice(), "Remaining elements")
+	})
+
+	// HugeCapacity tests that a capacity whose power of two does not fit in an int panics instead of looping.
+	t.Run("HugeCapacity", func(t *testing.T) {
+		assert.Equal(t, 1<<62, roundCapacity(1<<62), "Largest power of two")
+		assert.PanicsWithValue(t, fmt.Sprintf("queue: NewDeque capacity is too large, got %d", 1<<62+1), func() { roundCapacity(1<<62 + 1) }, "Capacity above the largest power of two")
+		assert.Panics(t, func() { NewDeque[int](math.MaxInt) }, "Maximum capacity")
+	})
+
+	// KeepsPreallocation tests that a preallocated deque that fills and drains below its capacity never
+	// reallocates, and that the zero value still shrinks down to minDequeCapacity.
+	t.Run("KeepsPreallocation", func(t *testing.T) {
+		d := NewDeque[int](1000)
+		buf := d.buf
+		for range 3 {
+			for v := range 1000 {
+				d.PushBack(v)
+			}
+			for d.Len() > 0 {
+				d.PopFront()
+			}
+		}
+		assert.Equal(t, 1024, len(d.buf), "Capacity after filling and draining")
+		assert.Same(t, &buf[0], &d.buf[0], "The preallocated buffer should be reused")
+
+		d.Clear()
+		d.PushBack(1)
+		assert.Equal(t, 1024, len(d.buf), "Capacity allocated again after Clear")
+
+		var z Deque[int]
+		for v := range 1000 {
+			z.PushBack(v)
+		}
+		for z.Len() > 0 {
+			z.PopFront()
+		}
+		assert.Equal(t, minDequeCapacity, len(z.buf), "Capacity of a drained zero value")
+	})
+
+	// NoRetention tests that popped elements are not retained by the buffer.
+	t.Run("NoRetention", func(t *testing.T) {
+		var d Deque[*int]
+		for range 4 {
+			v := 1
+			d.PushBack(&v)
+		}
+		d.PopFront()
+		d.PopBack()
+		retained := 0
+		for _, p := range d.buf {
+			if p != nil {
+				retained++
+			}
+		}
+		assert.Equal(t, 2, retained, "Only the elements left in the deque should be referenced")
+	})
+
+	// BFS tests the deque as the work queue of a breadth-first search.
+	t.Run("BFS", func(t *testing.T) {
+		graph := map[int][]int{1: {2, 3}, 2: {4}, 3: {4, 5}, 4: {6}, 5: {6}}
+		seen := map[int]bool{1: true}
+		var order []int
+		var queue Deque[int]
+		queue.PushBack(1)
+		for queue.Len() > 0 {
+			node, _ := queue.PopFront()
+			order = append(order, node)
+			for _, next := range graph[node] {
+				if !seen[next] {
+					seen[next] = true
+					queue.PushBack(next)
+				}
+			}
+		}
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, order, "Breadth-first order")
+	})
+
+	// Model tests random operations at both ends against a plain slice.
+	t.Run("Model", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(3, 4))
+		var d Deque[int]
+		var model []int
+		for i := range 5000 {
+			switch r.IntN(4) {
+			case 0:
+				d.PushBack(i)
+				model = append(model, i)
+			case 1:
+				d.PushFront(i)
+				model = append([]int{i}, model...)
+			case 2:
+				v, ok := d.PopFront()
+				assert.Equal(t, len(model) > 0, ok, "PopFront presence at step %d", i)
+				if ok {
+					assert.Equal(t, model[0], v, "PopFront at step %d", i)
+					model = model[1:]
+				}
+			case 3:
+				v, ok := d.PopBack()
+				assert.Equal(t, len(model) > 0, ok, "PopBack presence at step %d", i)
+				if ok {
+					assert.Equal(t, model[len(model)-1], v, "PopBack at step %d", i)
+					model = model[:len(model)-1]
+				}
+			}
+		}
+		assert.Equal(t, append([]int{}, model...), d.Slice(), "Final elements")
+	})
+}
@@ -0,0 +1,139 @@
+// Package queue provides two containers backed by circular buffers: Ring, a fixed-capacity buffer that keeps the
+// last N elements, and Deque, a growable double-ended queue. Unlike a slice used as a queue with append and
+// reslicing, neither leaks capacity: Ring never grows, and Deque shrinks its buffer when it empties. Both convert
+// to a []T with Slice, so the functions of the slice package apply to their content.
+package queue
+
+import (
+	"fmt"
+	"iter"
+)
+
+// FullPolicy controls what Ring.Push does when the ring is full.
+type FullPolicy int
+
+const (
+	// OverwriteOldest drops the oldest element to make room for the new one, so the ring keeps the last elements.
+	OverwriteOldest FullPolicy = iota
+	// RejectNew keeps the ring unchanged and makes Push report false.
+	RejectNew
+)
+
+// Ring is a first-in first-out buffer with a fixed capacity. Push and Pop run in O(1) and never allocate.
+// A Ring must be created with NewRing and is not safe for concurrent use.
+type Ring[T any] struct {
+	// buf holds the elements; its length is the capacity of the ring.
+	buf []T
+	// head is the index of the oldest element in buf.
+	head int
+	// size is the number of elements.
+	size int
+	// policy decides what Push does when the ring is full.
+	policy FullPolicy
+}
+
+// NewRing creates an empty ring holding at most capacity elements, with the policy deciding what happens when an
+// element is pushed onto a full ring. It panics when the capacity is not positive, like make does for a negative
+// length, since no ring could hold an element.
+func NewRing[T any](capacity int, policy FullPolicy) *Ring[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("queue: NewRing capacity must be positive, got %d", capacity))
+	}
+	return &Ring[T]{buf: make([]T, capacity), policy: policy}
+}
+
+// Len returns the number of elements in the ring.
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+// Cap returns the maximum number of elements the ring holds.
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Full reports whether the ring holds as many elements as its capacity.
+func (r *Ring[T]) Full() bool {
+	return r.size == len(r.buf)
+}
+
+// Push adds the element as the newest one and reports whether it was stored. On a full ring, OverwriteOldest
+// drops the oldest element and always reports true, while RejectNew leaves the ring unchanged and reports false.
+func (r *Ring[T]) Push(v T) bool {
+	if r.Full() {
+		if r.policy == RejectNew {
+			return false
+		}
+
+		// Overwrite the oldest element, which makes its successor the oldest one.
+		r.buf[r.head] = v
+		r.head = (r.head + 1) % len(r.buf)
+		return true
+	}
+	r.buf[(r.head+r.size)%len(r.buf)] = v
+	r.size++
+	return true
+}
+
+// Pop removes and returns the oldest element, or the zero value and false when the ring is empty.
+func (r *Ring[T]) Pop() (T, bool) {
+	var zero T
+	if r.size == 0 {
+		return zero, false
+	}
+	v := r.buf[r.head]
+
+	// Clear the slot, so the ring does not retain the element.
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.size--
+	return v, true
+}
+
+// Peek returns the oldest element without removing it, or the zero value and false when the ring is empty.
+func (r *Ring[T]) Peek() (T, bool) {
+	return r.At(0)
+}
+
+// PeekNewest returns the newest element without removing it, or the zero value and false when the ring is empty.
+func (r *Ring[T]) PeekNewest() (T, bool) {
+	return r.At(r.size - 1)
+}
+
+// At returns the element at position i, counted from the oldest element at position 0, or the zero value and
+// false when i is out of range.
+func (r *Ring[T]) At(i int) (T, bool) {
+	if i < 0 || i >= r.size {
+		var zero T
+		return zero, false
+	}
+	return r.buf[(r.head+i)%len(r.buf)], true
+}
+
+// Clear removes every element from the ring.
+func (r *Ring[T]) Clear() {
+	clear(r.buf)
+	r.head, r.size = 0, 0
+}
+
+// All returns a sequence over the elements from the oldest to the newest. The ring must not be modified during
+// the iteration.
+func (r *Ring[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range r.size {
+			if !yield(r.buf[(r.head+i)%len(r.buf)]) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the elements from the oldest to the newest in a newly allocated slice.
+func (r *Ring[T]) Slice() []T {
+	result := make([]T, r.size)
+
+	// Copy the part up to the end of the buffer, then the part that wrapped around to its start.
+	n := copy(result, r.buf[r.head:min(r.head+r.size, len(r.buf))])
+	copy(result[n:], r.buf[:r.size-n])
+	return result
+}
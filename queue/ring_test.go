@@ -0,0 +1,121 @@
+package queue
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spacemagneto/common/slice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRing(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases that push a sequence of elements onto a ring of capacity three.
+	cases := []struct {
+		name             string
+		policy           FullPolicy
+		pushed           []int
+		expected         []int
+		expectedRejected []int
+	}{
+		{name: "Empty", policy: OverwriteOldest, pushed: nil, expected: []int{}},
+		{name: "Below capacity", policy: OverwriteOldest, pushed: []int{1, 2}, expected: []int{1, 2}},
+		{name: "At capacity", policy: RejectNew, pushed: []int{1, 2, 3}, expected: []int{1, 2, 3}},
+		{name: "Overwrite keeps the last elements", policy: OverwriteOldest, pushed: []int{1, 2, 3, 4, 5, 6, 7}, expected: []int{5, 6, 7}},
+		{name: "Reject keeps the first elements", policy: RejectNew, pushed: []int{1, 2, 3, 4, 5}, expected: []int{1, 2, 3}, expectedRejected: []int{4, 5}},
+	}
+
+	// Iterate over the test cases, checking the content through every accessor.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRing[int](3, tt.policy)
+			var rejected []int
+			for _, v := range tt.pushed {
+				if !r.Push(v) {
+					rejected = append(rejected, v)
+				}
+			}
+			assert.Equal(t, tt.expectedRejected, rejected, "Rejected elements for case %q", tt.name)
+			assert.Equal(t, tt.expected, r.Slice(), "Slice for case %q", tt.name)
+			assert.Equal(t, tt.expected, append([]int{}, slices.Collect(r.All())...), "All for case %q", tt.name)
+			assert.Equal(t, len(tt.expected), r.Len(), "Length for case %q", tt.name)
+			assert.Equal(t, len(tt.expected) == 3, r.Full(), "Full for case %q", tt.name)
+
+			for i, v := range tt.expected {
+				at, ok := r.At(i)
+				assert.True(t, ok, "At(%d) for case %q", i, tt.name)
+				assert.Equal(t, v, at, "At(%d) for case %q", i, tt.name)
+			}
+			_, ok := r.At(len(tt.expected))
+			assert.False(t, ok, "At out of range for case %q", tt.name)
+		})
+	}
+
+	// PopAndPeek tests the FIFO order across the wrap-around of the buffer.
+	t.Run("PopAndPeek", func(t *testing.T) {
+		r := NewRing[string](2, OverwriteOldest)
+		_, ok := r.Pop()
+		assert.False(t, ok, "Pop of an empty ring")
+		_, ok = r.Peek()
+		assert.False(t, ok, "Peek of an empty ring")
+
+		r.Push("a")
+		r.Push("b")
+		r.Push("c")
+		oldest, _ := r.Peek()
+		newest, _ := r.PeekNewest()
+		assert.Equal(t, "b", oldest, "Oldest element")
+		assert.Equal(t, "c", newest, "Newest element")
+
+		v, ok := r.Pop()
+		assert.True(t, ok, "Pop")
+		assert.Equal(t, "b", v, "Popped element")
+		r.Push("d")
+		assert.Equal(t, []string{"c", "d"}, r.Slice(), "Elements after wrapping around")
+
+		r.Clear()
+		assert.Zero(t, r.Len(), "Clear")
+		assert.Equal(t, 2, r.Cap(), "Clear keeps the capacity")
+		assert.Equal(t, []string{"", ""}, r.buf, "Clear should not retain the elements")
+	})
+
+	// InvalidCapacity tests that a ring needs a positive capacity.
+	t.Run("InvalidCapacity", func(t *testing.T) {
+		assert.PanicsWithValue(t, "queue: NewRing capacity must be positive, got 0", func() { NewRing[int](0, OverwriteOldest) }, "Zero capacity")
+		assert.PanicsWithValue(t, "queue: NewRing capacity must be positive, got -1", func() { NewRing[int](-1, RejectNew) }, "Negative capacity")
+	})
+
+	// SliceFunctions tests that the content works with the functions of the slice package.
+	t.Run("SliceFunctions", func(t *testing.T) {
+		r := NewRing[int](4, OverwriteOldest)
+		for v := range 10 {
+			r.Push(v)
+		}
+		assert.Equal(t, []int{6, 8}, slice.Filter(r.Slice(), func(v int) bool { return v%2 == 0 }), "slice.Filter")
+		assert.Equal(t, []int{12, 14, 16, 18}, slice.Map(r.Slice(), func(v int) int { return 2 * v }), "slice.Map")
+	})
+
+	// Model tests random pushes and pops against a slice that keeps the last elements.
+	t.Run("Model", func(t *testing.T) {
+		r := NewRing[int](5, OverwriteOldest)
+		var model []int
+		for i := range 200 {
+			if i%3 == 2 {
+				v, ok := r.Pop()
+				assert.Equal(t, len(model) > 0, ok, "Pop presence at step %d", i)
+				if len(model) > 0 {
+					assert.Equal(t, model[0], v, "Popped element at step %d", i)
+					model = model[1:]
+				}
+				continue
+			}
+			r.Push(i)
+			model = append(model, i)
+			if len(model) > 5 {
+				model = model[1:]
+			}
+			assert.Equal(t, model, r.Slice(), "Elements at step %d", i)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package roaring
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/spacemagneto/common/slice"
+)
+
+// The benchmarks in this file compare the Bitmap with the sorted-slice and map idioms it replaces, over sparse
+// IDs spread across the whole uint32 range and over IDs clustered in dense ranges. Run them with:
+//
+//	go test -run '^$' -bench . -benchmem ./roaring
+
+// benchSize is the number of values of every set.
+const benchSize = 1 << 18
+
+// benchInputs returns two sets of values of the shape.
+func benchInputs(shape string) ([]uint32, []uint32) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	generate := func() []uint32 {
+		values := make([]uint32, benchSize)
+		for i := range values {
+			if shape == "sparse" {
+				values[i] = rng.Uint32()
+			} else {
+				// Clustered IDs fill about half of the first 64 chunks.
+				values[i] = rng.Uint32N(1 << 22)
+			}
+		}
+		return values
+	}
+	return generate(), generate()
+}
+
+// BenchmarkDedup collects the distinct values, with a Bitmap and with slice.Unique.
+func BenchmarkDedup(b *testing.B) {
+	for _, shape := range []string{"sparse", "clustered"} {
+		values, _ := benchInputs(shape)
+		b.Run(fmt.Sprintf("Bitmap/%s", shape), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				FromUint32s(values)
+			}
+		})
+		b.Run(fmt.Sprintf("Unique/%s", shape), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				slice.Unique(values)
+			}
+		})
+	}
+}
+
+// BenchmarkUnion merges two sets, with Bitmap.Union and with slice.SortedUnion over sorted values.
+func BenchmarkUnion(b *testing.B) {
+	for _, shape := range []string{"sparse", "clustered"} {
+		left, right := benchInputs(shape)
+		a, c := FromUint32s(left), FromUint32s(right)
+		sortedLeft, sortedRight := a.Uint32s(), c.Uint32s()
+		b.Run(fmt.Sprintf("Bitmap/%s", shape), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				a.Union(c)
+			}
+		})
+		b.Run(fmt.Sprintf("SortedUnion/%s", shape), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				slice.SortedUnion(sortedLeft, sortedRight)
+			}
+		})
+	}
+}
+
+// BenchmarkIntersection intersects two sets, with Bitmap.Intersection and with slice.SortedIntersect over sorted
+// values.
+func BenchmarkIntersection(b *testing.B) {
+	for _, shape := range []string{"sparse", "clustered"} {
+		left, right := benchInputs(shape)
+		a, c := FromUint32s(left), FromUint32s(right)
+		sortedLeft, sortedRight := a.Uint32s(), c.Uint32s()
+		b.Run(fmt.Sprintf("Bitmap/%s", shape), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				a.Intersection(c)
+			}
+		})
+		b.Run(fmt.Sprintf("SortedIntersect/%s", shape), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				slice.SortedIntersect(sortedLeft, sortedRight)
+			}
+		})
+	}
+}
@@ -0,0 +1,542 @@
+package roaring
+
+import (
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+// containerKind is the representation of the low 16 bits of the values of a chunk.
+type containerKind uint8
+
+const (
+	// arrayKind stores the values as a sorted []uint16, two bytes per value.
+	arrayKind containerKind = iota
+	// bitmapKind stores the values as 65536 bits, 8 KiB whatever the number of values.
+	bitmapKind
+	// runKind stores the values as sorted ranges of consecutive values, four bytes per range.
+	runKind
+)
+
+const (
+	// arrayMaxSize is the largest number of values of an array container, beyond which a bitmap is smaller.
+	arrayMaxSize = 4096
+	// bitmapSize is the size in bytes of a bitmap container.
+	bitmapSize = 1 << 16 / 8
+	// bitmapWords is the number of 16-bit words of a bitmap container.
+	bitmapWords = bitmapSize / 2
+)
+
+// run is a range of consecutive values, from start to last inclusive.
+type run struct {
+	start, last uint16
+}
+
+// container holds the low 16 bits of the values of a chunk, in one of three representations sharing a single
+// []uint16 payload, which keeps a sparse chunk to a 32-byte header and the array of its values. It never holds
+// zero values: the Bitmap drops empty containers.
+type container struct {
+	// data holds the sorted values of an array container, the 16-bit words of a bitmap container, value v being
+	// bit v%16 of word v/16, or the start and last values of every range of a run container, in increasing order
+	// and separated by at least one absent value.
+	data []uint16
+	// card is the number of values, kept up to date by every representation.
+	card int32
+	kind containerKind
+}
+
+// newArray creates an array container holding the sorted, distinct values.
+func newArray(values []uint16) container {
+	return container{kind: arrayKind, data: values, card: int32(len(values))}
+}
+
+// newBitmap creates a bitmap container holding the bits of the words.
+func newBitmap(words []uint16) container {
+	card := 0
+	for _, w := range words {
+		card += bits.OnesCount16(w)
+	}
+	return container{kind: bitmapKind, data: words, card: int32(card)}
+}
+
+// newRuns creates a run container holding the sorted, separated ranges.
+func newRuns(runs []run) container {
+	data := make([]uint16, 0, 2*len(runs))
+	card := 0
+	for _, r := range runs {
+		data = append(data, r.start, r.last)
+		card += int(r.last-r.start) + 1
+	}
+	return container{kind: runKind, data: data, card: int32(card)}
+}
+
+// clone returns a deep copy of the container.
+func (c *container) clone() container {
+	return container{kind: c.kind, data: slices.Clone(c.data), card: c.card}
+}
+
+// equal reports whether both containers hold the same values, whatever their representations.
+func (c *container) equal(other *container) bool {
+	if c.card != other.card {
+		return false
+	}
+
+	// Arrays and runs have a single form for the same values; compare the bits of mixed representations.
+	if c.kind == other.kind && c.kind != bitmapKind {
+		return slices.Equal(c.data, other.data)
+	}
+	return slices.Equal(c.bitmapWords(), other.bitmapWords())
+}
+
+// contains reports whether the container holds the value.
+func (c *container) contains(v uint16) bool {
+	switch c.kind {
+	case arrayKind:
+		_, found := slices.BinarySearch(c.data, v)
+		return found
+	case bitmapKind:
+		return c.data[v/16]&(1<<(v%16)) != 0
+	default:
+		i := sort.Search(c.numRuns(), func(i int) bool { return c.data[2*i+1] >= v })
+		return i < c.numRuns() && c.data[2*i] <= v
+	}
+}
+
+// add adds the value and reports whether it was absent. An array container that outgrows arrayMaxSize becomes a
+// bitmap, and a run container fragmented into more runs than fit in a bitmap becomes an array or a bitmap.
+func (c *container) add(v uint16) bool {
+	switch c.kind {
+	case arrayKind:
+		i, found := slices.BinarySearch(c.data, v)
+		if found {
+			return false
+		}
+		if c.card == arrayMaxSize {
+			c.convert(bitmapKind)
+			return c.add(v)
+		}
+		c.data = slices.Insert(c.data, i, v)
+	case bitmapKind:
+		if c.data[v/16]&(1<<(v%16)) != 0 {
+			return false
+		}
+		c.data[v/16] |= 1 << (v % 16)
+	default:
+		if !c.addRun(v) {
+			return false
+		}
+	}
+	c.card++
+	c.limitRuns()
+	return true
+}
+
+// addRun adds the value to a run container, extending or merging the neighbouring runs when it touches them.
+func (c *container) addRun(v uint16) bool {
+	// Find the first run starting after the value; the run before it is the only one that may hold it.
+	n := c.numRuns()
+	i := sort.Search(n, func(i int) bool { return c.data[2*i] > v })
+	if i > 0 && c.data[2*i-1] >= v {
+		return false
+	}
+	extendsPrev := i > 0 && int(c.data[2*i-1])+1 == int(v)
+	extendsNext := i < n && int(c.data[2*i]) == int(v)+1
+	switch {
+	case extendsPrev && extendsNext:
+		c.data = slices.Delete(c.data, 2*i-1, 2*i+1)
+	case extendsPrev:
+		c.data[2*i-1] = v
+	case extendsNext:
+		c.data[2*i] = v
+	default:
+		c.data = slices.Insert(c.data, 2*i, v, v)
+	}
+	return true
+}
+
+// remove removes the value and reports whether it was present. A bitmap container that shrinks to arrayMaxSize
+// values becomes an array, and a run container fragmented into more runs than fit in a bitmap becomes an array
+// or a bitmap.
+func (c *container) remove(v uint16) bool {
+	switch c.kind {
+	case arrayKind:
+		i, found := slices.BinarySearch(c.data, v)
+		if !found {
+			return false
+		}
+		c.data = slices.Delete(c.data, i, i+1)
+	case bitmapKind:
+		if c.data[v/16]&(1<<(v%16)) == 0 {
+			return false
+		}
+		c.data[v/16] &^= 1 << (v % 16)
+		if c.card-1 == arrayMaxSize {
+			c.card--
+			c.convert(arrayKind)
+			return true
+		}
+	default:
+		if !c.removeRun(v) {
+			return false
+		}
+	}
+	c.card--
+	c.limitRuns()
+	return true
+}
+
+// removeRun removes the value from a run container, shrinking or splitting the run holding it.
+func (c *container) removeRun(v uint16) bool {
+	n := c.numRuns()
+	i := sort.Search(n, func(i int) bool { return c.data[2*i+1] >= v })
+	if i == n || c.data[2*i] > v {
+		return false
+	}
+	start, last := c.data[2*i], c.data[2*i+1]
+	switch {
+	case start == last:
+		c.data = slices.Delete(c.data, 2*i, 2*i+2)
+	case v == start:
+		c.data[2*i]++
+	case v == last:
+		c.data[2*i+1]--
+	default:
+		c.data[2*i+1] = v - 1
+		c.data = slices.Insert(c.data, 2*i+2, v+1, last)
+	}
+	return true
+}
+
+// limitRuns converts a run container whose runs take more memory than a bitmap to an array when it holds at most
+// arrayMaxSize values and to a bitmap otherwise, so that Add and Remove never grow a chunk past a bitmap.
+func (c *container) limitRuns() {
+	if c.kind != runKind || 4*c.numRuns() <= bitmapSize {
+		return
+	}
+	if c.card <= arrayMaxSize {
+		c.convert(arrayKind)
+	} else {
+		c.convert(bitmapKind)
+	}
+}
+
+// all yields the values of the container with the high bits added, in increasing order. It returns false when
+// yield stopped the iteration.
+func (c *container) all(high uint32, yield func(uint32) bool) bool {
+	switch c.kind {
+	case arrayKind:
+		for _, v := range c.data {
+			if !yield(high | uint32(v)) {
+				return false
+			}
+		}
+	case bitmapKind:
+		for i, w := range c.data {
+			for w != 0 {
+				if !yield(high | uint32(i*16+bits.TrailingZeros16(w))) {
+					return false
+				}
+				w &= w - 1
+			}
+		}
+	default:
+		for i := 0; i < len(c.data); i += 2 {
+			for v := uint32(c.data[i]); v <= uint32(c.data[i+1]); v++ {
+				if !yield(high | v) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// arrayValues returns the values of the container as a sorted slice, which is shared with an array container.
+func (c *container) arrayValues() []uint16 {
+	if c.kind == arrayKind {
+		return c.data
+	}
+	values := make([]uint16, 0, c.card)
+	c.all(0, func(v uint32) bool {
+		values = append(values, uint16(v))
+		return true
+	})
+	return values
+}
+
+// bitmapWords returns the values of the container as bits, which are shared with a bitmap container.
+func (c *container) bitmapWords() []uint16 {
+	if c.kind == bitmapKind {
+		return c.data
+	}
+	words := make([]uint16, bitmapWords)
+	c.orInto(words)
+	return words
+}
+
+// orInto sets the bits of the values of the container in the words.
+func (c *container) orInto(words []uint16) {
+	switch c.kind {
+	case arrayKind:
+		for _, v := range c.data {
+			words[v/16] |= 1 << (v % 16)
+		}
+	case bitmapKind:
+		for i, w := range c.data {
+			words[i] |= w
+		}
+	default:
+		for i := 0; i < len(c.data); i += 2 {
+			setRange(words, int(c.data[i]), int(c.data[i+1]))
+		}
+	}
+}
+
+// setRange sets the bits from start to last inclusive, a word at a time.
+func setRange(words []uint16, start, last int) {
+	first, final := start/16, last/16
+	low := ^uint16(0) << (start % 16)
+	high := ^uint16(0) >> (15 - last%16)
+	if first == final {
+		words[first] |= low & high
+		return
+	}
+	words[first] |= low
+	for i := first + 1; i < final; i++ {
+		words[i] = ^uint16(0)
+	}
+	words[final] |= high
+}
+
+// runRanges returns the values of the container as a new slice of runs.
+func (c *container) runRanges() []run {
+	runs := make([]run, 0, c.numRuns())
+	switch c.kind {
+	case runKind:
+		for i := 0; i < len(c.data); i += 2 {
+			runs = append(runs, run{start: c.data[i], last: c.data[i+1]})
+		}
+	case arrayKind:
+		for _, v := range c.data {
+			if n := len(runs); n > 0 && int(runs[n-1].last)+1 == int(v) {
+				runs[n-1].last = v
+			} else {
+				runs = append(runs, run{start: v, last: v})
+			}
+		}
+	default:
+		for i, w := range c.data {
+			// Peel the runs of set bits off the word, lowest first, joining them to a run ending in the previous
+			// word.
+			for w != 0 {
+				start := bits.TrailingZeros16(w)
+				length := bits.TrailingZeros16(^(w >> start))
+				first, last := uint16(i*16+start), uint16(i*16+start+length-1)
+				if n := len(runs); n > 0 && int(runs[n-1].last)+1 == int(first) {
+					runs[n-1].last = last
+				} else {
+					runs = append(runs, run{start: first, last: last})
+				}
+				if length == 16 {
+					w = 0
+				} else {
+					w &^= (1<<length - 1) << start
+				}
+			}
+		}
+	}
+	return runs
+}
+
+// numRuns returns the number of runs of consecutive values of the container, whatever its representation.
+func (c *container) numRuns() int {
+	switch c.kind {
+	case arrayKind:
+		n := 0
+		for i, v := range c.data {
+			if i == 0 || c.data[i-1]+1 != v {
+				n++
+			}
+		}
+		return n
+	case bitmapKind:
+		// A run starts at every set bit whose preceding bit, possibly in the previous word, is clear.
+		n, carry := 0, uint16(0)
+		for _, w := range c.data {
+			n += bits.OnesCount16(w &^ (w<<1 | carry))
+			carry = w >> 15
+		}
+		return n
+	default:
+		return len(c.data) / 2
+	}
+}
+
+// bestKind returns the representation taking the least memory for the values of the container: runs when they
+// are fewer than the values would take in an array or a bitmap, and otherwise an array up to arrayMaxSize values.
+func (c *container) bestKind() containerKind {
+	size := 2 * int(c.card)
+	kind := arrayKind
+	if c.card > arrayMaxSize {
+		size, kind = bitmapSize, bitmapKind
+	}
+	if 4*c.numRuns() < size {
+		return runKind
+	}
+	return kind
+}
+
+// convert changes the representation of the container.
+func (c *container) convert(kind containerKind) {
+	switch {
+	case kind == c.kind:
+		return
+	case kind == arrayKind:
+		c.data = c.arrayValues()
+	case kind == bitmapKind:
+		c.data = c.bitmapWords()
+	default:
+		c.data = newRuns(c.runRanges()).data
+	}
+	c.kind = kind
+}
+
+// optimize converts the container to its smallest representation.
+func (c *container) optimize() {
+	if c.card > 0 {
+		c.convert(c.bestKind())
+	}
+}
+
+// optimized returns the container converted to its smallest representation. An empty container stays empty, and
+// the Bitmap drops it.
+func optimized(c container) container {
+	c.optimize()
+	return c
+}
+
+// union returns a new container holding the values of both containers, in its smallest representation.
+func union(a, b *container) container {
+	switch {
+	case a.kind == runKind && b.kind == runKind:
+		return optimized(newRuns(unionRuns(a.runRanges(), b.runRanges())))
+	case a.kind == bitmapKind || b.kind == bitmapKind || a.card+b.card > arrayMaxSize:
+		words := slices.Clone(a.bitmapWords())
+		b.orInto(words)
+		return optimized(newBitmap(words))
+	default:
+		return optimized(newArray(unionValues(a.arrayValues(), b.arrayValues())))
+	}
+}
+
+// intersection returns a new container holding the values present in both containers, in its smallest
+// representation, which is empty when there are none.
+func intersection(a, b *container) container {
+	switch {
+	case a.kind == runKind && b.kind == runKind:
+		return optimized(newRuns(intersectRuns(a.runRanges(), b.runRanges())))
+	case a.kind == arrayKind && b.kind == arrayKind:
+		return optimized(newArray(intersectValues(a.data, b.data)))
+	case a.kind == arrayKind || b.kind == arrayKind:
+		// Probe the other container with every value of the array.
+		if b.kind == arrayKind {
+			a, b = b, a
+		}
+		values := make([]uint16, 0, a.card)
+		for _, v := range a.data {
+			if b.contains(v) {
+				values = append(values, v)
+			}
+		}
+		return optimized(newArray(values))
+	default:
+		words := slices.Clone(a.bitmapWords())
+		other := b.bitmapWords()
+		for i := range words {
+			words[i] &= other[i]
+		}
+		return optimized(newBitmap(words))
+	}
+}
+
+// unionValues merges two sorted slices of distinct values into a new one.
+func unionValues(a, b []uint16) []uint16 {
+	result := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i, j = i+1, j+1
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
+
+// intersectValues returns the values present in both sorted slices of distinct values.
+func intersectValues(a, b []uint16) []uint16 {
+	result := make([]uint16, 0, min(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i, j = i+1, j+1
+		}
+	}
+	return result
+}
+
+// unionRuns merges two sorted lists of runs, joining the runs that overlap or touch.
+func unionRuns(a, b []run) []run {
+	result := make([]run, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		// Take the run that starts first.
+		var next run
+		if j == len(b) || (i < len(a) && a[i].start <= b[j].start) {
+			next, i = a[i], i+1
+		} else {
+			next, j = b[j], j+1
+		}
+
+		// Join it to the last run when they overlap or touch.
+		if n := len(result); n > 0 && int(next.start) <= int(result[n-1].last)+1 {
+			result[n-1].last = max(result[n-1].last, next.last)
+		} else {
+			result = append(result, next)
+		}
+	}
+	return result
+}
+
+// intersectRuns returns the ranges covered by both sorted lists of runs.
+func intersectRuns(a, b []run) []run {
+	var result []run
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start, last := max(a[i].start, b[j].start), min(a[i].last, b[j].last)
+		if start <= last {
+			result = append(result, run{start: start, last: last})
+		}
+
+		// Move past the run that ends first; the other may overlap the next one.
+		if a[i].last < b[j].last {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
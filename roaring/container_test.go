@@ -0,0 +1,142 @@
+package roaring
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lowValues returns the values of the container as uint16.
+func lowValues(c *container) []uint16 {
+	return append([]uint16{}, c.arrayValues()...)
+}
+
+func TestRunContainer(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases that add or remove one value of the runs 10-12 and 20-22; a positive op adds the value and
+	// a negative one removes its opposite.
+	cases := []struct {
+		name         string
+		op           int
+		expectedRuns []run
+		expectedOK   bool
+	}{
+		{name: "Add inside", op: 11, expectedRuns: []run{{10, 12}, {20, 22}}, expectedOK: false},
+		{name: "Add apart", op: 16, expectedRuns: []run{{10, 12}, {16, 16}, {20, 22}}, expectedOK: true},
+		{name: "Add extends the previous run", op: 13, expectedRuns: []run{{10, 13}, {20, 22}}, expectedOK: true},
+		{name: "Add extends the next run", op: 9, expectedRuns: []run{{9, 12}, {20, 22}}, expectedOK: true},
+		{name: "Add at the end", op: 23, expectedRuns: []run{{10, 12}, {20, 23}}, expectedOK: true},
+		{name: "Remove the start", op: -10, expectedRuns: []run{{11, 12}, {20, 22}}, expectedOK: true},
+		{name: "Remove the last", op: -22, expectedRuns: []run{{10, 12}, {20, 21}}, expectedOK: true},
+		{name: "Remove splits", op: -21, expectedRuns: []run{{10, 12}, {20, 20}, {22, 22}}, expectedOK: true},
+		{name: "Remove absent", op: -15, expectedRuns: []run{{10, 12}, {20, 22}}, expectedOK: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := newRuns([]run{{10, 12}, {20, 22}})
+			var ok bool
+			if tt.op >= 0 {
+				ok = c.add(uint16(tt.op))
+			} else {
+				ok = c.remove(uint16(-tt.op))
+			}
+			assert.Equal(t, tt.expectedOK, ok, "Result for case %q", tt.name)
+			assert.Equal(t, tt.expectedRuns, c.runRanges(), "Runs for case %q", tt.name)
+			assert.Equal(t, newRuns(tt.expectedRuns).card, c.card, "Cardinality for case %q", tt.name)
+		})
+	}
+
+	// Fragmented tests that a run container split into more runs than fit in a bitmap falls back to a bitmap, or
+	// to an array when it holds few values.
+	t.Run("Fragmented", func(t *testing.T) {
+		t.Parallel()
+		c := newRuns([]run{{0, 0xFFFF}})
+		for v := 0; v < 1<<16; v += 2 {
+			c.remove(uint16(v))
+			assert.LessOrEqual(t, 2*len(c.data), bitmapSize, "Size of the container after removing %d", v)
+		}
+		assert.Equal(t, bitmapKind, c.kind, "Kind after removing every even value")
+		assert.Equal(t, int32(1<<15), c.card, "Cardinality after removing every even value")
+		assert.True(t, c.contains(1), "Contains of an odd value")
+		assert.False(t, c.contains(2), "Contains of an even value")
+
+		c = newRuns([]run{{0, 0}})
+		for v := 2; v <= 6000; v += 2 {
+			c.add(uint16(v))
+		}
+		assert.Equal(t, arrayKind, c.kind, "Kind after adding scattered values")
+		assert.Equal(t, int32(3001), c.card, "Cardinality after adding scattered values")
+	})
+
+	// Merge tests that adding the gap between two runs joins them.
+	t.Run("Merge", func(t *testing.T) {
+		t.Parallel()
+		c := newRuns([]run{{0, 4}, {6, 0xFFFF}})
+		assert.True(t, c.add(5), "Add of the gap")
+		assert.Equal(t, []run{{0, 0xFFFF}}, c.runRanges(), "Runs after the merge")
+		assert.Equal(t, int32(1<<16), c.card, "Cardinality after the merge")
+		assert.True(t, c.remove(0xFFFF), "Remove of the last value")
+		assert.False(t, c.contains(0xFFFF), "Contains of the removed value")
+	})
+}
+
+func TestContainerConversions(t *testing.T) {
+	t.Parallel()
+
+	// GrowAndShrink tests that an array becomes a bitmap past arrayMaxSize values and back.
+	t.Run("GrowAndShrink", func(t *testing.T) {
+		t.Parallel()
+		c := newArray(nil)
+		for v := range arrayMaxSize {
+			c.add(uint16(2 * v))
+		}
+		assert.Equal(t, arrayKind, c.kind, "Kind at arrayMaxSize values")
+		c.add(1)
+		assert.Equal(t, bitmapKind, c.kind, "Kind past arrayMaxSize values")
+		assert.Equal(t, int32(arrayMaxSize+1), c.card, "Cardinality past arrayMaxSize values")
+		c.remove(1)
+		assert.Equal(t, arrayKind, c.kind, "Kind back at arrayMaxSize values")
+		assert.Equal(t, int32(arrayMaxSize), c.card, "Cardinality back at arrayMaxSize values")
+	})
+
+	// RoundTrip tests that every representation converts to every other without losing values, across word
+	// boundaries.
+	t.Run("RoundTrip", func(t *testing.T) {
+		t.Parallel()
+		values := []uint16{0, 1, 2, 62, 63, 64, 65, 127, 128, 1000, 0xFFFE, 0xFFFF}
+		expectedRuns := []run{{0, 2}, {62, 65}, {127, 128}, {1000, 1000}, {0xFFFE, 0xFFFF}}
+		for _, from := range []containerKind{arrayKind, bitmapKind, runKind} {
+			for _, to := range []containerKind{arrayKind, bitmapKind, runKind} {
+				c := newArray(slices.Clone(values))
+				c.convert(from)
+				assert.Equal(t, len(expectedRuns), c.numRuns(), "Runs of kind %d", from)
+				c.convert(to)
+				assert.Equal(t, to, c.kind, "Kind converted from %d to %d", from, to)
+				assert.Equal(t, values, lowValues(&c), "Values converted from %d to %d", from, to)
+				assert.Equal(t, expectedRuns, c.runRanges(), "Runs converted from %d to %d", from, to)
+				for _, v := range values {
+					assert.True(t, c.contains(v), "Contains(%d) converted from %d to %d", v, from, to)
+				}
+			}
+		}
+	})
+
+	// BestKind tests the choice of the smallest representation.
+	t.Run("BestKind", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, arrayKind, optimized(newArray([]uint16{1, 3, 5})).kind, "Scattered values")
+		assert.Equal(t, runKind, optimized(newArray([]uint16{1, 2, 3})).kind, "Consecutive values")
+		assert.Equal(t, runKind, optimized(newRuns([]run{{0, 0xFFFF}})).kind, "Full chunk")
+
+		words := make([]uint16, bitmapWords)
+		for i := range words {
+			words[i] = 0x5555
+		}
+		assert.Equal(t, bitmapKind, optimized(newBitmap(words)).kind, "Alternating values")
+		assert.Zero(t, optimized(newArray(nil)).card, "Empty container")
+	})
+}
@@ -0,0 +1,166 @@
+package roaring
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrInvalidEncoding is reported by UnmarshalBinary when the data is not a Bitmap encoded by MarshalBinary.
+var ErrInvalidEncoding = errors.New("invalid roaring encoding")
+
+// magic starts every encoded Bitmap: "RBM" followed by the version of the format.
+var magic = [4]byte{'R', 'B', 'M', 1}
+
+// The binary format of a Bitmap, version 1, is stable: data written by any version of this package can be read
+// by every later one. Every integer is little-endian.
+//
+//	magic      4 bytes   "RBM" followed by the version byte 0x01
+//	chunks     uint32    the number of chunks that follow, in strictly increasing key order
+//
+// Every chunk is made of a header followed by the payload of its container:
+//
+//	key        uint16    the high 16 bits shared by the values of the chunk
+//	kind       uint8     0 for an array, 1 for a bitmap, 2 for runs
+//	count      uint32    the number of values of an array or a bitmap, or the number of runs
+//	payload              array:  count uint16 values, strictly increasing
+//	                     bitmap: 1024 uint64 words, value v being bit v%64 of word v/64
+//	                     runs:   count pairs of uint16 start and last values, inclusive, in increasing order and
+//	                             separated by at least one absent value
+//
+// Chunks are never empty. MarshalBinary writes every chunk in its smallest container, which makes the encoding
+// canonical: equal sets encode to the same bytes. UnmarshalBinary accepts any valid container for a chunk.
+const (
+	// headerSize is the size of the magic and the number of chunks.
+	headerSize = 8
+	// chunkHeaderSize is the size of the key, the kind and the count of a chunk.
+	chunkHeaderSize = 7
+)
+
+// MarshalBinary encodes the set in the binary format documented above.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	data := append(make([]byte, 0, headerSize), magic[:]...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(b.keys)))
+
+	// Write every chunk in its smallest container, converting copies of the others.
+	for i := range b.containers {
+		c := &b.containers[i]
+		kind := c.bestKind()
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		data = append(data, byte(kind))
+		switch kind {
+		case arrayKind:
+			values := c.arrayValues()
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(values)))
+			for _, v := range values {
+				data = binary.LittleEndian.AppendUint16(data, v)
+			}
+		case bitmapKind:
+			// The little-endian 16-bit words are laid out exactly like the 64-bit words of the format.
+			data = binary.LittleEndian.AppendUint32(data, uint32(c.card))
+			for _, w := range c.bitmapWords() {
+				data = binary.LittleEndian.AppendUint16(data, w)
+			}
+		default:
+			runs := c.runRanges()
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(runs)))
+			for _, r := range runs {
+				data = binary.LittleEndian.AppendUint16(data, r.start)
+				data = binary.LittleEndian.AppendUint16(data, r.last)
+			}
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes data encoded by MarshalBinary into the set, replacing its previous content. It reports
+// ErrInvalidEncoding, and leaves the set unchanged, when the data does not follow the format.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return fmt.Errorf("%w: missing header", ErrInvalidEncoding)
+	}
+	n := binary.LittleEndian.Uint32(data[4:])
+	if uint64(n) > 1<<16 {
+		return fmt.Errorf("%w: %d chunks", ErrInvalidEncoding, n)
+	}
+
+	// Decode every chunk, checking that the keys increase.
+	result := Bitmap{keys: make([]uint16, 0, n), containers: make([]container, 0, n)}
+	rest := data[headerSize:]
+	for range n {
+		if len(rest) < chunkHeaderSize {
+			return fmt.Errorf("%w: truncated chunk header", ErrInvalidEncoding)
+		}
+		key := binary.LittleEndian.Uint16(rest)
+		if k := len(result.keys); k > 0 && key <= result.keys[k-1] {
+			return fmt.Errorf("%w: chunk %d out of order", ErrInvalidEncoding, key)
+		}
+		c, size, err := decodeContainer(containerKind(rest[2]), binary.LittleEndian.Uint32(rest[3:]), rest[chunkHeaderSize:])
+		if err != nil {
+			return fmt.Errorf("%w: chunk %d: %s", ErrInvalidEncoding, key, err)
+		}
+		result.keys = append(result.keys, key)
+		result.containers = append(result.containers, c)
+		rest = rest[chunkHeaderSize+size:]
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(rest))
+	}
+
+	*b = result
+	return nil
+}
+
+// decodeContainer decodes the payload of a chunk of the kind and count at the start of data. It returns the
+// container and the size of its payload, or an error describing why it is invalid.
+func decodeContainer(kind containerKind, count uint32, data []byte) (container, int, error) {
+	switch kind {
+	case arrayKind:
+		size := 2 * int(count)
+		if count == 0 || count > 1<<16 || len(data) < size {
+			return container{}, 0, fmt.Errorf("array of %d values in %d bytes", count, len(data))
+		}
+		values := make([]uint16, count)
+		for i := range values {
+			values[i] = binary.LittleEndian.Uint16(data[2*i:])
+			if i > 0 && values[i] <= values[i-1] {
+				return container{}, 0, errors.New("array values out of order")
+			}
+		}
+		return newArray(values), size, nil
+
+	case bitmapKind:
+		size := bitmapSize
+		if len(data) < size {
+			return container{}, 0, fmt.Errorf("bitmap in %d bytes", len(data))
+		}
+		words := make([]uint16, bitmapWords)
+		card := 0
+		for i := range words {
+			words[i] = binary.LittleEndian.Uint16(data[2*i:])
+			card += bits.OnesCount16(words[i])
+		}
+		if card == 0 || uint32(card) != count {
+			return container{}, 0, fmt.Errorf("bitmap of %d values counted as %d", card, count)
+		}
+		return newBitmap(words), size, nil
+
+	case runKind:
+		size := 4 * int(count)
+		if count == 0 || count > 1<<15 || len(data) < size {
+			return container{}, 0, fmt.Errorf("%d runs in %d bytes", count, len(data))
+		}
+		runs := make([]run, count)
+		for i := range runs {
+			runs[i] = run{start: binary.LittleEndian.Uint16(data[4*i:]), last: binary.LittleEndian.Uint16(data[4*i+2:])}
+			if runs[i].start > runs[i].last || (i > 0 && int(runs[i].start) <= int(runs[i-1].last)+1) {
+				return container{}, 0, errors.New("runs out of order")
+			}
+		}
+		return newRuns(runs), size, nil
+
+	default:
+		return container{}, 0, fmt.Errorf("unknown container kind %d", kind)
+	}
+}
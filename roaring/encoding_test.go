@@ -0,0 +1,127 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(5, 6))
+
+	// Define test cases round-tripping sets with every kind of container.
+	cases := []struct {
+		name          string
+		values        []uint32
+		expectedBytes int
+	}{
+		{name: "Empty", values: nil, expectedBytes: headerSize},
+		{name: "Array", values: []uint32{1, 2, 4}, expectedBytes: headerSize + chunkHeaderSize + 6},
+		{name: "Runs", values: rangeValues(70000, 80000), expectedBytes: headerSize + chunkHeaderSize + 4},
+		{name: "Bitmap", values: randomValues(rng, 50000, 1<<16), expectedBytes: headerSize + chunkHeaderSize + bitmapSize},
+		{name: "Mixed", values: append(rangeValues(1<<16, 1<<17-1), 3, 1<<31), expectedBytes: headerSize + 3*chunkHeaderSize + 2 + 4 + 2},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := FromUint32s(tt.values)
+			data, err := b.MarshalBinary()
+			assert.NoError(t, err, "Marshal error for case %q", tt.name)
+			assert.Len(t, data, tt.expectedBytes, "Encoded length for case %q", tt.name)
+
+			decoded := New(9, 99, 999)
+			assert.NoError(t, decoded.UnmarshalBinary(data), "Unmarshal error for case %q", tt.name)
+			assert.Equal(t, b.Uint32s(), decoded.Uint32s(), "Round trip for case %q", tt.name)
+
+			// Sets built in other representations encode to the same bytes.
+			var added Bitmap
+			for _, v := range tt.values {
+				added.Add(v)
+			}
+			canonical, _ := added.MarshalBinary()
+			assert.Equal(t, data, canonical, "Canonical encoding for case %q", tt.name)
+		})
+	}
+
+	// Layout tests the documented format byte by byte.
+	t.Run("Layout", func(t *testing.T) {
+		t.Parallel()
+		b := New(1, 3)
+		b.AddRange(0x20005, 0x20009)
+		data, _ := b.MarshalBinary()
+		expected := []byte{
+			'R', 'B', 'M', 1, 2, 0, 0, 0,
+			0, 0, 0, 2, 0, 0, 0, 1, 0, 3, 0,
+			2, 0, 2, 1, 0, 0, 0, 5, 0, 9, 0,
+		}
+		assert.Equal(t, expected, data, "Encoded bytes")
+	})
+
+	// BitmapLayout tests that a bitmap is written as 64-bit little-endian words, value v being bit v%8 of byte v/8.
+	t.Run("BitmapLayout", func(t *testing.T) {
+		t.Parallel()
+		var values []uint32
+		for v := uint32(0); v < 1<<16; v += 2 {
+			values = append(values, v)
+		}
+		values = append(values, 0xFFFF)
+		data, _ := FromUint32s(values).MarshalBinary()
+		assert.Equal(t, byte(bitmapKind), data[headerSize+2], "Kind of the chunk")
+
+		payload := data[headerSize+chunkHeaderSize:]
+		assert.Equal(t, uint64(0x5555555555555555), binary.LittleEndian.Uint64(payload), "First word")
+		assert.Equal(t, uint64(0xD555555555555555), binary.LittleEndian.Uint64(payload[bitmapSize-8:]), "Last word")
+	})
+
+	// Invalid tests that malformed data is rejected and leaves the set unchanged.
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		valid, _ := New(1, 3, 1<<20).MarshalBinary()
+		bitmap, _ := FromUint32s(randomValues(rng, 50000, 1<<16)).MarshalBinary()
+		runs, _ := FromUint32s(rangeValues(10, 100)).MarshalBinary()
+
+		// corrupt returns a copy of data with the byte at offset replaced.
+		corrupt := func(data []byte, offset int, value byte) []byte {
+			data = bytes.Clone(data)
+			data[offset] = value
+			return data
+		}
+		// withCount returns a copy of data with the count of the first chunk replaced.
+		withCount := func(data []byte, count uint32) []byte {
+			data = bytes.Clone(data)
+			binary.LittleEndian.PutUint32(data[headerSize+3:], count)
+			return data
+		}
+
+		cases := []struct {
+			name string
+			data []byte
+		}{
+			{name: "Nil", data: nil},
+			{name: "Bad magic", data: corrupt(valid, 0, 'X')},
+			{name: "Bad version", data: corrupt(valid, 3, 2)},
+			{name: "Too many chunks", data: corrupt(valid, 6, 2)},
+			{name: "Truncated", data: valid[:len(valid)-1]},
+			{name: "Trailing bytes", data: append(bytes.Clone(valid), 0)},
+			{name: "Unknown kind", data: corrupt(valid, headerSize+2, 7)},
+			{name: "Empty array", data: withCount(valid, 0)},
+			{name: "Array out of order", data: corrupt(valid, headerSize+chunkHeaderSize+2, 1)},
+			{name: "Chunks out of order", data: corrupt(valid, headerSize+chunkHeaderSize+4, 0)},
+			{name: "Bitmap miscounted", data: withCount(bitmap, 1)},
+			{name: "Run reversed", data: corrupt(runs, headerSize+chunkHeaderSize+2, 5)},
+			{name: "Runs overflow", data: withCount(runs, 1<<16)},
+		}
+		for _, tt := range cases {
+			b := New(42)
+			err := b.UnmarshalBinary(tt.data)
+			assert.ErrorIs(t, err, ErrInvalidEncoding, "Error for case %q", tt.name)
+			assert.Equal(t, []uint32{42}, b.Uint32s(), "Set after an error for case %q", tt.name)
+		}
+	})
+}
@@ -0,0 +1,231 @@
+// Package roaring provides Bitmap, a compressed set of uint32 values in the style of Roaring bitmaps. The values
+// are split into chunks of 65536 by their high 16 bits, and each chunk stores its low 16 bits in the smallest of
+// three containers: a sorted array for sparse chunks, a bitmap for dense ones, and a list of runs for chunks of
+// consecutive values. A million random IDs spread over the whole uint32 range take about 4.4 MiB, close to the
+// 3.8 MiB of a []uint32 and far from the tens of MiB of a map, and 2.3 MiB once encoded; a million IDs below 1<<24
+// fill 256 bitmaps, 2 MiB. Unions and intersections work a chunk at a time on the containers instead of value by
+// value.
+package roaring
+
+import (
+	"iter"
+	"slices"
+)
+
+// Bitmap is a compressed set of uint32 values.
+// The zero value is an empty set that is ready to use. A Bitmap is not safe for concurrent use.
+type Bitmap struct {
+	// keys holds the high 16 bits of the chunks in increasing order.
+	keys []uint16
+	// containers holds the low 16 bits of the values of the chunk with the key at the same index. None is empty.
+	containers []container
+}
+
+// New creates a set holding the provided values, with duplicates collapsed.
+func New(values ...uint32) *Bitmap {
+	return FromUint32s(values)
+}
+
+// FromUint32s creates a set holding the values of the slice in any order, with duplicates collapsed. Every
+// container is built in its smallest representation.
+func FromUint32s(values []uint32) *Bitmap {
+	// Sort a copy of the values, so that the values of every chunk are contiguous.
+	sorted := slices.Compact(slices.Sorted(slices.Values(values)))
+
+	// Count the chunks to allocate them once, since a sparse set has up to one chunk per 16 values.
+	chunks := 0
+	for i, v := range sorted {
+		if i == 0 || v>>16 != sorted[i-1]>>16 {
+			chunks++
+		}
+	}
+
+	// Build a container from the values of every chunk.
+	b := &Bitmap{keys: make([]uint16, 0, chunks), containers: make([]container, 0, chunks)}
+	for len(sorted) > 0 {
+		key := uint16(sorted[0] >> 16)
+		n := 1
+		for n < len(sorted) && uint16(sorted[n]>>16) == key {
+			n++
+		}
+		low := make([]uint16, n)
+		for i, v := range sorted[:n] {
+			low[i] = uint16(v)
+		}
+		b.keys = append(b.keys, key)
+		b.containers = append(b.containers, optimized(newArray(low)))
+		sorted = sorted[n:]
+	}
+	return b
+}
+
+// find returns the index of the chunk of the key, and whether it exists.
+func (b *Bitmap) find(key uint16) (int, bool) {
+	return slices.BinarySearch(b.keys, key)
+}
+
+// Add adds the value to the set.
+func (b *Bitmap) Add(v uint32) {
+	key := uint16(v >> 16)
+	i, found := b.find(key)
+	if !found {
+		b.keys = slices.Insert(b.keys, i, key)
+		b.containers = slices.Insert(b.containers, i, newArray(nil))
+	}
+	b.containers[i].add(uint16(v))
+}
+
+// AddRange adds every value from start to last inclusive. It does nothing when start is greater than last.
+func (b *Bitmap) AddRange(start, last uint32) {
+	// Merge a run container covering the range with every chunk it spans.
+	for key := start >> 16; start <= last && key <= last>>16; key++ {
+		low, high := uint16(0), uint16(0xFFFF)
+		if key == start>>16 {
+			low = uint16(start)
+		}
+		if key == last>>16 {
+			high = uint16(last)
+		}
+		r := newRuns([]run{{start: low, last: high}})
+		if i, found := b.find(uint16(key)); found {
+			b.containers[i] = union(&b.containers[i], &r)
+		} else {
+			b.keys = slices.Insert(b.keys, i, uint16(key))
+			b.containers = slices.Insert(b.containers, i, r)
+		}
+	}
+}
+
+// Remove removes the value from the set. Removing an absent value is a no-op.
+func (b *Bitmap) Remove(v uint32) {
+	i, found := b.find(uint16(v >> 16))
+	if !found {
+		return
+	}
+
+	// Drop the chunk when its last value is removed.
+	if c := &b.containers[i]; c.remove(uint16(v)) && c.card == 0 {
+		b.keys = slices.Delete(b.keys, i, i+1)
+		b.containers = slices.Delete(b.containers, i, i+1)
+	}
+}
+
+// Has reports whether the value is in the set.
+func (b *Bitmap) Has(v uint32) bool {
+	i, found := b.find(uint16(v >> 16))
+	return found && b.containers[i].contains(uint16(v))
+}
+
+// Len returns the number of values in the set.
+func (b *Bitmap) Len() int {
+	n := 0
+	for _, c := range b.containers {
+		n += int(c.card)
+	}
+	return n
+}
+
+// Clear removes every value.
+func (b *Bitmap) Clear() {
+	b.keys, b.containers = nil, nil
+}
+
+// Clone returns a deep copy of the set.
+func (b *Bitmap) Clone() *Bitmap {
+	result := &Bitmap{keys: slices.Clone(b.keys), containers: make([]container, len(b.containers))}
+	for i := range b.containers {
+		result.containers[i] = b.containers[i].clone()
+	}
+	return result
+}
+
+// Equal reports whether both sets hold the same values, whatever the representation of their chunks.
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if !slices.Equal(b.keys, other.keys) {
+		return false
+	}
+	for i := range b.containers {
+		if !b.containers[i].equal(&other.containers[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Optimize converts every chunk to its smallest representation. Add and Remove only switch between arrays and
+// bitmaps as chunks grow and shrink, so calling Optimize after adding runs of consecutive values one by one
+// compresses them into run containers. FromUint32s, AddRange, Union and Intersection build optimized chunks.
+func (b *Bitmap) Optimize() {
+	for i := range b.containers {
+		b.containers[i].optimize()
+	}
+}
+
+// Union returns a new set holding the values present in either set.
+func (b *Bitmap) Union(other *Bitmap) *Bitmap {
+	result := &Bitmap{
+		keys:       make([]uint16, 0, len(b.keys)+len(other.keys)),
+		containers: make([]container, 0, len(b.keys)+len(other.keys)),
+	}
+
+	// Merge the chunks by key, copying the chunks of a single set and merging the common ones.
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(b.keys) && b.keys[i] < other.keys[j]):
+			result.keys = append(result.keys, b.keys[i])
+			result.containers = append(result.containers, b.containers[i].clone())
+			i++
+		case i == len(b.keys) || b.keys[i] > other.keys[j]:
+			result.keys = append(result.keys, other.keys[j])
+			result.containers = append(result.containers, other.containers[j].clone())
+			j++
+		default:
+			result.keys = append(result.keys, b.keys[i])
+			result.containers = append(result.containers, union(&b.containers[i], &other.containers[j]))
+			i, j = i+1, j+1
+		}
+	}
+	return result
+}
+
+// Intersection returns a new set holding the values present in both sets.
+func (b *Bitmap) Intersection(other *Bitmap) *Bitmap {
+	result := &Bitmap{}
+
+	// Only the chunks of both sets can hold common values; drop the ones whose intersection is empty.
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(other.keys) {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			i++
+		case b.keys[i] > other.keys[j]:
+			j++
+		default:
+			if c := intersection(&b.containers[i], &other.containers[j]); c.card > 0 {
+				result.keys = append(result.keys, b.keys[i])
+				result.containers = append(result.containers, c)
+			}
+			i, j = i+1, j+1
+		}
+	}
+	return result
+}
+
+// All returns an iterator over the values of the set in increasing order. The set must not be modified while
+// iterating.
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i := range b.containers {
+			if !b.containers[i].all(uint32(b.keys[i])<<16, yield) {
+				return
+			}
+		}
+	}
+}
+
+// Uint32s returns the values of the set in increasing order, in a newly allocated slice that can be passed to the
+// functions of the slice package.
+func (b *Bitmap) Uint32s() []uint32 {
+	return slices.AppendSeq(make([]uint32, 0, b.Len()), b.All())
+}
@@ -0,0 +1,238 @@
+package roaring
+
+import (
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/spacemagneto/common/slice"
+	"github.com/stretchr/testify/assert"
+)
+
+// kinds returns the kind of the container of every chunk of the set.
+func (b *Bitmap) kinds() []containerKind {
+	kinds := make([]containerKind, len(b.containers))
+	for i, c := range b.containers {
+		kinds[i] = c.kind
+	}
+	return kinds
+}
+
+// sortedDistinct returns the sorted distinct values, as the set should hold them.
+func sortedDistinct(values []uint32) []uint32 {
+	return append([]uint32{}, slice.SortedDedup(slices.Sorted(slices.Values(values)))...)
+}
+
+// rangeValues returns the values from start to last inclusive.
+func rangeValues(start, last uint32) []uint32 {
+	values := make([]uint32, 0, last-start+1)
+	for v := start; v <= last; v++ {
+		values = append(values, v)
+	}
+	return values
+}
+
+// randomValues returns n random values below limit, with possible duplicates.
+func randomValues(rng *rand.Rand, n int, limit uint32) []uint32 {
+	values := make([]uint32, n)
+	for i := range values {
+		values[i] = rng.Uint32N(limit)
+	}
+	return values
+}
+
+func TestBitmap(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	// Define test cases whose values fill the chunks sparsely, densely or with runs.
+	cases := []struct {
+		name          string
+		values        []uint32
+		expectedKinds []containerKind
+	}{
+		{name: "Empty", values: nil, expectedKinds: []containerKind{}},
+		{name: "Sparse", values: []uint32{5, 1, 5, 70000, 1 << 31}, expectedKinds: []containerKind{arrayKind, arrayKind, arrayKind}},
+		{name: "Ends of the range", values: []uint32{0, math.MaxUint32}, expectedKinds: []containerKind{arrayKind, arrayKind}},
+		{name: "Dense", values: randomValues(rng, 30000, 1<<16), expectedKinds: []containerKind{bitmapKind}},
+		{name: "Run", values: rangeValues(100, 20000), expectedKinds: []containerKind{runKind}},
+		{name: "Run across chunks", values: rangeValues(60000, 140000), expectedKinds: []containerKind{runKind, runKind, runKind}},
+		{name: "Mixed", values: append(rangeValues(1<<16, 1<<17-1), 3, 7, 1<<20), expectedKinds: []containerKind{arrayKind, runKind, arrayKind}},
+	}
+
+	// Iterate over the test cases, building the set both ways and checking every accessor.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expected := sortedDistinct(tt.values)
+
+			b := FromUint32s(tt.values)
+			assert.Equal(t, tt.expectedKinds, b.kinds(), "Containers for case %q", tt.name)
+			assert.Equal(t, expected, b.Uint32s(), "Values for case %q", tt.name)
+			assert.Equal(t, expected, append([]uint32{}, slices.Collect(b.All())...), "All for case %q", tt.name)
+			assert.Equal(t, len(expected), b.Len(), "Length for case %q", tt.name)
+
+			var added Bitmap
+			for _, v := range tt.values {
+				added.Add(v)
+			}
+			assert.True(t, added.Equal(b), "Add and FromUint32s agree for case %q", tt.name)
+			added.Optimize()
+			assert.Equal(t, tt.expectedKinds, added.kinds(), "Containers after Optimize for case %q", tt.name)
+
+			for _, v := range expected {
+				assert.True(t, b.Has(v), "Has(%d) for case %q", v, tt.name)
+			}
+			for range 100 {
+				v := rng.Uint32()
+				_, found := slices.BinarySearch(expected, v)
+				assert.Equal(t, found, b.Has(v), "Has(%d) for case %q", v, tt.name)
+			}
+
+			// Removing every value drops every chunk.
+			for _, v := range tt.values {
+				b.Remove(v)
+			}
+			assert.Equal(t, 0, b.Len(), "Length after Remove for case %q", tt.name)
+			assert.Empty(t, b.keys, "Chunks after Remove for case %q", tt.name)
+		})
+	}
+
+	// AddRange tests ranges within a chunk, across chunks and over existing values.
+	t.Run("AddRange", func(t *testing.T) {
+		t.Parallel()
+		b := New(5, 200000)
+		b.AddRange(10, 20)
+		b.AddRange(65530, 65545)
+		b.AddRange(30, 10)
+		expected := sortedDistinct(slices.Concat([]uint32{5, 200000}, rangeValues(10, 20), rangeValues(65530, 65545)))
+		assert.Equal(t, expected, b.Uint32s(), "Values after AddRange")
+
+		full := &Bitmap{}
+		full.AddRange(0, math.MaxUint32)
+		assert.Equal(t, 1<<32, full.Len(), "Length of the full range")
+		assert.Len(t, full.keys, 1<<16, "Chunks of the full range")
+		assert.True(t, full.Has(math.MaxUint32), "Last value of the full range")
+	})
+
+	// CloneAndClear tests that a clone is independent and that Clear empties the set.
+	t.Run("CloneAndClear", func(t *testing.T) {
+		t.Parallel()
+		b := FromUint32s(rangeValues(1, 5000))
+		c := b.Clone()
+		b.Remove(3)
+		assert.True(t, c.Has(3), "Clone after Remove from the original")
+		b.Clear()
+		assert.Equal(t, 0, b.Len(), "Length after Clear")
+		assert.Equal(t, 5000, c.Len(), "Clone after Clear of the original")
+	})
+
+	// Equal tests that equality ignores the representation of the chunks.
+	t.Run("Equal", func(t *testing.T) {
+		t.Parallel()
+		runs := FromUint32s(rangeValues(0, 9999))
+		var bitmap Bitmap
+		for _, v := range rangeValues(0, 9999) {
+			bitmap.Add(v)
+		}
+		assert.Equal(t, []containerKind{bitmapKind}, bitmap.kinds(), "Containers built by Add")
+		assert.True(t, runs.Equal(&bitmap), "Equal across representations")
+		bitmap.Remove(9999)
+		assert.False(t, runs.Equal(&bitmap), "Equal after Remove")
+		assert.False(t, runs.Equal(New(1)), "Equal to another chunk")
+
+		// Chunks with the same key and cardinality but different values differ, in every representation.
+		assert.False(t, New(1).Equal(New(2)), "Equal of disjoint arrays")
+		assert.False(t, New(1, 2).Equal(New(1, 3)), "Equal of overlapping arrays")
+		shifted := FromUint32s(rangeValues(1, 10000))
+		assert.False(t, runs.Equal(shifted), "Equal of shifted runs")
+		assert.False(t, FromUint32s(rangeValues(1, 9999)).Equal(&bitmap), "Equal of runs and a bitmap")
+	})
+}
+
+func TestAlgebra(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	// Define test cases pairing every kind of container with every other.
+	sparse := randomValues(rng, 500, 1<<18)
+	dense := randomValues(rng, 100000, 1<<18)
+	runs := slices.Concat(rangeValues(1000, 70000), rangeValues(150000, 151000))
+	cases := []struct {
+		name        string
+		left, right []uint32
+	}{
+		{name: "Empty", left: nil, right: sparse},
+		{name: "Array and array", left: sparse, right: randomValues(rng, 500, 1<<18)},
+		{name: "Array and bitmap", left: sparse, right: dense},
+		{name: "Array and runs", left: sparse, right: runs},
+		{name: "Bitmap and bitmap", left: dense, right: randomValues(rng, 100000, 1<<18)},
+		{name: "Bitmap and runs", left: dense, right: runs},
+		{name: "Runs and runs", left: runs, right: slices.Concat(rangeValues(500, 1500), rangeValues(69000, 151500))},
+		{name: "Arrays into a bitmap", left: randomValues(rng, 3000, 1<<16), right: randomValues(rng, 3000, 1<<16)},
+		{name: "Disjoint chunks", left: []uint32{1, 2}, right: []uint32{1 << 20}},
+	}
+
+	// Iterate over the test cases in both orders, checking the results against the sorted slice helpers.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			left, right := sortedDistinct(tt.left), sortedDistinct(tt.right)
+			expectedUnion := append([]uint32{}, slice.SortedUnion(left, right)...)
+			expectedIntersection := append([]uint32{}, slice.SortedIntersect(left, right)...)
+
+			a, b := FromUint32s(tt.left), FromUint32s(tt.right)
+			for _, pair := range [][2]*Bitmap{{a, b}, {b, a}} {
+				union := pair[0].Union(pair[1])
+				assert.Equal(t, expectedUnion, union.Uint32s(), "Union for case %q", tt.name)
+				assert.Equal(t, len(expectedUnion), union.Len(), "Union length for case %q", tt.name)
+
+				intersection := pair[0].Intersection(pair[1])
+				assert.Equal(t, expectedIntersection, intersection.Uint32s(), "Intersection for case %q", tt.name)
+				assert.Equal(t, len(expectedIntersection), intersection.Len(), "Intersection length for case %q", tt.name)
+				for i, c := range intersection.containers {
+					assert.Positive(t, c.card, "Empty chunk %d of the intersection for case %q", intersection.keys[i], tt.name)
+				}
+			}
+
+			// The operations leave their operands unchanged.
+			assert.Equal(t, left, a.Uint32s(), "Left operand for case %q", tt.name)
+			assert.Equal(t, right, b.Uint32s(), "Right operand for case %q", tt.name)
+		})
+	}
+}
+
+// TestMemory backs the sizes given in the package documentation. It does not run in parallel, so that the other
+// tests do not allocate while it measures the live heap.
+func TestMemory(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	cases := []struct {
+		name     string
+		limit    uint32
+		expected float64
+	}{
+		{name: "Sparse", limit: math.MaxUint32, expected: 4.5},
+		{name: "Clustered", limit: 1 << 24, expected: 2.1},
+	}
+
+	for _, tt := range cases {
+		values := randomValues(rng, 1_000_000, tt.limit)
+
+		// Measure the live heap before and after building the set.
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		b := FromUint32s(values)
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		live := float64(after.HeapAlloc-before.HeapAlloc) / (1 << 20)
+		runtime.KeepAlive(b)
+		runtime.KeepAlive(values)
+
+		assert.Less(t, live, tt.expected, "MiB of a million IDs for case %q", tt.name)
+	}
+}
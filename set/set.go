@@ -0,0 +1,347 @@
+// Package set provides a generic, hash-based Set type together with the usual set algebra.
+// A Set holds every element at most once, the same way slice.Unique keeps only the first occurrence of
+// every element, and Remove drops an element the same way slice.Exclude does, without any ordering guarantee.
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"sort"
+)
+
+// Set is an unordered collection of distinct comparable elements.
+// The zero value is an empty set that is ready to use. A Set is not safe for concurrent use.
+type Set[T comparable] struct {
+	// items stores the elements of the set as map keys.
+	items map[T]struct{}
+}
+
+// New creates a set holding the provided elements, with duplicates collapsed.
+func New[T comparable](elements ...T) *Set[T] {
+	return FromSlice(elements)
+}
+
+// FromSlice creates a set holding the distinct elements of the slice.
+// The resulting set contains exactly the elements returned by slice.Unique for the same input.
+func FromSlice[T comparable](elements []T) *Set[T] {
+	// Size the map for the worst case where every element is distinct.
+	s := &Set[T]{items: make(map[T]struct{}, len(elements))}
+
+	// Add every element, letting the map collapse duplicates.
+	for _, v := range elements {
+		s.items[v] = struct{}{}
+	}
+
+	// Return the populated set.
+	return s
+}
+
+// Slice returns the elements of the set in a newly allocated slice, in unspecified order.
+// Sorting the result yields the same elements as sorting the output of slice.Unique over the original input.
+func (s *Set[T]) Slice() []T {
+	// Allocate the result with the exact number of elements.
+	result := make([]T, 0, len(s.items))
+
+	// Copy every element of the set into the result.
+	for v := range s.items {
+		result = append(result, v)
+	}
+
+	// Return the elements of the set.
+	return result
+}
+
+// Len returns the number of elements in the set.
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// Add inserts the elements into the set. Elements already present are ignored.
+func (s *Set[T]) Add(elements ...T) {
+	// Lazily allocate the map so the zero value of Set is usable.
+	if s.items == nil {
+		s.items = make(map[T]struct{}, len(elements))
+	}
+
+	// Insert every element.
+	for _, v := range elements {
+		s.items[v] = struct{}{}
+	}
+}
+
+// Remove deletes the elements from the set. Elements that are not present are ignored.
+func (s *Set[T]) Remove(elements ...T) {
+	for _, v := range elements {
+		delete(s.items, v)
+	}
+}
+
+// Has reports whether the element is present in the set.
+func (s *Set[T]) Has(element T) bool {
+	_, ok := s.items[element]
+	return ok
+}
+
+// Clear removes every element from the set, keeping the allocated storage for reuse.
+func (s *Set[T]) Clear() {
+	clear(s.items)
+}
+
+// Clone returns a new set holding the same elements.
+func (s *Set[T]) Clone() *Set[T] {
+	// Allocate the copy with the same number of elements.
+	result := &Set[T]{items: make(map[T]struct{}, len(s.items))}
+
+	// Copy every element into the new set.
+	for v := range s.items {
+		result.items[v] = struct{}{}
+	}
+
+	// Return the independent copy.
+	return result
+}
+
+// All returns a sequence over the elements of the set, in unspecified order.
+// Adding elements to the set while iterating may or may not make them visible to the iteration,
+// following the semantics of ranging over a map.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// orEmpty returns the set, or a new empty set when it is nil, so the binary operations treat a nil argument as
+// the empty set, like the zero value.
+func orEmpty[T comparable](s *Set[T]) *Set[T] {
+	if s == nil {
+		return &Set[T]{}
+	}
+	return s
+}
+
+// Union returns a new set holding the elements present in either set. A nil argument is the empty set, and so
+// it is for the other binary operations.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	// Start from a copy of the receiver.
+	other = orEmpty(other)
+	result := s.Clone()
+
+	// Add every element of the other set.
+	for v := range other.items {
+		result.items[v] = struct{}{}
+	}
+
+	// Return the union of both sets.
+	return result
+}
+
+// Intersection returns a new set holding the elements present in both sets.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	// Iterate over the smaller set and probe the larger one, which bounds the work by the smaller size.
+	small, large := s, orEmpty(other)
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+
+	result := &Set[T]{items: make(map[T]struct{})}
+
+	// Keep the elements of the smaller set that are also present in the larger set.
+	for v := range small.items {
+		if large.Has(v) {
+			result.items[v] = struct{}{}
+		}
+	}
+
+	// Return the intersection of both sets.
+	return result
+}
+
+// Difference returns a new set holding the elements of the receiver that are not present in the other set.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	other = orEmpty(other)
+	result := &Set[T]{items: make(map[T]struct{})}
+
+	// Keep the elements of the receiver that are missing from the other set.
+	for v := range s.items {
+		if !other.Has(v) {
+			result.items[v] = struct{}{}
+		}
+	}
+
+	// Return the difference of both sets.
+	return result
+}
+
+// SymmetricDifference returns a new set holding the elements present in exactly one of the two sets.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	// Start from the elements that are only present in the receiver.
+	other = orEmpty(other)
+	result := s.Difference(other)
+
+	// Add the elements that are only present in the other set.
+	for v := range other.items {
+		if !s.Has(v) {
+			result.items[v] = struct{}{}
+		}
+	}
+
+	// Return the symmetric difference of both sets.
+	return result
+}
+
+// IsSubset reports whether every element of the receiver is present in the other set.
+// The empty set is a subset of every set.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	other = orEmpty(other)
+
+	// A larger set can never be a subset of a smaller one.
+	if s.Len() > other.Len() {
+		return false
+	}
+
+	// Check that every element of the receiver is present in the other set.
+	for v := range s.items {
+		if !other.Has(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset reports whether every element of the other set is present in the receiver.
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return orEmpty(other).IsSubset(s)
+}
+
+// Equal reports whether both sets hold exactly the same elements.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	other = orEmpty(other)
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// MarshalJSON encodes the set as a JSON array with the elements in a deterministic, sorted order.
+// Elements of the predeclared string and number types are sorted by their natural order, and every other
+// element, including the values of an interface element type, by its fmt.Sprint text. Elements with the same
+// text are ordered by their encoding.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	// Encode every element on its own, so the elements can be sorted by their representation if needed.
+	elements := s.Slice()
+	encoded := make([][]byte, len(elements))
+	for i, v := range elements {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = data
+	}
+
+	// Sort the elements together with their encodings.
+	sort.Sort(byElement[T]{elements: elements, encoded: encoded, compare: compareFunc[T]()})
+
+	// Join the encoded elements into a JSON array.
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, data := range encoded {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(']')
+
+	// Return the encoded array.
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its previous content.
+// Duplicate elements in the array are collapsed.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	// Decode the array into a slice first.
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	// Replace the content of the set with the decoded elements.
+	*s = *FromSlice(elements)
+	return nil
+}
+
+// byElement sorts elements together with their JSON encodings, as used by MarshalJSON.
+type byElement[T comparable] struct {
+	elements []T
+	encoded  [][]byte
+	compare  func(a, b T) int
+}
+
+// Len returns the number of sorted elements.
+func (b byElement[T]) Len() int {
+	return len(b.elements)
+}
+
+// Swap exchanges two elements together with their encodings.
+func (b byElement[T]) Swap(i, j int) {
+	b.elements[i], b.elements[j] = b.elements[j], b.elements[i]
+	b.encoded[i], b.encoded[j] = b.encoded[j], b.encoded[i]
+}
+
+// Less orders two elements by the comparison function, and by their encoding when it finds them equal, which
+// keeps the order total when distinct values of an interface element type print the same.
+func (b byElement[T]) Less(i, j int) bool {
+	if c := b.compare(b.elements[i], b.elements[j]); c != 0 {
+		return c < 0
+	}
+	return bytes.Compare(b.encoded[i], b.encoded[j]) < 0
+}
+
+// compareFunc returns the comparison MarshalJSON sorts the elements with: cmp.Compare when T is a predeclared
+// ordered type, and the comparison of the fmt.Sprint text of the elements otherwise.
+func compareFunc[T comparable]() func(a, b T) int {
+	switch any(*new(T)).(type) {
+	case string:
+		return compareAs[T, string]
+	case int:
+		return compareAs[T, int]
+	case int8:
+		return compareAs[T, int8]
+	case int16:
+		return compareAs[T, int16]
+	case int32:
+		return compareAs[T, int32]
+	case int64:
+		return compareAs[T, int64]
+	case uint:
+		return compareAs[T, uint]
+	case uint8:
+		return compareAs[T, uint8]
+	case uint16:
+		return compareAs[T, uint16]
+	case uint32:
+		return compareAs[T, uint32]
+	case uint64:
+		return compareAs[T, uint64]
+	case uintptr:
+		return compareAs[T, uintptr]
+	case float32:
+		return compareAs[T, float32]
+	case float64:
+		return compareAs[T, float64]
+	default:
+		return func(a, b T) int {
+			return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		}
+	}
+}
+
+// compareAs compares two elements of the ordered type O held in the element type T.
+func compareAs[T comparable, O cmp.Ordered](a, b T) int {
+	return cmp.Compare(any(a).(O), any(b).(O))
+}
@@ -0,0 +1,222 @@
+package set
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	// Basics tests the Add, Remove, Has, Len and Clear operations, starting from the zero value.
+	t.Run("Basics", func(t *testing.T) {
+		// The zero value must be usable without a constructor.
+		var s Set[string]
+		assert.Zero(t, s.Len(), "The zero value should be empty")
+		assert.False(t, s.Has("apple"), "The zero value should not contain anything")
+
+		// Adding duplicates keeps a single copy of every element.
+		s.Add("apple", "banana", "apple")
+		assert.Equal(t, 2, s.Len(), "Duplicates should be collapsed")
+		assert.True(t, s.Has("banana"), "Added elements should be present")
+
+		// Removing present and missing elements only drops the present ones.
+		s.Remove("apple", "cherry")
+		assert.Equal(t, []string{"banana"}, s.Slice(), "Only the removed element should be dropped")
+
+		// Clearing the set drops every element.
+		s.Clear()
+		assert.Zero(t, s.Len(), "Clear should drop every element")
+	})
+
+	// UniqueSemantics tests that converting a slice to a set and back yields the same elements as slice.Unique,
+	// and that removing an element yields the same elements as slice.Exclude.
+	t.Run("UniqueSemantics", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			elements []int
+			unique   []int
+			excluded []int
+		}{
+			{name: "Nil slice", elements: nil, unique: nil, excluded: nil},
+			{name: "No duplicates", elements: []int{3, 1, 2}, unique: []int{3, 1, 2}, excluded: []int{3, 1}},
+			{name: "Duplicates", elements: []int{1, 2, 2, 3, 4, 4, 5, 1}, unique: []int{1, 2, 3, 4, 5}, excluded: []int{1, 3, 4, 5}},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				// The set must hold exactly the distinct elements returned by slice.Unique.
+				s := FromSlice(tt.elements)
+				assert.ElementsMatch(t, tt.unique, s.Slice(), "Set elements should match slice.Unique for case %q", tt.name)
+
+				// Removing an element must match excluding it from the deduplicated slice.
+				s.Remove(2)
+				assert.ElementsMatch(t, tt.excluded, s.Slice(), "Remove should match slice.Exclude for case %q", tt.name)
+			})
+		}
+	})
+
+	// Algebra tests the binary set operations against a pair of overlapping sets.
+	t.Run("Algebra", func(t *testing.T) {
+		a, b := New(1, 2, 3, 4), New(3, 4, 5)
+
+		cases := []struct {
+			name     string
+			result   *Set[int]
+			expected []int
+		}{
+			{name: "Union", result: a.Union(b), expected: []int{1, 2, 3, 4, 5}},
+			{name: "Intersection", result: a.Intersection(b), expected: []int{3, 4}},
+			{name: "Intersection with empty", result: a.Intersection(New[int]()), expected: []int{}},
+			{name: "Difference", result: a.Difference(b), expected: []int{1, 2}},
+			{name: "Reverse difference", result: b.Difference(a), expected: []int{5}},
+			{name: "Symmetric difference", result: a.SymmetricDifference(b), expected: []int{1, 2, 5}},
+			{name: "Union with nil", result: a.Union(nil), expected: []int{1, 2, 3, 4}},
+			{name: "Intersection with nil", result: a.Intersection(nil), expected: []int{}},
+			{name: "Difference with nil", result: a.Difference(nil), expected: []int{1, 2, 3, 4}},
+			{name: "Symmetric difference with nil", result: a.SymmetricDifference(nil), expected: []int{1, 2, 3, 4}},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.ElementsMatch(t, tt.expected, tt.result.Slice(), "Result of %s did not match", tt.name)
+			})
+		}
+
+		// The operands must be left untouched by every operation.
+		assert.ElementsMatch(t, []int{1, 2, 3, 4}, a.Slice(), "The receiver should not be modified")
+		assert.ElementsMatch(t, []int{3, 4, 5}, b.Slice(), "The argument should not be modified")
+	})
+
+	// Relations tests the subset, superset and equality checks.
+	t.Run("Relations", func(t *testing.T) {
+		cases := []struct {
+			name             string
+			a, b             *Set[string]
+			expectedSubset   bool
+			expectedSuperset bool
+			expectedEqual    bool
+		}{
+			{name: "Proper subset", a: New("a"), b: New("a", "b"), expectedSubset: true, expectedSuperset: false, expectedEqual: false},
+			{name: "Proper superset", a: New("a", "b"), b: New("b"), expectedSubset: false, expectedSuperset: true, expectedEqual: false},
+			{name: "Equal sets", a: New("a", "b"), b: New("b", "a"), expectedSubset: true, expectedSuperset: true, expectedEqual: true},
+			{name: "Disjoint sets", a: New("a"), b: New("b"), expectedSubset: false, expectedSuperset: false, expectedEqual: false},
+			{name: "Same size, different elements", a: New("a", "b"), b: New("a", "c"), expectedSubset: false, expectedSuperset: false, expectedEqual: false},
+			{name: "Empty set", a: &Set[string]{}, b: New("a"), expectedSubset: true, expectedSuperset: false, expectedEqual: false},
+			{name: "Nil argument", a: New("a"), b: nil, expectedSubset: false, expectedSuperset: true, expectedEqual: false},
+			{name: "Empty and nil", a: &Set[string]{}, b: nil, expectedSubset: true, expectedSuperset: true, expectedEqual: true},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expectedSubset, tt.a.IsSubset(tt.b), "IsSubset for case %q", tt.name)
+				assert.Equal(t, tt.expectedSuperset, tt.a.IsSuperset(tt.b), "IsSuperset for case %q", tt.name)
+				assert.Equal(t, tt.expectedEqual, tt.a.Equal(tt.b), "Equal for case %q", tt.name)
+			})
+		}
+	})
+
+	// Iteration tests the All sequence, including stopping early.
+	t.Run("Iteration", func(t *testing.T) {
+		s := New(1, 2, 3)
+
+		// Ranging over the whole sequence yields every element once.
+		var all []int
+		for v := range s.All() {
+			all = append(all, v)
+		}
+		assert.ElementsMatch(t, []int{1, 2, 3}, all, "All should yield every element")
+
+		// Breaking out of the loop stops the iteration.
+		count := 0
+		for range s.All() {
+			count++
+			break
+		}
+		assert.Equal(t, 1, count, "All should stop when the consumer stops")
+	})
+
+	// Clone tests that a cloned set is independent of the original.
+	t.Run("Clone", func(t *testing.T) {
+		original := New(1, 2)
+		cloned := original.Clone()
+		cloned.Add(3)
+
+		assert.False(t, original.Has(3), "Changes to the clone should not affect the original")
+		assert.True(t, cloned.Equal(New(1, 2, 3)), "The clone should hold the original elements and the new one")
+	})
+}
+
+func TestSetJSON(t *testing.T) {
+	t.Parallel()
+
+	// Marshal tests that every set is encoded as a sorted JSON array, whatever the insertion order.
+	t.Run("Marshal", func(t *testing.T) {
+		type label string
+		type point struct {
+			X int `json:"x"`
+		}
+
+		cases := []struct {
+			name     string
+			set      any
+			expected string
+		}{
+			{name: "Integers sorted numerically", set: New(10, 2, -1, 33), expected: `[-1,2,10,33]`},
+			{name: "Strings sorted lexically", set: New("pear", "apple", "fig"), expected: `["apple","fig","pear"]`},
+			{name: "Named string type", set: New[label]("b", "a"), expected: `["a","b"]`},
+			{name: "Floats sorted numerically", set: New(2.5, -0.5, 10.25), expected: `[-0.5,2.5,10.25]`},
+			{name: "Structs sorted by encoding", set: New(point{X: 2}, point{X: 1}), expected: `[{"x":1},{"x":2}]`},
+			{name: "Mixed kinds sorted by text", set: New[any](10, "b", 2.5, uint(7), nil, 3, "a", true, 0.5), expected: `[0.5,10,2.5,3,7,null,"a","b",true]`},
+			{name: "Same text", set: New[any](1, "1", int8(1)), expected: `["1",1,1]`},
+			{name: "Empty set", set: New[int](), expected: `[]`},
+			{name: "Zero value", set: &Set[int]{}, expected: `[]`},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				data, err := json.Marshal(tt.set)
+				assert.NoError(t, err, "Marshal should not fail for case %q", tt.name)
+				assert.Equal(t, tt.expected, string(data), "Encoded set should match for case %q", tt.name)
+			})
+		}
+	})
+
+	// Deterministic tests that a set of mixed kinds, whose natural and encoded orders disagree, always encodes to
+	// the same bytes whatever the iteration order of the underlying map.
+	t.Run("Deterministic", func(t *testing.T) {
+		elements := []any{3, 10, 2.5, "10", "2.5", "3", uint(10), int8(3), 1.5, false}
+		expected, err := json.Marshal(New(elements...))
+		assert.NoError(t, err, "Marshal should not fail")
+		for range 200 {
+			data, _ := json.Marshal(New(elements...))
+			assert.Equal(t, string(expected), string(data), "Encoded mixed set should not depend on the iteration order")
+		}
+	})
+
+	// RoundTrip tests that decoding an encoded set yields an equal set and that duplicates are collapsed.
+	t.Run("RoundTrip", func(t *testing.T) {
+		var decoded Set[string]
+		err := json.Unmarshal([]byte(`["b","a","b"]`), &decoded)
+		assert.NoError(t, err, "Unmarshal should not fail")
+
+		elements := decoded.Slice()
+		sort.Strings(elements)
+		assert.Equal(t, []string{"a", "b"}, elements, "Duplicates should be collapsed while decoding")
+
+		// Decoding into a populated set replaces its content.
+		s := New("z")
+		assert.NoError(t, json.Unmarshal([]byte(`["a"]`), s), "Unmarshal should not fail")
+		assert.True(t, s.Equal(New("a")), "Unmarshal should replace the previous content")
+
+		// A document that is not an array is rejected.
+		assert.Error(t, json.Unmarshal([]byte(`{"a":1}`), s), "Unmarshal should reject a JSON object")
+	})
+}
@@ -0,0 +1,163 @@
+package set
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// SyncSet is a set that is safe for concurrent use by multiple goroutines.
+// Writers take an exclusive lock and modify the elements in place. Readers work on an immutable snapshot that is
+// copied once after every write and then shared, copy-on-write style, so read-heavy workloads such as membership
+// checks run without any lock while the snapshot is current. Callbacks of the read operations run on the snapshot
+// without holding the lock, so they may freely call back into the SyncSet. The zero value is an empty set that is
+// ready to use. A SyncSet must not be copied after first use.
+type SyncSet[T comparable] struct {
+	// mu guards items.
+	mu sync.RWMutex
+	// items holds the current elements, modified in place by the writers.
+	items Set[T]
+	// snapshot caches an immutable copy of items, or nil when a write happened since it was taken.
+	snapshot atomic.Pointer[Set[T]]
+}
+
+// NewSyncSet creates a SyncSet holding the provided elements, with duplicates collapsed.
+func NewSyncSet[T comparable](elements ...T) *SyncSet[T] {
+	return &SyncSet[T]{items: *FromSlice(elements)}
+}
+
+// Snapshot returns the elements at the time of the call as a set that is shared with other readers and must not
+// be modified; use Clone to get a private copy. Later writes never show up in a returned snapshot.
+func (s *SyncSet[T]) Snapshot() *Set[T] {
+	// Serve the cached snapshot without locking when no write happened since it was taken.
+	if p := s.snapshot.Load(); p != nil {
+		return p
+	}
+
+	// Copy the elements under the read lock, which keeps the writers out until the copy is published.
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot := s.items.Clone()
+	s.snapshot.Store(snapshot)
+	return snapshot
+}
+
+// Clone returns a private copy of the elements as a Set, which the caller may modify.
+func (s *SyncSet[T]) Clone() *Set[T] {
+	return s.Snapshot().Clone()
+}
+
+// Slice returns the elements in a newly allocated slice, in unspecified order.
+func (s *SyncSet[T]) Slice() []T {
+	return s.Snapshot().Slice()
+}
+
+// Has reports whether the element is present in the set.
+func (s *SyncSet[T]) Has(element T) bool {
+	if p := s.snapshot.Load(); p != nil {
+		return p.Has(element)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items.Has(element)
+}
+
+// Len returns the number of elements in the set.
+func (s *SyncSet[T]) Len() int {
+	if p := s.snapshot.Load(); p != nil {
+		return p.Len()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items.Len()
+}
+
+// All returns a sequence over the elements of a snapshot taken when the iteration starts, in unspecified order.
+// The lock is not held while the loop body runs, so the body may modify the SyncSet without deadlocking;
+// such writes are not visible to the ongoing iteration.
+func (s *SyncSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.Snapshot().All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Add inserts the elements into the set. Elements already present are ignored.
+func (s *SyncSet[T]) Add(elements ...T) {
+	s.Update(func(items *Set[T]) { items.Add(elements...) })
+}
+
+// AddIfAbsent inserts the element and reports whether it was absent, atomically, so exactly one of several
+// goroutines adding the same element sees true.
+func (s *SyncSet[T]) AddIfAbsent(element T) bool {
+	added := false
+	s.Update(func(items *Set[T]) {
+		if !items.Has(element) {
+			items.Add(element)
+			added = true
+		}
+	})
+	return added
+}
+
+// Remove deletes the elements from the set. Elements that are not present are ignored.
+func (s *SyncSet[T]) Remove(elements ...T) {
+	s.Update(func(items *Set[T]) { items.Remove(elements...) })
+}
+
+// Clear removes every element from the set.
+func (s *SyncSet[T]) Clear() {
+	s.Update(func(items *Set[T]) { items.Clear() })
+}
+
+// Update modifies the elements with fn, atomically: no other write can happen while fn runs. fn must not keep a
+// reference to the set after returning. It runs under the exclusive lock, so it must not call any method of the
+// SyncSet.
+func (s *SyncSet[T]) Update(fn func(items *Set[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.items)
+
+	// Invalidate the snapshot, so the next read copies the new elements.
+	s.snapshot.Store(nil)
+}
+
+// Filter returns a new set holding the elements of a snapshot that satisfy the predicate.
+// The predicate runs without holding the lock.
+func (s *SyncSet[T]) Filter(fn func(T) bool) *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{})}
+	for v := range s.Snapshot().items {
+		if fn(v) {
+			result.items[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// FilterInPlace atomically removes the elements that do not satisfy the predicate.
+// The predicate runs under the exclusive lock, so it must not call any method of the SyncSet.
+func (s *SyncSet[T]) FilterInPlace(fn func(T) bool) {
+	s.Update(func(items *Set[T]) {
+		// Deleting from a map while ranging over it is safe.
+		for v := range items.items {
+			if !fn(v) {
+				delete(items.items, v)
+			}
+		}
+	})
+}
+
+// SyncMap returns a new set holding the result of the transformation function applied to every element of a
+// snapshot of the SyncSet. Elements transformed to the same value are collapsed.
+// The transformation runs without holding the lock.
+func SyncMap[T, R comparable](s *SyncSet[T], fn func(T) R) *Set[R] {
+	snapshot := s.Snapshot()
+	result := &Set[R]{items: make(map[R]struct{}, snapshot.Len())}
+	for v := range snapshot.items {
+		result.items[fn(v)] = struct{}{}
+	}
+	return result
+}
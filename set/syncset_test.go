@@ -0,0 +1,120 @@
+package set
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncSet(t *testing.T) {
+	t.Parallel()
+
+	// Basics tests the single-goroutine behaviour, starting from the zero value.
+	t.Run("Basics", func(t *testing.T) {
+		var s SyncSet[int]
+		assert.Zero(t, s.Len(), "The zero value should be empty")
+		assert.False(t, s.Has(1), "The zero value should not contain anything")
+
+		s.Add(1, 2, 2, 3, 4)
+		assert.Equal(t, 4, s.Len(), "Duplicates should be collapsed")
+		assert.True(t, s.Has(3), "Added elements should be present")
+		assert.True(t, s.AddIfAbsent(5), "AddIfAbsent of a new element")
+		assert.False(t, s.AddIfAbsent(5), "AddIfAbsent of a present element")
+
+		assert.ElementsMatch(t, []int{2, 4}, s.Filter(func(v int) bool { return v%2 == 0 }).Slice(), "Filter")
+		assert.ElementsMatch(t, []int{0, 1, 2}, SyncMap(&s, func(v int) int { return v / 2 }).Slice(), "SyncMap collapses equal results")
+		assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, s.Slice(), "The read operations should not modify the elements")
+
+		s.FilterInPlace(func(v int) bool { return v > 2 })
+		assert.ElementsMatch(t, []int{3, 4, 5}, s.Slice(), "FilterInPlace")
+		s.Remove(4, 9)
+		assert.ElementsMatch(t, []int{3, 5}, s.Slice(), "Remove")
+
+		s.Clear()
+		assert.Zero(t, s.Len(), "Clear")
+	})
+
+	// Snapshot tests that a snapshot is immutable and shared until the next write.
+	t.Run("Snapshot", func(t *testing.T) {
+		s := NewSyncSet("a", "b")
+		first := s.Snapshot()
+		assert.Same(t, first, s.Snapshot(), "Snapshots should be shared between writes")
+
+		s.Add("c")
+		assert.ElementsMatch(t, []string{"a", "b"}, first.Slice(), "A snapshot should not see later writes")
+		assert.True(t, s.Has("c"), "Reads should see the write")
+
+		private := s.Clone()
+		private.Add("d")
+		assert.False(t, s.Has("d"), "Modifying the private copy should not leak")
+	})
+
+	// ReentrantIteration tests that the iteration and the read callbacks do not hold the lock, so they can write
+	// to the SyncSet without deadlocking.
+	t.Run("ReentrantIteration", func(t *testing.T) {
+		s := NewSyncSet(1, 2, 3)
+		count := 0
+		for v := range s.All() {
+			count++
+			s.Add(v * 10)
+		}
+		assert.Equal(t, 3, count, "The iteration should ignore the added elements")
+		assert.Equal(t, 6, s.Len(), "Writes from the loop body")
+
+		SyncMap(s, func(v int) int { s.Remove(v); return v })
+		assert.Zero(t, s.Len(), "Writes from the SyncMap transformation")
+	})
+}
+
+// TestSyncSetStress hammers a SyncSet from concurrent writers and readers. It checks invariants that only hold if
+// every operation is atomic, and is meant to run with the race detector: go test -race -run Stress.
+func TestSyncSetStress(t *testing.T) {
+	t.Parallel()
+
+	const workers, rounds = 8, 300
+	s := NewSyncSet[int]()
+	var wins atomic.Int64
+	var wg sync.WaitGroup
+
+	// Every worker tries to claim every element; AddIfAbsent must let exactly one of them win each time.
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				if s.AddIfAbsent(i) {
+					wins.Add(1)
+				}
+
+				// Writers add pairs {-v, v} atomically, so readers must never see half of a pair.
+				v := rounds + i
+				s.Update(func(items *Set[int]) { items.Add(v, -v) })
+				if i%10 == 0 {
+					s.FilterInPlace(func(v int) bool { return v < 2*rounds-50 && v > 50-2*rounds })
+				}
+
+				// Check the pairs on a snapshot, through an iteration and through Has.
+				snapshot := s.Snapshot()
+				for v := range snapshot.All() {
+					if v >= rounds && !snapshot.Has(-v) {
+						t.Errorf("snapshot holds %d without %d", v, -v)
+						return
+					}
+				}
+				for v := range s.All() {
+					_ = s.Has(v)
+				}
+				_ = SyncMap(s, func(v int) int { return v % 7 })
+				_ = s.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(rounds), wins.Load(), "Every element should be claimed exactly once")
+	for i := range rounds {
+		assert.True(t, s.Has(i), "Claimed element %d", i)
+	}
+}
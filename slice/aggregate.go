@@ -0,0 +1,143 @@
+package slice
+
+import "golang.org/x/exp/constraints"
+
+// Number is the set of numeric types accepted by the arithmetic aggregations Sum, Product and Average.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Reduce combines the elements of a slice from left to right using the provided function,
+// starting from the first element. It returns false when the slice is empty, since there is no
+// element to start from; use FoldLeft when an explicit initial value is available.
+func Reduce[T any](elements []T, fn func(T, T) T) (T, bool) {
+	// An empty slice has nothing to reduce, so report it instead of inventing a value.
+	if len(elements) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Start from the first element and fold the remaining ones into it.
+	return FoldLeft(elements[1:], elements[0], fn), true
+}
+
+// FoldLeft combines the elements of a slice from left to right, starting from the initial value.
+// For elements [a, b, c] it computes fn(fn(fn(initial, a), b), c). An empty slice returns the initial value.
+func FoldLeft[T, R any](elements []T, initial R, fn func(R, T) R) R {
+	// Start the accumulation from the provided initial value.
+	acc := initial
+
+	// Fold every element into the accumulator, in order.
+	for _, v := range elements {
+		acc = fn(acc, v)
+	}
+
+	// Return the final accumulator.
+	return acc
+}
+
+// FoldRight combines the elements of a slice from right to left, starting from the initial value.
+// For elements [a, b, c] it computes fn(a, fn(b, fn(c, initial))). An empty slice returns the initial value.
+func FoldRight[T, R any](elements []T, initial R, fn func(T, R) R) R {
+	// Start the accumulation from the provided initial value.
+	acc := initial
+
+	// Fold every element into the accumulator, from the last one to the first one.
+	for i := len(elements) - 1; i >= 0; i-- {
+		acc = fn(elements[i], acc)
+	}
+
+	// Return the final accumulator.
+	return acc
+}
+
+// Scan returns the running accumulations of FoldLeft: the element at position i of the result is the
+// accumulator after folding the elements up to and including position i. The result has the same length
+// as the input, and does not include the initial value.
+func Scan[T, R any](elements []T, initial R, fn func(R, T) R) []R {
+	// Create the result with the same length as the input, exactly like Map.
+	result := make([]R, len(elements))
+	acc := initial
+
+	// Fold every element and record the accumulator after each step.
+	for i, v := range elements {
+		acc = fn(acc, v)
+		result[i] = acc
+	}
+
+	// Return the running accumulations.
+	return result
+}
+
+// Sum returns the sum of the elements of a slice. The sum of an empty slice is zero.
+func Sum[T Number](elements []T) T {
+	return FoldLeft(elements, T(0), func(acc, v T) T { return acc + v })
+}
+
+// Product returns the product of the elements of a slice. The product of an empty slice is one.
+func Product[T Number](elements []T) T {
+	return FoldLeft(elements, T(1), func(acc, v T) T { return acc * v })
+}
+
+// Average returns the arithmetic mean of the elements of a slice as a float64.
+// It returns false when the slice is empty, since the mean of no element is undefined.
+func Average[T Number](elements []T) (float64, bool) {
+	// The mean of an empty slice is undefined.
+	if len(elements) == 0 {
+		return 0, false
+	}
+
+	// Accumulate in float64 to avoid overflowing small integer types.
+	total := FoldLeft(elements, 0.0, func(acc float64, v T) float64 { return acc + float64(v) })
+
+	// Return the mean of the elements.
+	return total / float64(len(elements)), true
+}
+
+// Min returns the smallest element of a slice. When several elements are equally small, the first one is returned.
+// It returns false when the slice is empty.
+func Min[T constraints.Ordered](elements []T) (T, bool) {
+	return MinBy(elements, func(v T) T { return v })
+}
+
+// Max returns the largest element of a slice. When several elements are equally large, the first one is returned.
+// It returns false when the slice is empty.
+func Max[T constraints.Ordered](elements []T) (T, bool) {
+	return MaxBy(elements, func(v T) T { return v })
+}
+
+// MinBy returns the element of a slice with the smallest key, as computed by the key function.
+// When several elements share the smallest key, the first one is returned. It returns false when the slice is empty.
+func MinBy[T any, K constraints.Ordered](elements []T, key func(T) K) (T, bool) {
+	return extremeBy(elements, key, func(candidate, best K) bool { return candidate < best })
+}
+
+// MaxBy returns the element of a slice with the largest key, as computed by the key function.
+// When several elements share the largest key, the first one is returned. It returns false when the slice is empty.
+func MaxBy[T any, K constraints.Ordered](elements []T, key func(T) K) (T, bool) {
+	return extremeBy(elements, key, func(candidate, best K) bool { return candidate > best })
+}
+
+// extremeBy returns the first element whose key is better than the keys of every other element,
+// where better reports whether a candidate key should replace the best key found so far.
+// Each key is computed exactly once.
+func extremeBy[T any, K constraints.Ordered](elements []T, key func(T) K, better func(candidate, best K) bool) (T, bool) {
+	// An empty slice has no extreme element.
+	if len(elements) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Start from the first element and its key.
+	best, bestKey := elements[0], key(elements[0])
+
+	// Replace the current best element only with a strictly better one, so the first extreme wins ties.
+	for _, v := range elements[1:] {
+		if k := key(v); better(k, bestKey) {
+			best, bestKey = v, k
+		}
+	}
+
+	// Return the extreme element.
+	return best, true
+}
@@ -0,0 +1,211 @@
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReduce(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for reducing integer slices with addition.
+	cases := []struct {
+		name       string
+		elements   []int
+		expected   int
+		expectedOk bool
+	}{
+		{name: "Nil slice", elements: nil, expected: 0, expectedOk: false},
+		{name: "Empty slice", elements: []int{}, expected: 0, expectedOk: false},
+		{name: "Single element", elements: []int{7}, expected: 7, expectedOk: true},
+		{name: "Multiple elements", elements: []int{1, 2, 3, 4}, expected: 10, expectedOk: true},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := Reduce(tt.elements, func(a, b int) int { return a + b })
+			assert.Equal(t, tt.expectedOk, ok, "Reduce presence for case %q", tt.name)
+			assert.Equal(t, tt.expected, result, "Reduce result for case %q", tt.name)
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases that render the fold order, which makes the association direction visible.
+	cases := []struct {
+		name          string
+		elements      []string
+		expectedLeft  string
+		expectedRight string
+	}{
+		{name: "Empty slice returns the initial value", elements: nil, expectedLeft: "0", expectedRight: "0"},
+		{name: "Single element", elements: []string{"a"}, expectedLeft: "(0+a)", expectedRight: "(a+0)"},
+		{name: "Multiple elements", elements: []string{"a", "b", "c"}, expectedLeft: "(((0+a)+b)+c)", expectedRight: "(a+(b+(c+0)))"},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			left := FoldLeft(tt.elements, "0", func(acc, v string) string { return fmt.Sprintf("(%s+%s)", acc, v) })
+			right := FoldRight(tt.elements, "0", func(v, acc string) string { return fmt.Sprintf("(%s+%s)", v, acc) })
+
+			assert.Equal(t, tt.expectedLeft, left, "FoldLeft result for case %q", tt.name)
+			assert.Equal(t, tt.expectedRight, right, "FoldRight result for case %q", tt.name)
+		})
+	}
+
+	// BuildIndex tests folding into a different type, such as building an index from a slice.
+	t.Run("BuildIndex", func(t *testing.T) {
+		index := FoldLeft([]string{"apple", "avocado", "banana"}, map[byte][]string{}, func(acc map[byte][]string, v string) map[byte][]string {
+			acc[v[0]] = append(acc[v[0]], v)
+			return acc
+		})
+		assert.Equal(t, map[byte][]string{'a': {"apple", "avocado"}, 'b': {"banana"}}, index, "FoldLeft should build the index")
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for running sums.
+	cases := []struct {
+		name     string
+		elements []int
+		expected []int
+	}{
+		{name: "Empty slice", elements: []int{}, expected: []int{}},
+		{name: "Single element", elements: []int{5}, expected: []int{15}},
+		{name: "Multiple elements", elements: []int{1, 2, 3, 4}, expected: []int{11, 13, 16, 20}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Scan(tt.elements, 10, func(acc, v int) int { return acc + v })
+			assert.Equal(t, tt.expected, result, "Scan result for case %q", tt.name)
+		})
+	}
+
+	// LastMatchesFold tests that the last running accumulation equals the result of FoldLeft.
+	t.Run("LastMatchesFold", func(t *testing.T) {
+		input := createSequenceWithRepeats(300, 9)
+		sum := func(acc, v int) int { return acc + v }
+
+		running := Scan(input, 0, sum)
+		assert.Equal(t, FoldLeft(input, 0, sum), running[len(running)-1], "The last running sum should equal the fold")
+	})
+}
+
+func TestArithmetic(t *testing.T) {
+	t.Parallel()
+
+	// Integers tests the arithmetic aggregations on integer slices.
+	t.Run("Integers", func(t *testing.T) {
+		cases := []struct {
+			name            string
+			elements        []int
+			expectedSum     int
+			expectedProduct int
+			expectedAverage float64
+			expectedOk      bool
+		}{
+			{name: "Nil slice", elements: nil, expectedSum: 0, expectedProduct: 1, expectedAverage: 0, expectedOk: false},
+			{name: "Single element", elements: []int{4}, expectedSum: 4, expectedProduct: 4, expectedAverage: 4, expectedOk: true},
+			{name: "Multiple elements", elements: []int{1, 2, 3, 4}, expectedSum: 10, expectedProduct: 24, expectedAverage: 2.5, expectedOk: true},
+			{name: "Negative elements", elements: []int{-2, 3, -1}, expectedSum: 0, expectedProduct: 6, expectedAverage: 0, expectedOk: true},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expectedSum, Sum(tt.elements), "Sum result for case %q", tt.name)
+				assert.Equal(t, tt.expectedProduct, Product(tt.elements), "Product result for case %q", tt.name)
+
+				average, ok := Average(tt.elements)
+				assert.Equal(t, tt.expectedOk, ok, "Average presence for case %q", tt.name)
+				assert.InDelta(t, tt.expectedAverage, average, 1e-9, "Average result for case %q", tt.name)
+			})
+		}
+	})
+
+	// Floats tests the arithmetic aggregations on float slices.
+	t.Run("Floats", func(t *testing.T) {
+		elements := []float64{1.5, 2.5, 4}
+
+		assert.InDelta(t, 8.0, Sum(elements), 1e-9, "Sum of floats")
+		assert.InDelta(t, 15.0, Product(elements), 1e-9, "Product of floats")
+
+		average, ok := Average(elements)
+		assert.True(t, ok, "Average of floats should be defined")
+		assert.InDelta(t, 8.0/3, average, 1e-9, "Average of floats")
+	})
+
+	// AverageDoesNotOverflow tests that Average accumulates small integer types without overflowing.
+	t.Run("AverageDoesNotOverflow", func(t *testing.T) {
+		average, ok := Average([]int8{100, 100, 100})
+		assert.True(t, ok, "Average should be defined")
+		assert.InDelta(t, 100.0, average, 1e-9, "Average should not overflow int8")
+	})
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	// Ordered tests Min and Max on ordered element types.
+	t.Run("Ordered", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			elements    []int
+			expectedMin int
+			expectedMax int
+			expectedOk  bool
+		}{
+			{name: "Nil slice", elements: nil, expectedOk: false},
+			{name: "Single element", elements: []int{3}, expectedMin: 3, expectedMax: 3, expectedOk: true},
+			{name: "Multiple elements", elements: []int{3, -1, 8, 0}, expectedMin: -1, expectedMax: 8, expectedOk: true},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				minimum, ok := Min(tt.elements)
+				assert.Equal(t, tt.expectedOk, ok, "Min presence for case %q", tt.name)
+				assert.Equal(t, tt.expectedMin, minimum, "Min result for case %q", tt.name)
+
+				maximum, ok := Max(tt.elements)
+				assert.Equal(t, tt.expectedOk, ok, "Max presence for case %q", tt.name)
+				assert.Equal(t, tt.expectedMax, maximum, "Max result for case %q", tt.name)
+			})
+		}
+
+		// Strings are ordered lexically.
+		minimum, _ := Min([]string{"pear", "apple", "fig"})
+		assert.Equal(t, "apple", minimum, "Min of strings should be lexical")
+	})
+
+	// ByKey tests MinBy and MaxBy, including that ties keep the first element.
+	t.Run("ByKey", func(t *testing.T) {
+		type player struct {
+			name  string
+			score int
+		}
+		players := []player{{"ann", 10}, {"bob", 3}, {"cid", 10}, {"dee", 3}}
+		score := func(p player) int { return p.score }
+
+		lowest, ok := MinBy(players, score)
+		assert.True(t, ok, "MinBy should find an element")
+		assert.Equal(t, "bob", lowest.name, "MinBy should return the first lowest element")
+
+		highest, ok := MaxBy(players, score)
+		assert.True(t, ok, "MaxBy should find an element")
+		assert.Equal(t, "ann", highest.name, "MaxBy should return the first highest element")
+
+		_, ok = MaxBy([]player(nil), score)
+		assert.False(t, ok, "MaxBy should report an empty slice")
+	})
+}
@@ -0,0 +1,141 @@
+package slice
+
+import "sync"
+
+// DefaultLinearScanThreshold is the input size up to which Unique and UniqueInto deduplicate by scanning the
+// elements kept so far instead of hashing them into a map. Below this size the quadratic scan is faster than
+// allocating and filling a map, as shown by the UniqueIntoMap and UniqueIntoLinear cases of BenchmarkAlloc.
+const DefaultLinearScanThreshold = 16
+
+// maxPooledSeenSize is the number of entries above which a seen-map is dropped instead of being returned to its
+// pool, so that one unusually large input does not keep a huge map alive for every later, smaller one.
+const maxPooledSeenSize = 1 << 16
+
+// SeenPool recycles the maps that UniqueInto uses to track the elements it has already kept.
+// It is safe for concurrent use and is meant to be shared, typically as a package-level variable,
+// by every call site that deduplicates the same element type.
+type SeenPool[T comparable] struct {
+	// pool holds the recycled map[T]struct{} values.
+	pool sync.Pool
+}
+
+// NewSeenPool creates an empty pool of seen-maps for the element type T.
+func NewSeenPool[T comparable]() *SeenPool[T] {
+	return &SeenPool[T]{pool: sync.Pool{New: func() any { return make(map[T]struct{}) }}}
+}
+
+// get returns an empty map from the pool, allocating one if the pool is empty.
+func (p *SeenPool[T]) get() map[T]struct{} {
+	return p.pool.Get().(map[T]struct{})
+}
+
+// put clears the map and returns it to the pool, unless it grew too large to be worth keeping.
+func (p *SeenPool[T]) put(seen map[T]struct{}) {
+	if len(seen) > maxPooledSeenSize {
+		return
+	}
+	clear(seen)
+	p.pool.Put(seen)
+}
+
+// UniqueOption configures the strategy used by UniqueInto. Options take and return the configuration by value,
+// so applying them does not move it to the heap.
+type UniqueOption[T comparable] func(uniqueOptions[T]) uniqueOptions[T]
+
+// uniqueOptions holds the strategy selected through UniqueOption values.
+type uniqueOptions[T comparable] struct {
+	// threshold is the input size up to which a linear scan is used instead of a map.
+	threshold int
+	// pool, when set, provides the seen-map instead of allocating a new one.
+	pool *SeenPool[T]
+}
+
+// WithLinearScanThreshold sets the input size up to which UniqueInto deduplicates by a linear scan instead of
+// a map. A value of zero or less disables the linear scan. The default is DefaultLinearScanThreshold.
+func WithLinearScanThreshold[T comparable](n int) UniqueOption[T] {
+	return func(o uniqueOptions[T]) uniqueOptions[T] {
+		o.threshold = n
+		return o
+	}
+}
+
+// WithSeenPool makes UniqueInto borrow its seen-map from the provided pool and return it afterwards,
+// instead of allocating a new map on every call.
+func WithSeenPool[T comparable](pool *SeenPool[T]) UniqueOption[T] {
+	return func(o uniqueOptions[T]) uniqueOptions[T] {
+		o.pool = pool
+		return o
+	}
+}
+
+// FilterInto appends the elements that satisfy the predicate to dst and returns the extended slice,
+// following the semantics of the built-in append. Passing a reused buffer such as buf[:0], or a slice
+// presized with make([]T, 0, len(elements)), avoids the repeated growth of the result that Filter incurs.
+// The input slice is never modified, unless dst shares its backing array.
+func FilterInto[T any](dst, elements []T, fn func(T) bool) []T {
+	// Append every matching element to the destination.
+	for _, v := range elements {
+		if fn(v) {
+			dst = append(dst, v)
+		}
+	}
+
+	// Return the extended destination.
+	return dst
+}
+
+// UniqueInto appends the distinct elements of the input to dst, keeping the first occurrence of every element
+// in its original order, and returns the extended slice, following the semantics of the built-in append.
+// Only the elements of the input are deduplicated; the elements already present in dst are neither inspected
+// nor removed. The options select the deduplication strategy: a linear scan for small inputs, and a pooled
+// seen-map for the others.
+func UniqueInto[T comparable](dst, elements []T, opts ...UniqueOption[T]) []T {
+	// Start from the default strategy and apply the options.
+	options := uniqueOptions[T]{threshold: DefaultLinearScanThreshold}
+	for _, opt := range opts {
+		options = opt(options)
+	}
+
+	// Small inputs are cheaper to deduplicate by scanning the already kept elements.
+	if len(elements) <= options.threshold {
+		return uniqueLinear(dst, elements)
+	}
+
+	// Borrow the seen-map from the pool when one is configured, otherwise allocate it.
+	var seen map[T]struct{}
+	if options.pool != nil {
+		seen = options.pool.get()
+		defer options.pool.put(seen)
+	} else {
+		seen = make(map[T]struct{}, len(elements))
+	}
+
+	// Append the first occurrence of every element to the destination.
+	for _, v := range elements {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		dst = append(dst, v)
+	}
+
+	// Return the extended destination.
+	return dst
+}
+
+// uniqueLinear appends the distinct elements of the input to dst by comparing every element with the ones
+// already appended during this call. It allocates nothing beyond the growth of dst.
+func uniqueLinear[T comparable](dst, elements []T) []T {
+	// Remember where the elements appended by this call start, so the existing content of dst is ignored.
+	start := len(dst)
+
+	// Append every element that has not been appended yet.
+	for _, v := range elements {
+		if !Contains(dst[start:], v) {
+			dst = append(dst, v)
+		}
+	}
+
+	// Return the extended destination.
+	return dst
+}
@@ -0,0 +1,109 @@
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterInto(t *testing.T) {
+	// Not parallel: testing.AllocsPerRun refuses to run during parallel tests.
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	// Define test cases with different destinations. The result must always be the destination followed by
+	// the matching elements, like append.
+	cases := []struct {
+		name     string
+		dst      []int
+		elements []int
+		expected []int
+	}{
+		{name: "Nil destination", dst: nil, elements: []int{1, 2, 3, 4}, expected: []int{2, 4}},
+		{name: "Nil destination without matches", dst: nil, elements: []int{1, 3}, expected: nil},
+		{name: "Presized destination", dst: make([]int, 0, 4), elements: []int{1, 2, 3, 4}, expected: []int{2, 4}},
+		{name: "Non-empty destination", dst: []int{100}, elements: []int{1, 2, 3, 4}, expected: []int{100, 2, 4}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FilterInto(tt.dst, tt.elements, isEven), "FilterInto result for case %q", tt.name)
+		})
+	}
+
+	// ReusesBuffer tests that a buffer with enough capacity is reused without allocating.
+	t.Run("ReusesBuffer", func(t *testing.T) {
+		input := createSequenceWithoutRepeats(1000)
+		buf := make([]int, 0, len(input))
+
+		allocs := testing.AllocsPerRun(10, func() {
+			buf = FilterInto(buf[:0], input, isEven)
+		})
+
+		assert.Zero(t, allocs, "FilterInto should not allocate when the buffer is large enough")
+		assert.Equal(t, Filter(input, isEven), buf, "FilterInto should match Filter")
+	})
+}
+
+func TestUniqueInto(t *testing.T) {
+	// Not parallel: testing.AllocsPerRun refuses to run during parallel tests.
+	// Strategies tests that every strategy produces the same result as Unique, for inputs below and above the
+	// linear scan threshold.
+	t.Run("Strategies", func(t *testing.T) {
+		pool := NewSeenPool[int]()
+		strategies := []struct {
+			name string
+			opts []UniqueOption[int]
+		}{
+			{name: "Default", opts: nil},
+			{name: "MapOnly", opts: []UniqueOption[int]{WithLinearScanThreshold[int](0)}},
+			{name: "LinearOnly", opts: []UniqueOption[int]{WithLinearScanThreshold[int](1 << 20)}},
+			{name: "Pooled", opts: []UniqueOption[int]{WithLinearScanThreshold[int](0), WithSeenPool(pool)}},
+		}
+		inputs := map[string][]int{
+			"Nil":   nil,
+			"Small": {3, 1, 3, 2, 1},
+			"Large": createSequenceWithRepeats(500, 7),
+		}
+
+		// Run every strategy on every input.
+		for _, s := range strategies {
+			for inputName, input := range inputs {
+				t.Run(fmt.Sprintf("%s/%s", s.name, inputName), func(t *testing.T) {
+					assert.Equal(t, Unique(input), UniqueInto(nil, input, s.opts...), "UniqueInto should match Unique")
+				})
+			}
+		}
+	})
+
+	// DestinationNotDeduplicated tests that the existing content of the destination is kept as is and does
+	// not influence the deduplication of the input.
+	t.Run("DestinationNotDeduplicated", func(t *testing.T) {
+		for _, threshold := range []int{0, 100} {
+			result := UniqueInto([]int{1, 1}, []int{1, 2, 2}, WithLinearScanThreshold[int](threshold))
+			assert.Equal(t, []int{1, 1, 1, 2}, result, "Only the input should be deduplicated with threshold %d", threshold)
+		}
+	})
+
+	// PooledMapIsCleared tests that a map borrowed from the pool does not leak elements from a previous call.
+	t.Run("PooledMapIsCleared", func(t *testing.T) {
+		pool := NewSeenPool[string]()
+		opts := []UniqueOption[string]{WithLinearScanThreshold[string](0), WithSeenPool(pool)}
+
+		assert.Equal(t, []string{"a", "b"}, UniqueInto(nil, []string{"a", "b", "a"}, opts...), "First call should deduplicate")
+		assert.Equal(t, []string{"b", "a"}, UniqueInto(nil, []string{"b", "a"}, opts...), "Second call should not see the first call's elements")
+	})
+
+	// SmallInputDoesNotAllocateMap tests that Unique avoids the map for small inputs, leaving only the growth
+	// of the result slice.
+	t.Run("SmallInputDoesNotAllocateMap", func(t *testing.T) {
+		input := []int{1, 2, 1, 3}
+		buf := make([]int, 0, len(input))
+
+		allocs := testing.AllocsPerRun(10, func() {
+			buf = UniqueInto(buf[:0], input)
+		})
+		assert.Zero(t, allocs, "UniqueInto should not allocate for small inputs with a large enough buffer")
+	})
+}
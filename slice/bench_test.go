@@ -0,0 +1,930 @@
+package slice
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// The benchmarks in this file cover every exported function of the package. Each top-level benchmark groups the
+// functions of one source file, and every sub-benchmark is named function/type/size=N/dup=P, where P is the
+// percentage of duplicate elements in the input. The names are stable, so the output of two runs can be compared
+// with cmd/benchcmp:
+//
+//	go test -run '^$' -bench . -benchtime 100ms -count 6 . > new.txt
+//	go run ./cmd/benchcmp -threshold 10 old.txt new.txt
+//
+// Functions whose cost does not depend on duplicates only run with dup=0, and functions whose cost or output
+// is quadratic only run on the smaller sizes, so a full run stays within a few minutes.
+
+// benchRecord is the struct element type of the benchmarks. It is comparable and holds a string, so it
+// exercises both larger copies and pointer-carrying values compared with plain ints.
+type benchRecord struct {
+	ID    int
+	Name  string
+	Score float64
+}
+
+// benchShape selects the input sizes and duplicate percentages a function is benchmarked with.
+type benchShape struct {
+	sizes []int
+	dups  []int
+}
+
+var (
+	// fullShape is used for functions whose cost depends on the number of distinct elements.
+	fullShape = benchShape{sizes: []int{16, 1024, 65536}, dups: []int{0, 50, 90}}
+	// sizeShape is used for functions whose cost only depends on the input size.
+	sizeShape = benchShape{sizes: []int{16, 1024, 65536}, dups: []int{0}}
+	// quadraticShape is used for functions whose cost or output grows quadratically with the input size.
+	quadraticShape = benchShape{sizes: []int{16, 256}, dups: []int{0, 50, 90}}
+	// thresholdShape is used for the strategies compared around DefaultLinearScanThreshold.
+	thresholdShape = benchShape{sizes: []int{4, 16, 64, 256}, dups: []int{0, 50, 90}}
+)
+
+// benchInts generates size integers with roughly dup percent of duplicates, shuffled with a fixed seed so every
+// run sees the same input. The distinct values are the first ones of createSequenceWithoutRepeats, repeated
+// cyclically until the input has size elements.
+func benchInts(size, dup int) []int {
+	// Reduce the number of distinct values to obtain the requested share of duplicates. The helper skips the
+	// multiples of 100, so ask it for a few more values than needed.
+	distinct := max(1, size-size*dup/100)
+	values := createSequenceWithoutRepeats(distinct + distinct/99 + 1)[:distinct]
+	elements := make([]int, size)
+	for i := range elements {
+		elements[i] = values[i%distinct]
+	}
+
+	// Shuffle deterministically, so duplicates are spread over the whole input.
+	r := rand.New(rand.NewPCG(uint64(size), uint64(dup)))
+	r.Shuffle(len(elements), func(i, j int) {
+		elements[i], elements[j] = elements[j], elements[i]
+	})
+	return elements
+}
+
+// benchFloats generates the same values as benchInts, converted to float64.
+func benchFloats(size, dup int) []float64 {
+	return Map(benchInts(size, dup), func(v int) float64 { return float64(v) + 0.5 })
+}
+
+// benchStrings generates the same values as benchInts, formatted as strings of equal length.
+func benchStrings(size, dup int) []string {
+	return Map(benchInts(size, dup), func(v int) string { return fmt.Sprintf("item-%08d", v) })
+}
+
+// benchRecords generates the same values as benchInts, wrapped in benchRecord values.
+func benchRecords(size, dup int) []benchRecord {
+	return Map(benchInts(size, dup), func(v int) benchRecord {
+		return benchRecord{ID: v, Name: "record-" + strconv.Itoa(v), Score: float64(v) / 2}
+	})
+}
+
+// benchSorted generates the input with gen and sorts it, for the functions that require sorted input.
+func benchSorted[T any](gen func(size, dup int) []T, less func(a, b T) bool) func(size, dup int) []T {
+	return func(size, dup int) []T {
+		elements := gen(size, dup)
+		sort.Slice(elements, func(i, j int) bool { return less(elements[i], elements[j]) })
+		return elements
+	}
+}
+
+// bench runs fn as the sub-benchmark name/typeName/size=N/dup=P for every size and duplicate percentage of the
+// shape. The input is generated before the timer starts and fn must run its operation b.N times.
+func bench[T any](b *testing.B, name, typeName string, shape benchShape, gen func(size, dup int) []T, fn func(b *testing.B, elements []T)) {
+	for _, size := range shape.sizes {
+		for _, dup := range shape.dups {
+			elements := gen(size, dup)
+			b.Run(fmt.Sprintf("%s/%s/size=%d/dup=%d", name, typeName, size, dup), func(b *testing.B) {
+				b.ReportAllocs()
+				fn(b, elements)
+			})
+		}
+	}
+}
+
+// restore copies the original input into the scratch buffer before an in-place function overwrites it.
+// The copy is part of the measured time, which keeps the benchmark free of timer stops that would dominate
+// small inputs.
+func restore[T any](scratch, original []T) []T {
+	return append(scratch[:0], original...)
+}
+
+func isEvenInt(v int) bool            { return v%2 == 0 }
+func isEvenRecord(r benchRecord) bool { return r.ID%2 == 0 }
+func recordID(r benchRecord) int      { return r.ID }
+func compareInts(a, b int) int        { return a - b }
+func lessInts(a, b int) bool          { return a < b }
+func lessStrings(a, b string) bool    { return a < b }
+
+// BenchmarkSlice covers the functions of slice.go.
+func BenchmarkSlice(b *testing.B) {
+	bench(b, "Merge", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Merge(xs, xs)
+		}
+	})
+	bench(b, "Merge", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Merge(xs, xs)
+		}
+	})
+
+	// Exclude reuses the backing array of its input, so every iteration works on a restored copy.
+	bench(b, "Exclude", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		scratch := make([]int, len(xs))
+		for i := 0; i < b.N; i++ {
+			Exclude(restore(scratch, xs), 0)
+		}
+	})
+	bench(b, "Exclude", "string", fullShape, benchStrings, func(b *testing.B, xs []string) {
+		scratch := make([]string, len(xs))
+		for i := 0; i < b.N; i++ {
+			Exclude(restore(scratch, xs), xs[0])
+		}
+	})
+	bench(b, "Exclude", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		scratch := make([]benchRecord, len(xs))
+		for i := 0; i < b.N; i++ {
+			Exclude(restore(scratch, xs), xs[0])
+		}
+	})
+	bench(b, "ExcludeFunc", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		scratch := make([]int, len(xs))
+		for i := 0; i < b.N; i++ {
+			ExcludeFunc(restore(scratch, xs), isEvenInt)
+		}
+	})
+	bench(b, "ExcludeFunc", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		scratch := make([]benchRecord, len(xs))
+		for i := 0; i < b.N; i++ {
+			ExcludeFunc(restore(scratch, xs), isEvenRecord)
+		}
+	})
+
+	// Contains searches for a missing element, which is the worst case of the linear scan.
+	bench(b, "Contains", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Contains(xs, -1)
+		}
+	})
+	bench(b, "Contains", "string", sizeShape, benchStrings, func(b *testing.B, xs []string) {
+		for i := 0; i < b.N; i++ {
+			Contains(xs, "missing")
+		}
+	})
+	bench(b, "Contains", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Contains(xs, benchRecord{ID: -1})
+		}
+	})
+	bench(b, "ContainsFunc", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			ContainsFunc(xs, func(v int) bool { return v < 0 })
+		}
+	})
+	bench(b, "ContainsFunc", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			ContainsFunc(xs, func(r benchRecord) bool { return r.ID < 0 })
+		}
+	})
+	bench(b, "ContainsCmp", "int", sizeShape, benchSorted(benchInts, lessInts), func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			ContainsCmp(xs, -1, compareInts)
+		}
+	})
+
+	bench(b, "Map", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Map(xs, func(v int) int { return v * 2 })
+		}
+	})
+	bench(b, "Map", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Map(xs, recordID)
+		}
+	})
+	bench(b, "Filter", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Filter(xs, isEvenInt)
+		}
+	})
+	bench(b, "Filter", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Filter(xs, isEvenRecord)
+		}
+	})
+
+	// The parallel variants use the default limit and a cheap function, so they mostly measure the overhead of
+	// the coordination compared with Map and Filter.
+	bench(b, "ParallelMap", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ParallelMap(context.Background(), xs, 0, func(v int) int { return v * 2 })
+		}
+	})
+	bench(b, "ParallelFilter", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ParallelFilter(context.Background(), xs, 0, isEvenInt)
+		}
+	})
+
+	bench(b, "Unique", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Unique(xs)
+		}
+	})
+	bench(b, "Unique", "string", fullShape, benchStrings, func(b *testing.B, xs []string) {
+		for i := 0; i < b.N; i++ {
+			Unique(xs)
+		}
+	})
+	bench(b, "Unique", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Unique(xs)
+		}
+	})
+	bench(b, "UniqueBy", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			UniqueBy(xs, recordID)
+		}
+	})
+	bench(b, "UniqueFunc", "struct", quadraticShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			UniqueFunc(xs, func(a, b benchRecord) bool { return a.ID == b.ID })
+		}
+	})
+}
+
+// BenchmarkAlloc covers the functions of alloc.go and compares their strategies. FilterInto reuses a buffer and
+// FilterIntoPresized allocates an exact-capacity one per call, to compare with Filter/int, which grows its result
+// from nil. UniqueIntoMap and UniqueIntoLinear force the map and the linear scan around
+// DefaultLinearScanThreshold, while UniqueInto uses the default threshold with a pooled map and a reused buffer.
+func BenchmarkAlloc(b *testing.B) {
+	bench(b, "FilterInto", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		buf := make([]int, 0, len(xs))
+		for i := 0; i < b.N; i++ {
+			buf = FilterInto(buf[:0], xs, isEvenInt)
+		}
+	})
+	bench(b, "FilterIntoPresized", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			FilterInto(make([]int, 0, len(xs)), xs, isEvenInt)
+		}
+	})
+	bench(b, "UniqueIntoMap", "int", thresholdShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			UniqueInto(nil, xs, WithLinearScanThreshold[int](0))
+		}
+	})
+	bench(b, "UniqueIntoLinear", "int", thresholdShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			UniqueInto(nil, xs, WithLinearScanThreshold[int](len(xs)))
+		}
+	})
+	bench(b, "UniqueInto", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		pool := NewSeenPool[int]()
+		buf := make([]int, 0, len(xs))
+		for i := 0; i < b.N; i++ {
+			buf = UniqueInto(buf[:0], xs, WithSeenPool(pool))
+		}
+	})
+	bench(b, "UniqueInto", "string", fullShape, benchStrings, func(b *testing.B, xs []string) {
+		pool := NewSeenPool[string]()
+		buf := make([]string, 0, len(xs))
+		for i := 0; i < b.N; i++ {
+			buf = UniqueInto(buf[:0], xs, WithSeenPool(pool))
+		}
+	})
+}
+
+// BenchmarkPipeline covers the functions and methods of pipeline.go with a typical chain of lazy steps.
+func BenchmarkPipeline(b *testing.B) {
+	bench(b, "Pipeline", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			p := PipeUnique(From(xs).Filter(isEvenInt).Map(func(v int) int { return v + 1 }))
+			MapTo(PipeExclude(p, 1).Skip(1).Take(len(xs)/2), strconv.Itoa).Collect()
+		}
+	})
+	bench(b, "PipelineCount", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			FromSeq(From(xs).Seq()).Filter(isEvenInt).Count()
+		}
+	})
+	bench(b, "PipelineFirst", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			From(xs).Filter(func(v int) bool { return v < 0 }).First()
+		}
+	})
+}
+
+// BenchmarkErrors covers the functions of errors.go on the success path.
+func BenchmarkErrors(b *testing.B) {
+	bench(b, "MapErr", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = MapErr(xs, CollectErrors, func(v int) (int, error) { return v * 2, nil })
+		}
+	})
+	bench(b, "FilterErr", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = FilterErr(xs, CollectErrors, func(v int) (bool, error) { return isEvenInt(v), nil })
+		}
+	})
+	bench(b, "ReduceErr", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ReduceErr(xs, 0, CollectErrors, func(acc, v int) (int, error) { return acc + v, nil })
+		}
+	})
+	bench(b, "ForEachErr", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_ = ForEachErr(xs, CollectErrors, func(int) error { return nil })
+		}
+	})
+}
+
+// BenchmarkSortedIndex covers the constructor and the queries of sorted_index.go. SortedIndexHas looks up the
+// same missing element as Contains in BenchmarkSlice, so building an index with NewSortedIndex pays off after
+// about NewSortedIndex / (Contains - SortedIndexHas) lookups.
+func BenchmarkSortedIndex(b *testing.B) {
+	bench(b, "NewSortedIndex", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			NewSortedIndex(xs)
+		}
+	})
+	bench(b, "NewSortedIndex", "string", fullShape, benchStrings, func(b *testing.B, xs []string) {
+		for i := 0; i < b.N; i++ {
+			NewSortedIndex(xs)
+		}
+	})
+	bench(b, "SortedIndexHas", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		index := NewSortedIndex(xs)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			index.Has(-1)
+		}
+	})
+	bench(b, "SortedIndexQueries", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		index := NewSortedIndex(xs)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			index.Has(len(xs) / 2)
+			index.IndexOf(len(xs) / 3)
+			index.Rank(len(xs) / 4)
+			index.Range(len(xs)/4, len(xs)/4+8)
+		}
+	})
+}
+
+// BenchmarkSorted covers the set operations of sorted.go. The second operand holds the same values shifted by
+// half the input size, so the inputs partially overlap.
+func BenchmarkSorted(b *testing.B) {
+	sortedInts := benchSorted(benchInts, lessInts)
+	shifted := func(xs []int) []int {
+		return Map(xs, func(v int) int { return v + len(xs)/2 })
+	}
+
+	bench(b, "SortedDedup", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			SortedDedup(xs)
+		}
+	})
+	bench(b, "SortedDedup", "string", fullShape, benchSorted(benchStrings, lessStrings), func(b *testing.B, xs []string) {
+		for i := 0; i < b.N; i++ {
+			SortedDedup(xs)
+		}
+	})
+	bench(b, "SortedDedupFunc", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			SortedDedupFunc(xs, compareInts)
+		}
+	})
+	bench(b, "SortedUnion", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		other := shifted(xs)
+		for i := 0; i < b.N; i++ {
+			SortedUnion(xs, other)
+		}
+	})
+	bench(b, "SortedUnionFunc", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		other := shifted(xs)
+		for i := 0; i < b.N; i++ {
+			SortedUnionFunc(xs, other, compareInts)
+		}
+	})
+	bench(b, "SortedIntersect", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		other := shifted(xs)
+		for i := 0; i < b.N; i++ {
+			SortedIntersect(xs, other)
+		}
+	})
+	bench(b, "SortedIntersectFunc", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		other := shifted(xs)
+		for i := 0; i < b.N; i++ {
+			SortedIntersectFunc(xs, other, compareInts)
+		}
+	})
+	bench(b, "SortedDifference", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		other := shifted(xs)
+		for i := 0; i < b.N; i++ {
+			SortedDifference(xs, other)
+		}
+	})
+	bench(b, "SortedDifferenceFunc", "int", fullShape, sortedInts, func(b *testing.B, xs []int) {
+		other := shifted(xs)
+		for i := 0; i < b.N; i++ {
+			SortedDifferenceFunc(xs, other, compareInts)
+		}
+	})
+
+	// The k-way merge splits the input into eight sorted lists.
+	sortedLists := func(xs []int, k int) [][]int {
+		return Map(Chunk(xs, max(1, len(xs)/k)), func(c []int) []int {
+			list := append([]int(nil), c...)
+			sort.Ints(list)
+			return list
+		})
+	}
+	bench(b, "SortedMergeK", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		lists := sortedLists(xs, 8)
+		for i := 0; i < b.N; i++ {
+			SortedMergeK(lists...)
+		}
+	})
+	bench(b, "SortedMergeKFunc", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		lists := sortedLists(xs, 8)
+		for i := 0; i < b.N; i++ {
+			SortedMergeKFunc(compareInts, lists...)
+		}
+	})
+}
+
+// BenchmarkAggregate covers the functions of aggregate.go.
+func BenchmarkAggregate(b *testing.B) {
+	add := func(a, b int) int { return a + b }
+
+	bench(b, "Reduce", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Reduce(xs, add)
+		}
+	})
+	bench(b, "FoldLeft", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			FoldLeft(xs, 0, add)
+		}
+	})
+	bench(b, "FoldRight", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			FoldRight(xs, 0, add)
+		}
+	})
+	bench(b, "Scan", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Scan(xs, 0, add)
+		}
+	})
+
+	// The numeric helpers run on both an integer and a floating-point type.
+	bench(b, "Sum", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Sum(xs)
+		}
+	})
+	bench(b, "Sum", "float64", sizeShape, benchFloats, func(b *testing.B, xs []float64) {
+		for i := 0; i < b.N; i++ {
+			Sum(xs)
+		}
+	})
+	bench(b, "Product", "float64", sizeShape, benchFloats, func(b *testing.B, xs []float64) {
+		for i := 0; i < b.N; i++ {
+			Product(xs)
+		}
+	})
+	bench(b, "Average", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Average(xs)
+		}
+	})
+	bench(b, "Average", "float64", sizeShape, benchFloats, func(b *testing.B, xs []float64) {
+		for i := 0; i < b.N; i++ {
+			Average(xs)
+		}
+	})
+	bench(b, "Min", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Min(xs)
+		}
+	})
+	bench(b, "Min", "string", sizeShape, benchStrings, func(b *testing.B, xs []string) {
+		for i := 0; i < b.N; i++ {
+			Min(xs)
+		}
+	})
+	bench(b, "Max", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Max(xs)
+		}
+	})
+	bench(b, "Max", "string", sizeShape, benchStrings, func(b *testing.B, xs []string) {
+		for i := 0; i < b.N; i++ {
+			Max(xs)
+		}
+	})
+	bench(b, "MinBy", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			MinBy(xs, recordID)
+		}
+	})
+	bench(b, "MaxBy", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			MaxBy(xs, func(r benchRecord) string { return r.Name })
+		}
+	})
+}
+
+// BenchmarkGroup covers the functions of group.go. The number of groups follows the duplicate percentage.
+func BenchmarkGroup(b *testing.B) {
+	bench(b, "GroupBy", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			GroupBy(xs, recordID)
+		}
+	})
+	bench(b, "GroupByOrdered", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			GroupByOrdered(xs, recordID)
+		}
+	})
+	bench(b, "Partition", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Partition(xs, isEvenInt)
+		}
+	})
+	bench(b, "Partition", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Partition(xs, isEvenRecord)
+		}
+	})
+	bench(b, "KeyBy", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			_, _ = KeyBy(xs, recordID, KeepLast)
+		}
+	})
+	bench(b, "CountBy", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			CountBy(xs, func(v int) int { return v })
+		}
+	})
+	bench(b, "CountBy", "string", fullShape, benchStrings, func(b *testing.B, xs []string) {
+		for i := 0; i < b.N; i++ {
+			CountBy(xs, func(v string) string { return v })
+		}
+	})
+}
+
+// BenchmarkChunk covers the functions of chunk.go, draining the Seq variants with an empty loop.
+func BenchmarkChunk(b *testing.B) {
+	weight := func(v int) int { return v%7 + 1 }
+
+	bench(b, "Chunk", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Chunk(xs, 8)
+		}
+	})
+	bench(b, "ChunkSeq", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			for range ChunkSeq(xs, 8) {
+			}
+		}
+	})
+	bench(b, "SlidingWindow", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			SlidingWindow(xs, 8, 1)
+		}
+	})
+	bench(b, "SlidingWindowSeq", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			for range SlidingWindowSeq(xs, 8, 1) {
+			}
+		}
+	})
+	bench(b, "ChunkBy", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			ChunkBy(xs, isEvenInt)
+		}
+	})
+	bench(b, "ChunkBySeq", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			for range ChunkBySeq(xs, isEvenInt) {
+			}
+		}
+	})
+	bench(b, "Batch", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Batch(xs, 16, 32, weight)
+		}
+	})
+	bench(b, "BatchSeq", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			for range BatchSeq(xs, 16, 32, weight) {
+			}
+		}
+	})
+}
+
+// BenchmarkZip covers the functions of zip.go.
+func BenchmarkZip(b *testing.B) {
+	bench(b, "Zip", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Zip(xs, xs, RequireEqualLength)
+		}
+	})
+	bench(b, "Zip", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Zip(xs, xs, RequireEqualLength)
+		}
+	})
+	bench(b, "ZipWith", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ZipWith(xs, xs, TruncateToShortest, func(a, b int) int { return a + b })
+		}
+	})
+	bench(b, "Zip3", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Zip3(xs, xs, xs, RequireEqualLength)
+		}
+	})
+	bench(b, "ZipLongest", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			ZipLongest(xs, xs[:len(xs)/2], 0, 0)
+		}
+	})
+	bench(b, "Unzip", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		pairs := Enumerate(xs)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			Unzip(pairs)
+		}
+	})
+	bench(b, "Unzip3", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		triples, _ := Zip3(xs, xs, xs, RequireEqualLength)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			Unzip3(triples)
+		}
+	})
+	bench(b, "CartesianProduct", "int", benchShape{sizes: quadraticShape.sizes, dups: []int{0}}, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			CartesianProduct(xs, xs)
+		}
+	})
+	bench(b, "Enumerate", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Enumerate(xs)
+		}
+	})
+}
+
+// BenchmarkInPlace covers the functions of inplace.go. The in-place functions include the cost of restoring
+// their input, so they should be compared with each other rather than with their allocating counterparts.
+func BenchmarkInPlace(b *testing.B) {
+	bench(b, "FilterInPlace", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		scratch := make([]int, len(xs))
+		for i := 0; i < b.N; i++ {
+			FilterInPlace(restore(scratch, xs), isEvenInt)
+		}
+	})
+	bench(b, "UniqueInPlace", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		scratch := make([]int, len(xs))
+		for i := 0; i < b.N; i++ {
+			UniqueInPlace(restore(scratch, xs))
+		}
+	})
+	bench(b, "UniqueInPlace", "string", fullShape, benchStrings, func(b *testing.B, xs []string) {
+		scratch := make([]string, len(xs))
+		for i := 0; i < b.N; i++ {
+			UniqueInPlace(restore(scratch, xs))
+		}
+	})
+	bench(b, "CompactInPlace", "int", fullShape, benchSorted(benchInts, lessInts), func(b *testing.B, xs []int) {
+		scratch := make([]int, len(xs))
+		for i := 0; i < b.N; i++ {
+			CompactInPlace(restore(scratch, xs))
+		}
+	})
+	bench(b, "ReverseInPlace", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			ReverseInPlace(xs)
+		}
+	})
+	bench(b, "RotateInPlace", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			RotateInPlace(xs, len(xs)/3)
+		}
+	})
+	bench(b, "Compact", "int", fullShape, benchSorted(benchInts, lessInts), func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Compact(xs)
+		}
+	})
+	bench(b, "Compact", "struct", fullShape, benchSorted(benchRecords, func(a, b benchRecord) bool { return a.ID < b.ID }), func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			Compact(xs)
+		}
+	})
+	bench(b, "Reverse", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Reverse(xs)
+		}
+	})
+	bench(b, "Rotate", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			Rotate(xs, len(xs)/3)
+		}
+	})
+}
+
+// BenchmarkDiff covers the functions of diff.go. The second input is the first one with every tenth element
+// replaced, so the number of differences grows with the size and the duplicate percentage controls how many
+// equal elements the search can confuse.
+func BenchmarkDiff(b *testing.B) {
+	edited := func(xs []int) []int {
+		result := Merge(xs, nil)
+		for i := 0; i < len(result); i += 10 {
+			result[i] = -i
+		}
+		return result
+	}
+	shape := benchShape{sizes: []int{16, 1024}, dups: []int{0, 90}}
+
+	bench(b, "Diff", "int", shape, benchInts, func(b *testing.B, xs []int) {
+		other := edited(xs)
+		for i := 0; i < b.N; i++ {
+			Diff(xs, other)
+		}
+	})
+	bench(b, "DiffFunc", "int", shape, benchInts, func(b *testing.B, xs []int) {
+		other := edited(xs)
+		for i := 0; i < b.N; i++ {
+			DiffFunc(xs, other, func(x, y int) bool { return x == y })
+		}
+	})
+	bench(b, "LCS", "string", shape, benchStrings, func(b *testing.B, xs []string) {
+		other := Reverse(xs)
+		for i := 0; i < b.N; i++ {
+			LCS(xs, other)
+		}
+	})
+	bench(b, "LCSFunc", "int", shape, benchInts, func(b *testing.B, xs []int) {
+		other := edited(xs)
+		for i := 0; i < b.N; i++ {
+			LCSFunc(xs, other, func(x, y int) bool { return x == y })
+		}
+	})
+	bench(b, "Unified", "int", shape, benchInts, func(b *testing.B, xs []int) {
+		edits := Diff(xs, edited(xs))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			Unified(edits, "a", "b", 3, strconv.Itoa)
+		}
+	})
+}
+
+// BenchmarkSearch covers the functions of search.go. Every iteration searches a target from the middle of the
+// sorted input, so the cost is the logarithmic search itself.
+func BenchmarkSearch(b *testing.B) {
+	sorted := benchSorted(benchInts, func(a, b int) bool { return a < b })
+	compare := func(a, b int) int { return a - b }
+
+	bench(b, "LowerBound", "int", fullShape, sorted, func(b *testing.B, xs []int) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			LowerBound(xs, target)
+		}
+	})
+	bench(b, "LowerBoundFunc", "int", sizeShape, sorted, func(b *testing.B, xs []int) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			LowerBoundFunc(xs, target, compare)
+		}
+	})
+	bench(b, "UpperBound", "int", fullShape, sorted, func(b *testing.B, xs []int) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			UpperBound(xs, target)
+		}
+	})
+	bench(b, "UpperBoundFunc", "int", sizeShape, sorted, func(b *testing.B, xs []int) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			UpperBoundFunc(xs, target, compare)
+		}
+	})
+	bench(b, "EqualRange", "int", fullShape, sorted, func(b *testing.B, xs []int) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			EqualRange(xs, target)
+		}
+	})
+	bench(b, "EqualRangeFunc", "int", sizeShape, sorted, func(b *testing.B, xs []int) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			EqualRangeFunc(xs, target, compare)
+		}
+	})
+	bench(b, "BinarySearch", "string", sizeShape, benchSorted(benchStrings, func(a, b string) bool { return a < b }), func(b *testing.B, xs []string) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			BinarySearch(xs, target)
+		}
+	})
+	bench(b, "BinarySearchFunc", "int", sizeShape, sorted, func(b *testing.B, xs []int) {
+		target := xs[len(xs)/2]
+		for i := 0; i < b.N; i++ {
+			BinarySearchFunc(xs, target, compare)
+		}
+	})
+}
+
+// BenchmarkSortBy covers the functions of sortby.go. The selections keep ten elements, the common case for which
+// they beat a full sort.
+func BenchmarkSortBy(b *testing.B) {
+	byID := func(r benchRecord) int { return r.ID }
+
+	bench(b, "SortBy", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			SortBy(xs, byID)
+		}
+	})
+	bench(b, "SortStableBy", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			SortStableBy(xs, byID)
+		}
+	})
+	bench(b, "SortByKeys", "struct", fullShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		keys := []SortKey[benchRecord]{Descending(byID), Ascending(func(r benchRecord) string { return r.Name })}
+		for i := 0; i < b.N; i++ {
+			SortByKeys(xs, keys...)
+		}
+	})
+	bench(b, "TopK", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			TopK(xs, 10)
+		}
+	})
+	bench(b, "TopKBy", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			TopKBy(xs, 10, byID)
+		}
+	})
+	bench(b, "BottomK", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			BottomK(xs, 10)
+		}
+	})
+	bench(b, "BottomKBy", "struct", sizeShape, benchRecords, func(b *testing.B, xs []benchRecord) {
+		for i := 0; i < b.N; i++ {
+			BottomKBy(xs, 10, byID)
+		}
+	})
+}
+
+// BenchmarkSyncSlice covers the functions of syncslice.go. The read benchmarks run in parallel over a SyncSlice
+// whose snapshot is current, the read-heavy case the copy-on-write snapshot is designed for, while SyncSliceWrite
+// measures the cost of a write followed by the copy of the next read.
+func BenchmarkSyncSlice(b *testing.B) {
+	bench(b, "SyncSliceSnapshot", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		s := NewSyncSlice(xs)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s.Snapshot()
+			}
+		})
+	})
+	bench(b, "SyncSliceWrite", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		s := NewSyncSlice(xs)
+		for i := 0; i < b.N; i++ {
+			s.Update(func(e []int) []int { e[0] = i; return e })
+			s.Snapshot()
+		}
+	})
+	bench(b, "SyncMap", "int", sizeShape, benchInts, func(b *testing.B, xs []int) {
+		s := NewSyncSlice(xs)
+		for i := 0; i < b.N; i++ {
+			SyncMap(s, func(v int) int { return v * 2 })
+		}
+	})
+	bench(b, "SyncUnique", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		s := NewSyncSlice(xs)
+		for i := 0; i < b.N; i++ {
+			SyncUnique(s)
+		}
+	})
+	bench(b, "SyncUniqueInPlace", "int", fullShape, benchInts, func(b *testing.B, xs []int) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			s := NewSyncSlice(xs)
+			b.StartTimer()
+			SyncUniqueInPlace(s)
+		}
+	})
+}
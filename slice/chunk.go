@@ -0,0 +1,147 @@
+package slice
+
+import (
+	"iter"
+
+	"github.com/spacemagneto/common/slice/seq"
+)
+
+// The functions in this file never copy elements. Every chunk, window and batch they produce is a view over
+// the input slice: writing to an element of a view writes to the input, and the other way around. Every view
+// is capped at its own length, so appending to a view reallocates instead of overwriting the elements that
+// follow it in the input. Use Map with a copying function, or slices.Clone, when independent chunks are needed.
+
+// Chunk splits a slice into consecutive views of n elements each; the last view may be shorter.
+// It returns nil when the slice is empty or n is not positive.
+func Chunk[T any](elements []T, n int) [][]T {
+	return seq.Collect(ChunkSeq(elements, n))
+}
+
+// ChunkSeq is the iterator variant of Chunk. It yields the same views lazily, one chunk at a time.
+func ChunkSeq[T any](elements []T, n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		// A non-positive chunk size cannot make progress, so nothing is yielded.
+		if n <= 0 {
+			return
+		}
+
+		// Walk the slice in steps of n elements.
+		for start := 0; start < len(elements); start += n {
+			// Clamp the last chunk to the end of the slice.
+			end := min(start+n, len(elements))
+
+			// Yield a view capped at its own length.
+			if !yield(elements[start:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+// SlidingWindow returns the views of size consecutive elements starting at every step-th position.
+// Only complete windows are returned, so a slice shorter than size produces no window. Windows overlap
+// when step is smaller than size, in which case neighbouring windows share elements with each other.
+// It returns nil when size or step is not positive.
+func SlidingWindow[T any](elements []T, size, step int) [][]T {
+	return seq.Collect(SlidingWindowSeq(elements, size, step))
+}
+
+// SlidingWindowSeq is the iterator variant of SlidingWindow. It yields the same views lazily, one window at a time.
+func SlidingWindowSeq[T any](elements []T, size, step int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		// Non-positive sizes or steps cannot make progress, so nothing is yielded.
+		if size <= 0 || step <= 0 {
+			return
+		}
+
+		// Yield every complete window. The bounds are compared against the remaining length, so that a huge size
+		// or step cannot overflow start.
+		for start := 0; size <= len(elements)-start; start += step {
+			end := start + size
+			if !yield(elements[start:end:end]) {
+				return
+			}
+
+			// Stop when the next window would start past the end.
+			if step > len(elements)-start {
+				return
+			}
+		}
+	}
+}
+
+// ChunkBy splits a slice into views of consecutive elements for which the predicate returns the same result.
+// A new view starts every time the predicate result changes from one element to the next.
+// It returns nil when the slice is empty. The predicate is evaluated exactly once per element.
+func ChunkBy[T any](elements []T, fn func(T) bool) [][]T {
+	return seq.Collect(ChunkBySeq(elements, fn))
+}
+
+// ChunkBySeq is the iterator variant of ChunkBy. It yields the same views lazily, one run at a time.
+func ChunkBySeq[T any](elements []T, fn func(T) bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		// An empty slice has no run.
+		if len(elements) == 0 {
+			return
+		}
+
+		// Track the start of the current run and the predicate result shared by its elements.
+		start, current := 0, fn(elements[0])
+
+		// Close the current run every time the predicate result changes.
+		for i := 1; i < len(elements); i++ {
+			if next := fn(elements[i]); next != current {
+				if !yield(elements[start:i:i]) {
+					return
+				}
+				start, current = i, next
+			}
+		}
+
+		// Yield the final run.
+		yield(elements[start:len(elements):len(elements)])
+	}
+}
+
+// Batch splits a slice into views that hold at most maxCount elements and whose total weight, as computed
+// by the weight function, does not exceed maxWeight. A batch is closed as soon as adding the next element
+// would break either limit. An element that is heavier than maxWeight on its own is placed alone in its batch,
+// so every element is always part of exactly one batch. It returns nil when the slice is empty or maxCount
+// is not positive.
+func Batch[T any, W Number](elements []T, maxCount int, maxWeight W, weight func(T) W) [][]T {
+	return seq.Collect(BatchSeq(elements, maxCount, maxWeight, weight))
+}
+
+// BatchSeq is the iterator variant of Batch. It yields the same views lazily, one batch at a time.
+func BatchSeq[T any, W Number](elements []T, maxCount int, maxWeight W, weight func(T) W) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		// A non-positive count limit cannot make progress, so nothing is yielded.
+		if maxCount <= 0 {
+			return
+		}
+
+		// Track the start of the current batch and its accumulated weight.
+		start := 0
+		var total W
+
+		for i, v := range elements {
+			w := weight(v)
+
+			// Close the current batch when it is not empty and the element would break one of the limits.
+			if i > start && (i-start >= maxCount || total+w > maxWeight) {
+				if !yield(elements[start:i:i]) {
+					return
+				}
+				start, total = i, 0
+			}
+
+			// Add the element to the current batch.
+			total += w
+		}
+
+		// Yield the final batch, if any element is left.
+		if start < len(elements) {
+			yield(elements[start:len(elements):len(elements)])
+		}
+	}
+}
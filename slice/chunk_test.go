@@ -0,0 +1,202 @@
+package slice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spacemagneto/common/slice/seq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for splitting slices into fixed-size chunks.
+	cases := []struct {
+		name     string
+		elements []int
+		n        int
+		expected [][]int
+	}{
+		{name: "Nil slice", elements: nil, n: 2, expected: nil},
+		{name: "Zero size", elements: []int{1, 2}, n: 0, expected: nil},
+		{name: "Negative size", elements: []int{1, 2}, n: -1, expected: nil},
+		{name: "Exact multiple", elements: []int{1, 2, 3, 4}, n: 2, expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "Shorter last chunk", elements: []int{1, 2, 3, 4, 5}, n: 2, expected: [][]int{{1, 2}, {3, 4}, {5}}},
+		{name: "Size larger than slice", elements: []int{1, 2}, n: 5, expected: [][]int{{1, 2}}},
+		{name: "Size one", elements: []int{1, 2, 3}, n: 1, expected: [][]int{{1}, {2}, {3}}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// The slice and the iterator variants must produce the same chunks.
+			assert.Equal(t, tt.expected, Chunk(tt.elements, tt.n), "Chunk result for case %q", tt.name)
+			assert.Equal(t, tt.expected, seq.Collect(ChunkSeq(tt.elements, tt.n)), "ChunkSeq result for case %q", tt.name)
+		})
+	}
+
+	// SharesMemory tests the aliasing contract: chunks are views over the input, but appending to a chunk
+	// must not overwrite the elements of the next chunk.
+	t.Run("SharesMemory", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		chunks := Chunk(input, 2)
+
+		// Writing through a chunk is visible in the input, because the chunk shares its backing array.
+		chunks[1][0] = 30
+		assert.Equal(t, []int{1, 2, 30, 4, 5}, input, "Writing to a chunk should write to the input")
+
+		// Appending to a chunk reallocates, because every chunk is capped at its own length.
+		grown := append(chunks[0], 99)
+		grown[0] = 10
+		assert.Equal(t, []int{1, 2, 30, 4, 5}, input, "Appending to a chunk should not modify the input")
+		assert.Equal(t, 2, cap(chunks[0]), "Every chunk should be capped at its own length")
+	})
+
+	// StopsEarly tests that the iterator variant stops when the consumer stops.
+	t.Run("StopsEarly", func(t *testing.T) {
+		first := seq.Collect(seq.Take(ChunkSeq(createSequenceWithoutRepeats(1000), 3), 1))
+		assert.Equal(t, [][]int{{1, 2, 3}}, first, "ChunkSeq should stop after the first chunk")
+	})
+}
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for overlapping, adjacent and gapped windows.
+	cases := []struct {
+		name       string
+		elements   []int
+		size, step int
+		expected   [][]int
+	}{
+		{name: "Nil slice", elements: nil, size: 2, step: 1, expected: nil},
+		{name: "Slice shorter than window", elements: []int{1, 2}, size: 3, step: 1, expected: nil},
+		{name: "Zero size", elements: []int{1, 2}, size: 0, step: 1, expected: nil},
+		{name: "Zero step", elements: []int{1, 2}, size: 1, step: 0, expected: nil},
+		{name: "Overlapping windows", elements: []int{1, 2, 3, 4}, size: 2, step: 1, expected: [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{name: "Adjacent windows", elements: []int{1, 2, 3, 4, 5}, size: 2, step: 2, expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "Gapped windows", elements: []int{1, 2, 3, 4, 5, 6, 7}, size: 2, step: 3, expected: [][]int{{1, 2}, {4, 5}}},
+		{name: "Single full window", elements: []int{1, 2, 3}, size: 3, step: 1, expected: [][]int{{1, 2, 3}}},
+		{name: "Huge step", elements: []int{1, 2, 3}, size: 1, step: math.MaxInt, expected: [][]int{{1}}},
+		{name: "Huge size", elements: []int{1, 2, 3}, size: math.MaxInt, step: 1, expected: nil},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SlidingWindow(tt.elements, tt.size, tt.step), "SlidingWindow result for case %q", tt.name)
+			assert.Equal(t, tt.expected, seq.Collect(SlidingWindowSeq(tt.elements, tt.size, tt.step)), "SlidingWindowSeq result for case %q", tt.name)
+		})
+	}
+
+	// SharesMemory tests that overlapping windows share their common elements with each other and with the input.
+	t.Run("SharesMemory", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		windows := SlidingWindow(input, 2, 1)
+
+		// The second element of the first window is the first element of the second window.
+		windows[0][1] = 20
+		assert.Equal(t, 20, windows[1][0], "Overlapping windows should share elements")
+		assert.Equal(t, []int{1, 20, 3}, input, "Windows should share elements with the input")
+	})
+
+	// RollingAverage tests a typical use of the windows over metrics.
+	t.Run("RollingAverage", func(t *testing.T) {
+		averages := Map(SlidingWindow([]float64{1, 2, 3, 4, 5}, 3, 1), func(w []float64) float64 {
+			average, _ := Average(w)
+			return average
+		})
+		assert.Equal(t, []float64{2, 3, 4}, averages, "Rolling averages should be computed over every window")
+	})
+}
+
+func TestChunkBy(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	// Define test cases for splitting slices where the predicate result changes.
+	cases := []struct {
+		name     string
+		elements []int
+		expected [][]int
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "Single element", elements: []int{1}, expected: [][]int{{1}}},
+		{name: "Single run", elements: []int{2, 4, 6}, expected: [][]int{{2, 4, 6}}},
+		{name: "Alternating", elements: []int{1, 2, 3}, expected: [][]int{{1}, {2}, {3}}},
+		{name: "Runs", elements: []int{1, 3, 2, 4, 6, 5, 7}, expected: [][]int{{1, 3}, {2, 4, 6}, {5, 7}}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ChunkBy(tt.elements, isEven), "ChunkBy result for case %q", tt.name)
+			assert.Equal(t, tt.expected, seq.Collect(ChunkBySeq(tt.elements, isEven)), "ChunkBySeq result for case %q", tt.name)
+		})
+	}
+
+	// SharesMemory tests that runs are views over the input.
+	t.Run("SharesMemory", func(t *testing.T) {
+		input := []int{1, 3, 2, 4}
+		runs := ChunkBy(input, isEven)
+
+		runs[1][1] = 40
+		assert.Equal(t, []int{1, 3, 2, 40}, input, "Writing to a run should write to the input")
+		assert.Equal(t, 2, cap(runs[0]), "Every run should be capped at its own length")
+	})
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	// size uses the length of a string as its weight, like a payload size in bytes.
+	size := func(s string) int { return len(s) }
+
+	// Define test cases that exercise both the count and the weight limits.
+	cases := []struct {
+		name      string
+		elements  []string
+		maxCount  int
+		maxWeight int
+		expected  [][]string
+	}{
+		{name: "Nil slice", elements: nil, maxCount: 2, maxWeight: 10, expected: nil},
+		{name: "Zero count", elements: []string{"a"}, maxCount: 0, maxWeight: 10, expected: nil},
+		{name: "Count limit only", elements: []string{"a", "b", "c", "d", "e"}, maxCount: 2, maxWeight: 100, expected: [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{name: "Weight limit only", elements: []string{"aaa", "bb", "cccc", "d"}, maxCount: 10, maxWeight: 5, expected: [][]string{{"aaa", "bb"}, {"cccc", "d"}}},
+		{name: "Both limits", elements: []string{"a", "b", "c", "dddd", "e"}, maxCount: 2, maxWeight: 4, expected: [][]string{{"a", "b"}, {"c"}, {"dddd"}, {"e"}}},
+		{name: "Oversized element stands alone", elements: []string{"a", "toolarge", "b"}, maxCount: 5, maxWeight: 3, expected: [][]string{{"a"}, {"toolarge"}, {"b"}}},
+		{name: "Exact weight fits", elements: []string{"aa", "bb", "c"}, maxCount: 5, maxWeight: 4, expected: [][]string{{"aa", "bb"}, {"c"}}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Batch(tt.elements, tt.maxCount, tt.maxWeight, size), "Batch result for case %q", tt.name)
+			assert.Equal(t, tt.expected, seq.Collect(BatchSeq(tt.elements, tt.maxCount, tt.maxWeight, size)), "BatchSeq result for case %q", tt.name)
+		})
+	}
+
+	// CoversEveryElement tests that concatenating the batches reproduces the input, in order.
+	t.Run("CoversEveryElement", func(t *testing.T) {
+		input := createSequenceWithRepeats(1000, 5)
+		batches := Batch(input, 7, 2000, func(n int) int { return n })
+
+		assert.Equal(t, input, FoldLeft(batches, []int{}, func(acc, b []int) []int { return append(acc, b...) }), "Batches should cover every element in order")
+		for _, b := range batches {
+			assert.LessOrEqual(t, len(b), 7, "No batch should exceed the count limit")
+			assert.True(t, len(b) == 1 || Sum(b) <= 2000, "No batch should exceed the weight limit unless it holds a single element")
+		}
+	})
+
+	// SharesMemory tests that batches are views over the input.
+	t.Run("SharesMemory", func(t *testing.T) {
+		input := []string{"a", "b", "c"}
+		batches := Batch(input, 2, 10, size)
+
+		batches[1][0] = "z"
+		assert.Equal(t, []string{"a", "b", "z"}, input, "Writing to a batch should write to the input")
+	})
+}
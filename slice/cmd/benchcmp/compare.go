@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Samples holds the measurements of every benchmark found in the output of one or more `go test -bench` runs.
+// A benchmark run several times, for example with -count, contributes one sample per run and unit.
+type Samples struct {
+	// names lists the benchmark names in the order they first appeared, so reports follow the input order.
+	names []string
+	// values maps a benchmark name to its samples, keyed by unit such as "ns/op" or "allocs/op".
+	values map[string]map[string][]float64
+}
+
+// Names returns the benchmark names in the order they first appeared in the input.
+func (s *Samples) Names() []string {
+	return s.names
+}
+
+// Values returns the samples recorded for the benchmark and unit, or nil if there are none.
+func (s *Samples) Values(name, unit string) []float64 {
+	return s.values[name][unit]
+}
+
+// add records one sample, registering the benchmark name on first use.
+func (s *Samples) add(name, unit string, value float64) {
+	units, ok := s.values[name]
+	if !ok {
+		units = make(map[string][]float64)
+		s.values[name] = units
+		s.names = append(s.names, name)
+	}
+	units[unit] = append(units[unit], value)
+}
+
+// Parse reads the output of `go test -bench` and collects the samples of every benchmark line.
+// Lines that are not benchmark results, such as package headers and PASS lines, are ignored.
+// The GOMAXPROCS suffix is removed from benchmark names, so runs on machines with a different number of
+// CPUs can be compared.
+func Parse(r io.Reader) (*Samples, error) {
+	samples := &Samples{values: make(map[string]map[string][]float64)}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		// A result line is the name, the iteration count and then value and unit pairs.
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") || len(fields)%2 != 0 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+
+		// Record every measurement of the line.
+		name := trimProcs(fields[0])
+		for i := 2; i < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q for %s: %w", line, fields[i], fields[i+1], err)
+			}
+			samples.add(name, fields[i+1], value)
+		}
+	}
+
+	// Report read errors rather than silently comparing a truncated input.
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// trimProcs removes the -N GOMAXPROCS suffix that the testing package appends to benchmark names.
+func trimProcs(name string) string {
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 {
+		return name
+	}
+	if _, err := strconv.Atoi(name[i+1:]); err != nil {
+		return name
+	}
+	return name[:i]
+}
+
+// Comparison is the change of one benchmark between the old and the new samples, for a single unit.
+type Comparison struct {
+	// Name is the benchmark name without its GOMAXPROCS suffix.
+	Name string
+	// Old and New are the medians of the old and new samples.
+	Old, New float64
+	// Delta is the relative change of the median in percent. A positive value means the new run is slower
+	// or uses more of the measured resource.
+	Delta float64
+	// P is the one-sided p-value of the Mann-Whitney U test for the new samples being larger than the old ones.
+	P float64
+	// Regression reports that Delta exceeds the threshold and that P is below the significance level.
+	Regression bool
+	// Insufficient reports that the benchmark has too few samples for P to ever be below the significance level,
+	// so the comparison cannot tell a regression from noise. Regression is false for such a benchmark.
+	Insufficient bool
+}
+
+// Compare compares the medians of the benchmarks present in both before and after for the provided unit, in the
+// order they appear in after. A benchmark counts as a regression when its median grew by more than threshold
+// percent and a one-sided Mann-Whitney U test, the rank test benchstat uses, finds the new samples larger than
+// the old ones with a p-value below alpha. Ranking the samples keeps a single outlier on either side from hiding
+// a regression or from reporting one. A benchmark with so few samples that no p-value can be below alpha is
+// marked Insufficient instead; an alpha of 1 disables the test and only applies the threshold. Benchmarks
+// present in only one of the inputs are returned by Unmatched instead.
+func Compare(before, after *Samples, unit string, threshold, alpha float64) []Comparison {
+	var comparisons []Comparison
+	for _, name := range after.names {
+		oldValues, newValues := before.Values(name, unit), after.Values(name, unit)
+		if len(oldValues) == 0 || len(newValues) == 0 {
+			continue
+		}
+
+		// Compare the medians, which are less sensitive to outliers than means.
+		c := Comparison{Name: name, Old: median(oldValues), New: median(newValues)}
+		switch {
+		case c.Old == c.New:
+			c.Delta = 0
+		case c.Old == 0:
+			c.Delta = math.Inf(1)
+		default:
+			c.Delta = (c.New - c.Old) / c.Old * 100
+		}
+		c.P = mannWhitneyP(oldValues, newValues)
+
+		// Flag the comparisons the test cannot decide, rather than silently passing them.
+		c.Insufficient = minPValue(len(oldValues), len(newValues)) >= alpha
+		c.Regression = !c.Insufficient && c.Delta > threshold && c.P < alpha
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
+
+// Unmatched returns the names of the benchmarks that only appear in before and the ones that only appear in after.
+func Unmatched(before, after *Samples) (onlyOld, onlyNew []string) {
+	for _, name := range before.names {
+		if _, ok := after.values[name]; !ok {
+			onlyOld = append(onlyOld, name)
+		}
+	}
+	for _, name := range after.names {
+		if _, ok := before.values[name]; !ok {
+			onlyNew = append(onlyNew, name)
+		}
+	}
+	return onlyOld, onlyNew
+}
+
+// median returns the median of the values without modifying them.
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// exactLimit is the largest number of pooled samples for which mannWhitneyP computes the exact distribution of
+// the rank sum; larger inputs use the normal approximation.
+const exactLimit = 50
+
+// mannWhitneyP returns the one-sided p-value of the Mann-Whitney U test for the hypothesis that the new values
+// tend to be larger than the old ones. Tied values get the mean of their ranks. Up to exactLimit pooled values,
+// the p-value is the exact probability, over every assignment of the pooled ranks to the two groups, of a rank sum
+// of the new values at least as large as the observed one; above it, the normal approximation with tie and
+// continuity correction is used.
+func mannWhitneyP(oldValues, newValues []float64) float64 {
+	// Pool the values, remembering which ones are new.
+	type sample struct {
+		value float64
+		isNew bool
+	}
+	pooled := make([]sample, 0, len(oldValues)+len(newValues))
+	for _, v := range oldValues {
+		pooled = append(pooled, sample{value: v})
+	}
+	for _, v := range newValues {
+		pooled = append(pooled, sample{value: v, isNew: true})
+	}
+	slices.SortFunc(pooled, func(a, b sample) int {
+		return cmp.Compare(a.value, b.value)
+	})
+
+	// Rank the pooled values, doubling the ranks so the mean rank of a tie stays an integer.
+	n := len(pooled)
+	ranks := make([]int, n)
+	observed, tieTerm := 0, 0.0
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && pooled[j].value == pooled[i].value {
+			j++
+		}
+		for k := i; k < j; k++ {
+			ranks[k] = i + 1 + j
+			if pooled[k].isNew {
+				observed += ranks[k]
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	n1, n2 := float64(len(oldValues)), float64(len(newValues))
+	if n > exactLimit {
+		// Approximate the distribution of U with a normal distribution.
+		u := float64(observed)/2 - n2*(n2+1)/2
+		mean := n1 * n2 / 2
+		variance := n1 * n2 / 12 * (float64(n+1) - tieTerm/float64(n*(n-1)))
+		if variance <= 0 {
+			return 1
+		}
+		z := (u - mean - 0.5) / math.Sqrt(variance)
+		return math.Erfc(z/math.Sqrt2) / 2
+	}
+
+	// Count the ways to pick len(newValues) of the pooled ranks for every possible sum of doubled ranks.
+	total := n * (n + 1)
+	ways := make([][]float64, len(newValues)+1)
+	for k := range ways {
+		ways[k] = make([]float64, total+1)
+	}
+	ways[0][0] = 1
+	for i, r := range ranks {
+		for k := min(i+1, len(newValues)); k > 0; k-- {
+			for s := total; s >= r; s-- {
+				ways[k][s] += ways[k-1][s-r]
+			}
+		}
+	}
+
+	// Sum the probability of the sums at least as large as the observed one.
+	var atLeast, all float64
+	for s, w := range ways[len(newValues)] {
+		all += w
+		if s >= observed {
+			atLeast += w
+		}
+	}
+	return atLeast / all
+}
+
+// minPValue returns the smallest p-value mannWhitneyP can return for samples of the provided sizes, reached when
+// every new value is larger than every old one and no values are tied.
+func minPValue(n1, n2 int) float64 {
+	// The smallest p-value is one over the number of ways to pick the new ranks, C(n1+n2, n2).
+	p := 1.0
+	for i := 1; i <= n2; i++ {
+		p *= float64(i) / float64(n1+i)
+	}
+	return p
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// oldOutput and newOutput are trimmed `go test -bench -count 4` outputs. Unique got clearly slower, Merge got
+// slightly slower, and Filter only regressed in its median while its samples overlap.
+const oldOutput = `goos: linux
+goarch: amd64
+pkg: github.com/spacemagneto/common/slice
+BenchmarkSlice/Merge/int/size=16/dup=0-8         	 1000000	       100.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Merge/int/size=16/dup=0-8         	 1000000	       102.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Merge/int/size=16/dup=0-8         	 1000000	       101.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Merge/int/size=16/dup=0-8         	 1000000	       101.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-8        	  500000	       200.0 ns/op	     128 B/op	       3 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-8        	  500000	       210.0 ns/op	     128 B/op	       3 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-8        	  500000	       205.0 ns/op	     128 B/op	       3 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-8        	  500000	       205.0 ns/op	     128 B/op	       3 allocs/op
+BenchmarkSlice/Filter/int/size=16/dup=0-8        	  500000	       100.0 ns/op
+BenchmarkSlice/Filter/int/size=16/dup=0-8        	  500000	       200.0 ns/op
+BenchmarkSlice/Filter/int/size=16/dup=0-8        	  500000	       100.0 ns/op
+BenchmarkSlice/Filter/int/size=16/dup=0-8        	  500000	       100.0 ns/op
+BenchmarkSlice/Removed/int/size=16/dup=0-8       	  500000	       100.0 ns/op
+PASS
+ok  	github.com/spacemagneto/common/slice	12.345s
+`
+
+const newOutput = `BenchmarkSlice/Merge/int/size=16/dup=0-16        	 1000000	       104.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Merge/int/size=16/dup=0-16        	 1000000	       105.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Merge/int/size=16/dup=0-16        	 1000000	       106.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Merge/int/size=16/dup=0-16        	 1000000	       105.0 ns/op	     256 B/op	       1 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-16       	  500000	       300.0 ns/op	     256 B/op	       4 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-16       	  500000	       310.0 ns/op	     256 B/op	       4 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-16       	  500000	       305.0 ns/op	     256 B/op	       4 allocs/op
+BenchmarkSlice/Unique/int/size=16/dup=0-16       	  500000	       305.0 ns/op	     256 B/op	       4 allocs/op
+BenchmarkSlice/Filter/int/size=16/dup=0-16       	  500000	       150.0 ns/op
+BenchmarkSlice/Filter/int/size=16/dup=0-16       	  500000	       150.0 ns/op
+BenchmarkSlice/Filter/int/size=16/dup=0-16       	  500000	       150.0 ns/op
+BenchmarkSlice/Filter/int/size=16/dup=0-16       	  500000	       150.0 ns/op
+BenchmarkSlice/Added/int/size=16/dup=0-16        	  500000	       100.0 ns/op
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	samples, err := Parse(strings.NewReader(oldOutput))
+	assert.NoError(t, err, "Valid benchmark output should parse")
+
+	// The names must be in input order and without the GOMAXPROCS suffix.
+	expectedNames := []string{
+		"BenchmarkSlice/Merge/int/size=16/dup=0",
+		"BenchmarkSlice/Unique/int/size=16/dup=0",
+		"BenchmarkSlice/Filter/int/size=16/dup=0",
+		"BenchmarkSlice/Removed/int/size=16/dup=0",
+	}
+	assert.Equal(t, expectedNames, samples.Names(), "Names should follow the input order")
+
+	// Every run contributes one sample per unit.
+	assert.Equal(t, []float64{100, 102, 101, 101}, samples.Values(expectedNames[0], "ns/op"), "Samples for ns/op")
+	assert.Equal(t, []float64{3, 3, 3, 3}, samples.Values(expectedNames[1], "allocs/op"), "Samples for allocs/op")
+	assert.Nil(t, samples.Values(expectedNames[2], "B/op"), "Missing units should have no samples")
+	assert.Nil(t, samples.Values("BenchmarkMissing", "ns/op"), "Missing benchmarks should have no samples")
+
+	// InvalidValue tests that a malformed measurement is reported with its line number.
+	t.Run("InvalidValue", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("PASS\nBenchmarkX-8 100 abc ns/op\n"))
+		assert.ErrorContains(t, err, "line 2", "The error should point at the malformed line")
+	})
+
+	// IgnoresOtherLines tests that log output that merely starts with Benchmark is not parsed as a result.
+	t.Run("IgnoresOtherLines", func(t *testing.T) {
+		samples, err := Parse(strings.NewReader("BenchmarkX starting up now\nBenchmarkY-4\n"))
+		assert.NoError(t, err, "Non-result lines should be skipped")
+		assert.Empty(t, samples.Names(), "No benchmark should be recorded")
+	})
+}
+
+func TestTrimProcs(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"BenchmarkX-8":              "BenchmarkX",
+		"BenchmarkX/size=16-128":    "BenchmarkX/size=16",
+		"BenchmarkX":                "BenchmarkX",
+		"BenchmarkX/case-name":      "BenchmarkX/case-name",
+		"BenchmarkX/case-name-2-12": "BenchmarkX/case-name-2",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, trimProcs(input), "trimProcs(%q)", input)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	before, err := Parse(strings.NewReader(oldOutput))
+	assert.NoError(t, err)
+	after, err := Parse(strings.NewReader(newOutput))
+	assert.NoError(t, err)
+
+	// Compare the timings with a 10% threshold.
+	comparisons := Compare(before, after, "ns/op", 10, 0.05)
+	assert.Len(t, comparisons, 3, "Only the benchmarks present in both inputs should be compared")
+
+	merge, unique, filter := comparisons[0], comparisons[1], comparisons[2]
+	assert.InDelta(t, 3.96, merge.Delta, 0.01, "Merge delta")
+	assert.False(t, merge.Regression, "A change below the threshold is not a regression")
+	assert.InDelta(t, 48.78, unique.Delta, 0.01, "Unique delta")
+	assert.InDelta(t, 1.0/70, unique.P, 1e-9, "Unique p-value")
+	assert.True(t, unique.Regression, "A consistent change above the threshold is a regression")
+	assert.Equal(t, 50.0, filter.Delta, "Filter delta")
+	assert.Greater(t, filter.P, 0.05, "Filter p-value")
+	assert.False(t, filter.Regression, "A change within the noise of the old samples is not a regression")
+
+	// A lower threshold turns the Merge slowdown into a regression.
+	assert.True(t, Compare(before, after, "ns/op", 1, 0.05)[0].Regression, "Merge should regress with a 1% threshold")
+
+	// Other units can be compared as well.
+	allocs := Compare(before, after, "allocs/op", 10, 0.05)
+	assert.Len(t, allocs, 2, "Only the benchmarks reporting allocs/op should be compared")
+	assert.True(t, allocs[1].Regression, "Unique allocates more")
+
+	// Unmatched benchmarks are reported separately.
+	onlyOld, onlyNew := Unmatched(before, after)
+	assert.Equal(t, []string{"BenchmarkSlice/Removed/int/size=16/dup=0"}, onlyOld, "Benchmarks only in the old input")
+	assert.Equal(t, []string{"BenchmarkSlice/Added/int/size=16/dup=0"}, onlyNew, "Benchmarks only in the new input")
+
+	// OutlierDoesNotMaskRegression tests that a single slow old sample does not hide a +50% median regression.
+	t.Run("OutlierDoesNotMaskRegression", func(t *testing.T) {
+		before := &Samples{values: make(map[string]map[string][]float64)}
+		after := &Samples{values: make(map[string]map[string][]float64)}
+		for _, v := range []float64{100, 101, 99, 102, 98, 400} {
+			before.add("BenchmarkX", "ns/op", v)
+		}
+		for _, v := range []float64{150, 151, 149, 152, 153, 148} {
+			after.add("BenchmarkX", "ns/op", v)
+		}
+
+		comparisons := Compare(before, after, "ns/op", 10, 0.05)
+		assert.InDelta(t, 49.75, comparisons[0].Delta, 0.01, "Delta of the medians")
+		assert.InDelta(t, 30.0/924, comparisons[0].P, 1e-9, "The outlier should only cost the rank of one old sample")
+		assert.True(t, comparisons[0].Regression, "The outlier must not mask the regression")
+	})
+
+	// TooFewSamples tests that a comparison the test cannot decide is marked, without stopping the others.
+	t.Run("TooFewSamples", func(t *testing.T) {
+		before := &Samples{values: make(map[string]map[string][]float64)}
+		after := &Samples{values: make(map[string]map[string][]float64)}
+		before.add("BenchmarkX", "ns/op", 100)
+		after.add("BenchmarkX", "ns/op", 200)
+		for _, v := range []float64{100, 101, 102, 103} {
+			before.add("BenchmarkY", "ns/op", v)
+			after.add("BenchmarkY", "ns/op", 2*v)
+		}
+
+		comparisons := Compare(before, after, "ns/op", 10, 0.05)
+		assert.Len(t, comparisons, 2, "Every benchmark should be compared")
+		assert.True(t, comparisons[0].Insufficient, "A single sample per side cannot reach 0.05")
+		assert.False(t, comparisons[0].Regression, "An undecided comparison is not a regression")
+		assert.False(t, comparisons[1].Insufficient, "Four samples per side can reach 0.05")
+		assert.True(t, comparisons[1].Regression, "The other benchmarks should still be checked")
+
+		// An alpha of 1 disables the test and only applies the threshold.
+		comparisons = Compare(before, after, "ns/op", 10, 1)
+		assert.False(t, comparisons[0].Insufficient, "Any sample count reaches an alpha of 1")
+		assert.True(t, comparisons[0].Regression, "The threshold alone should flag the slowdown")
+	})
+}
+
+func TestMannWhitneyP(t *testing.T) {
+	t.Parallel()
+
+	// Define the samples and their one-sided p-values, computed by hand from the rank sums.
+	cases := []struct {
+		name     string
+		old, new []float64
+		expected float64
+	}{
+		{name: "Separated", old: []float64{1, 2, 3}, new: []float64{4, 5, 6}, expected: 1.0 / 20},
+		{name: "Reversed", old: []float64{4, 5, 6}, new: []float64{1, 2, 3}, expected: 1},
+		{name: "One swap", old: []float64{1, 2, 4}, new: []float64{3, 5, 6}, expected: 2.0 / 20},
+		{name: "All tied", old: []float64{1, 1, 1}, new: []float64{1, 1, 1}, expected: 1},
+		{name: "Single pair", old: []float64{1}, new: []float64{2}, expected: 0.5},
+	}
+
+	// Check the exact p-value of every case.
+	for _, tt := range cases {
+		assert.InDelta(t, tt.expected, mannWhitneyP(tt.old, tt.new), 1e-9, "P-value for case %q", tt.name)
+	}
+
+	// Large tests that the normal approximation agrees with the separation of large samples.
+	t.Run("Large", func(t *testing.T) {
+		oldValues, newValues := make([]float64, 40), make([]float64, 40)
+		for i := range oldValues {
+			oldValues[i], newValues[i] = float64(i), float64(i+20)
+		}
+		assert.Less(t, mannWhitneyP(oldValues, newValues), 0.001, "A shifted sample should be significant")
+		assert.Greater(t, mannWhitneyP(newValues, oldValues), 0.999, "A faster sample should not be significant")
+		assert.InDelta(t, 0.5, mannWhitneyP(oldValues, oldValues), 0.05, "Equal samples should be centered")
+	})
+
+	// MinPValue tests that the smallest p-value matches the fully separated samples.
+	t.Run("MinPValue", func(t *testing.T) {
+		assert.InDelta(t, 1.0/20, minPValue(3, 3), 1e-12, "minPValue(3, 3)")
+		assert.InDelta(t, 1.0/70, minPValue(4, 4), 1e-12, "minPValue(4, 4)")
+		assert.InDelta(t, 0.5, minPValue(1, 1), 1e-12, "minPValue(1, 1)")
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old.txt"), filepath.Join(dir, "new.txt")
+	assert.NoError(t, os.WriteFile(oldPath, []byte(oldOutput), 0o600))
+	assert.NoError(t, os.WriteFile(newPath, []byte(newOutput), 0o600))
+	singlePath := filepath.Join(dir, "single.txt")
+	assert.NoError(t, os.WriteFile(singlePath, []byte("BenchmarkSlice/Unique/int/size=16/dup=0-8 500000 400.0 ns/op\n"), 0o600))
+
+	// Define the command lines and their expected exit status.
+	cases := []struct {
+		name     string
+		args     []string
+		expected int
+		output   string
+	}{
+		{name: "Regression", args: []string{oldPath, newPath}, expected: 1, output: "1 of 3 benchmarks regressed"},
+		{name: "High threshold", args: []string{"-threshold", "60", oldPath, newPath}, expected: 0, output: "only in new"},
+		{name: "Improvement", args: []string{newPath, oldPath}, expected: 0, output: "-32.79%"},
+		{name: "Quiet", args: []string{"-quiet", oldPath, newPath}, expected: 1, output: "(regression)"},
+		{name: "Too few samples", args: []string{oldPath, singlePath}, expected: 0, output: "insufficient samples"},
+		{name: "Threshold only", args: []string{"-alpha", "1", oldPath, singlePath}, expected: 1, output: "(regression)"},
+		{name: "Missing argument", args: []string{oldPath}, expected: 2},
+		{name: "Missing file", args: []string{oldPath, filepath.Join(dir, "missing.txt")}, expected: 2},
+		{name: "Unknown flag", args: []string{"-unknown", oldPath, newPath}, expected: 2},
+	}
+
+	// Run the command for every case and check its exit status and output.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			assert.Equal(t, tt.expected, run(tt.args, &stdout, &stderr), "Exit status for case %q, stderr: %s", tt.name, stderr.String())
+			assert.Contains(t, stdout.String(), tt.output, "Output for case %q", tt.name)
+		})
+	}
+
+	// QuietHidesUnchanged tests that -quiet only lists the regressions.
+	t.Run("QuietHidesUnchanged", func(t *testing.T) {
+		var stdout bytes.Buffer
+		run([]string{"-quiet", oldPath, newPath}, &stdout, &bytes.Buffer{})
+		assert.NotContains(t, stdout.String(), "Merge", "Benchmarks within the threshold should be hidden")
+	})
+}
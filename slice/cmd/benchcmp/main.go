@@ -0,0 +1,154 @@
+// Command benchcmp compares two files holding the output of `go test -bench` and fails when a benchmark got
+// slower by more than a configurable threshold. It is meant to guard the slice package against performance
+// regressions in CI:
+//
+//	go test -run '^$' -bench . -benchtime 100ms -count 6 ./... > old.txt
+//	# apply the change
+//	go test -run '^$' -bench . -benchtime 100ms -count 6 ./... > new.txt
+//	go run ./cmd/benchcmp -threshold 10 old.txt new.txt
+//
+// Like benchstat, it reports the median of every benchmark, its relative change and the p-value of a Mann-Whitney
+// U test. A benchmark is a regression when its median grew by more than the threshold and the p-value is below
+// -alpha, so a single outlier on either side neither hides nor fakes a regression. The test needs enough samples
+// to reach -alpha: with the default of 0.05, run the benchmarks with -count 4 or more, or pass -alpha 1 to only
+// apply the threshold. Benchmarks with fewer samples are reported as "insufficient samples" and never fail the
+// check. The exit status is 0 without regressions, 1 with at least one regression, and 2 on invalid usage or
+// input.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"text/tabwriter"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command with the provided arguments and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("benchcmp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	threshold := flags.Float64("threshold", 10, "maximum allowed slowdown in `percent` before failing")
+	alpha := flags.Float64("alpha", 0.05, "significance `level` below which a slowdown is not considered noise")
+	unit := flags.String("unit", "ns/op", "measurement `unit` to compare, such as ns/op, B/op or allocs/op")
+	quiet := flags.Bool("quiet", false, "only print the regressions")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: benchcmp [flags] old.txt new.txt")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	// Read both inputs.
+	before, err := parseFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, "benchcmp:", err)
+		return 2
+	}
+	after, err := parseFile(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, "benchcmp:", err)
+		return 2
+	}
+
+	// Print the comparison table, marking every regression.
+	comparisons := Compare(before, after, *unit, *threshold, *alpha)
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "name\told %s\tnew %s\tdelta\tp\n", *unit, *unit)
+	regressions, insufficient := 0, 0
+	for _, c := range comparisons {
+		switch {
+		case c.Regression:
+			regressions++
+		case c.Insufficient:
+			insufficient++
+		case *quiet:
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Name, formatValue(c.Old), formatValue(c.New), formatDelta(c), formatP(c))
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(stderr, "benchcmp:", err)
+		return 2
+	}
+
+	// Mention the benchmarks that cannot be compared, since a renamed benchmark silently escapes the check.
+	onlyOld, onlyNew := Unmatched(before, after)
+	for _, name := range onlyOld {
+		fmt.Fprintf(stdout, "only in old: %s\n", name)
+	}
+	for _, name := range onlyNew {
+		fmt.Fprintf(stdout, "only in new: %s\n", name)
+	}
+
+	// Point at the benchmarks that need more runs, since they cannot fail the check.
+	if insufficient > 0 {
+		fmt.Fprintf(stdout, "%d of %d benchmarks have too few samples for -alpha %g; rerun them with a higher -count\n", insufficient, len(comparisons), *alpha)
+	}
+
+	// Fail when at least one benchmark regressed.
+	if regressions > 0 {
+		fmt.Fprintf(stdout, "%d of %d benchmarks regressed by more than %g%% in %s\n", regressions, len(comparisons), *threshold, *unit)
+		return 1
+	}
+	return 0
+}
+
+// parseFile parses the benchmark output stored in the named file.
+func parseFile(path string) (*Samples, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	samples, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return samples, nil
+}
+
+// formatValue prints a measurement with fewer decimals as it grows, like the testing package does.
+func formatValue(v float64) string {
+	switch {
+	case v >= 100:
+		return fmt.Sprintf("%.0f", v)
+	case v >= 10:
+		return fmt.Sprintf("%.1f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
+
+// formatDelta prints the relative change, with a marker for regressions and "~" for no change.
+func formatDelta(c Comparison) string {
+	switch {
+	case c.Delta == 0:
+		return "~"
+	case math.IsInf(c.Delta, 1):
+		return "+inf%"
+	case c.Regression:
+		return fmt.Sprintf("%+.2f%% (regression)", c.Delta)
+	default:
+		return fmt.Sprintf("%+.2f%%", c.Delta)
+	}
+}
+
+// formatP prints the p-value of the comparison, or why there is none.
+func formatP(c Comparison) string {
+	if c.Insufficient {
+		return "insufficient samples"
+	}
+	return fmt.Sprintf("%.3f", c.P)
+}
@@ -0,0 +1,250 @@
+package slice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EditKind identifies the operation of an Edit.
+type EditKind int
+
+const (
+	// EditEqual keeps an element that is present in both slices.
+	EditEqual EditKind = iota
+	// EditDelete removes an element of the first slice.
+	EditDelete
+	// EditInsert adds an element of the second slice.
+	EditInsert
+)
+
+// String returns the lower-case name of the operation.
+func (k EditKind) String() string {
+	switch k {
+	case EditEqual:
+		return "equal"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	default:
+		return fmt.Sprintf("EditKind(%d)", int(k))
+	}
+}
+
+// Edit is one operation of an edit script that turns a first slice a into a second slice b.
+type Edit[T any] struct {
+	// Kind is the operation.
+	Kind EditKind
+	// AIndex is the index of the element in a for EditEqual and EditDelete. For EditInsert it is the position
+	// in a before which the element is inserted, which is len(a) for insertions at the end.
+	AIndex int
+	// BIndex is the index of the element in b for EditEqual and EditInsert. For EditDelete it is the position
+	// in b at which the element would have been, so the indices of a script never go backwards.
+	BIndex int
+	// Value is the element, taken from a for EditEqual and EditDelete and from b for EditInsert.
+	Value T
+}
+
+// Diff returns the shortest edit script that turns a into b, using the Myers algorithm.
+// Applying the script in order keeps the EditEqual elements, drops the EditDelete elements and adds the
+// EditInsert elements. Within a block of changes the deletions come before the insertions, like in a
+// unified diff. It runs in O((N+M)·D) time and O(N+M+D²) memory, where D is the number of deleted and inserted
+// elements, so it is fast for similar inputs. It returns nil when both slices are empty.
+func Diff[T comparable](a, b []T) []Edit[T] {
+	return DiffFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// DiffFunc is the variant of Diff for element types that are not comparable or need a custom equality.
+// For EditEqual operations the value is taken from a.
+func DiffFunc[T any](a, b []T, eq func(x, y T) bool) []Edit[T] {
+	// Strip the common prefix and suffix, which are equal operations that need no search.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && eq(a[prefix], b[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && eq(a[len(a)-1-suffix], b[len(b)-1-suffix]) {
+		suffix++
+	}
+
+	var edits []Edit[T]
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, Edit[T]{Kind: EditEqual, AIndex: i, BIndex: i, Value: a[i]})
+	}
+
+	// Search the shortest edit script of the differing middle parts.
+	edits = myers(edits, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix, prefix, eq)
+
+	for i := 0; i < suffix; i++ {
+		ai, bi := len(a)-suffix+i, len(b)-suffix+i
+		edits = append(edits, Edit[T]{Kind: EditEqual, AIndex: ai, BIndex: bi, Value: a[ai]})
+	}
+	return edits
+}
+
+// LCS returns a longest common subsequence of a and b: the longest sequence of elements that appears in both
+// slices in the same relative order, though not necessarily contiguously. It returns nil when the slices have
+// no element in common.
+func LCS[T comparable](a, b []T) []T {
+	return LCSFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// LCSFunc is the variant of LCS that uses a custom equality. The elements are taken from a.
+func LCSFunc[T any](a, b []T, eq func(x, y T) bool) []T {
+	var result []T
+
+	// The kept elements of the shortest edit script form a longest common subsequence.
+	for _, e := range DiffFunc(a, b, eq) {
+		if e.Kind == EditEqual {
+			result = append(result, e.Value)
+		}
+	}
+	return result
+}
+
+// Unified renders an edit script as a unified diff, as produced by `diff -u`, with one element per line.
+// Every hunk shows up to context unchanged elements around the changes, and hunks closer than twice the context
+// are merged. Elements are formatted with format, or with fmt.Sprint when format is nil. It returns an empty
+// string when the script contains no change.
+func Unified[T any](edits []Edit[T], fromName, toName string, context int, format func(T) string) string {
+	if format == nil {
+		format = func(v T) string { return fmt.Sprint(v) }
+	}
+	context = max(context, 0)
+
+	// Find the hunks as ranges of edits, merging the ones whose context would overlap or touch.
+	type hunk struct{ start, end int }
+	var hunks []hunk
+	for i, e := range edits {
+		if e.Kind == EditEqual {
+			continue
+		}
+		start, end := max(0, i-context), min(len(edits), i+context+1)
+		if n := len(hunks); n > 0 && start <= hunks[n-1].end {
+			hunks[n-1].end = end
+		} else {
+			hunks = append(hunks, hunk{start: start, end: end})
+		}
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks {
+		// Count the lines of both sides covered by the hunk.
+		aCount, bCount := 0, 0
+		for _, e := range edits[h.start:h.end] {
+			if e.Kind != EditInsert {
+				aCount++
+			}
+			if e.Kind != EditDelete {
+				bCount++
+			}
+		}
+		first := edits[h.start]
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", unifiedRange(first.AIndex, aCount), unifiedRange(first.BIndex, bCount))
+
+		// Write every line with its marker.
+		for _, e := range edits[h.start:h.end] {
+			marker := " "
+			switch e.Kind {
+			case EditDelete:
+				marker = "-"
+			case EditInsert:
+				marker = "+"
+			}
+			sb.WriteString(marker)
+			sb.WriteString(format(e.Value))
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// unifiedRange formats the range of a hunk header from a zero-based start and a line count. Like GNU diff, it
+// uses one-based line numbers, omits a count of one, and names the line before the position for empty ranges.
+func unifiedRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+// myers appends the shortest edit script turning a into b to edits. The offsets are added to the indices, so
+// the script refers to positions in the slices a and b were cut from.
+func myers[T any](edits []Edit[T], a, b []T, aOffset, bOffset int, eq func(x, y T) bool) []Edit[T] {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return edits
+	}
+
+	// v[offset+k] holds the furthest x reached on diagonal k = x - y. Before round d, trace keeps a copy of the
+	// diagonals -d-1 to d+1, the only ones round d reads, which the backtracking needs to recover the path.
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+search:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			// Step down from diagonal k+1 (an insertion) or right from diagonal k-1 (a deletion).
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+
+			// Follow the diagonal of equal elements as far as possible.
+			for x < n && y < m && eq(a[x], b[y]) {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the path back from the end, collecting the operations in reverse order.
+	start := len(edits)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		vd, local := trace[d], d+1
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && vd[local+k-1] < vd[local+k+1]) {
+			prevK = k + 1
+		}
+		prevX := vd[local+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, Edit[T]{Kind: EditEqual, AIndex: aOffset + x, BIndex: bOffset + y, Value: a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			edits = append(edits, Edit[T]{Kind: EditInsert, AIndex: aOffset + x, BIndex: bOffset + prevY, Value: b[prevY]})
+		} else {
+			edits = append(edits, Edit[T]{Kind: EditDelete, AIndex: aOffset + prevX, BIndex: bOffset + y, Value: a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+
+	// Restore the forward order of the operations appended by the backtracking.
+	ReverseInPlace(edits[start:])
+	return edits
+}
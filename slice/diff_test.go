@@ -0,0 +1,207 @@
+package slice
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spacemagneto/common/slice/proptest"
+	"github.com/stretchr/testify/assert"
+)
+
+// applyEdits rebuilds both sides of an edit script, which must give back the inputs of the diff.
+func applyEdits[T any](edits []Edit[T]) (a, b []T) {
+	for _, e := range edits {
+		if e.Kind != EditInsert {
+			a = append(a, e.Value)
+		}
+		if e.Kind != EditDelete {
+			b = append(b, e.Value)
+		}
+	}
+	return a, b
+}
+
+// renderEdits writes an edit script in a compact form such as "=a -b +c", which keeps the table tests short.
+func renderEdits(edits []Edit[string]) string {
+	markers := map[EditKind]string{EditEqual: "=", EditDelete: "-", EditInsert: "+"}
+	return strings.Join(Map(edits, func(e Edit[string]) string { return markers[e.Kind] + e.Value }), " ")
+}
+
+// lcsLength computes the length of a longest common subsequence by dynamic programming, as a reference for
+// the minimality of Diff.
+func lcsLength(a, b []int) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	return table[0][0]
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases with the expected edit script in compact form.
+	cases := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{name: "Both empty", a: "", b: "", expected: ""},
+		{name: "Equal", a: "abc", b: "abc", expected: "=a =b =c"},
+		{name: "Insert only", a: "", b: "ab", expected: "+a +b"},
+		{name: "Delete only", a: "ab", b: "", expected: "-a -b"},
+		{name: "Insert in the middle", a: "ac", b: "abc", expected: "=a +b =c"},
+		{name: "Replace", a: "abc", b: "axc", expected: "=a -b +x =c"},
+		{name: "Replace block", a: "abcd", b: "axyd", expected: "=a -b -c +x +y =d"},
+		{name: "Classic Myers example", a: "abcabba", b: "cbabac", expected: "-a -b =c +b =a =b -b =a +c"},
+	}
+
+	// Iterate over the test cases, checking the script and that it rebuilds both inputs.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := strings.Split(tt.a, ""), strings.Split(tt.b, "")
+			edits := Diff(a, b)
+			assert.Equal(t, tt.expected, renderEdits(edits), "Edit script for case %q", tt.name)
+
+			rebuiltA, rebuiltB := applyEdits(edits)
+			assert.Equal(t, tt.a, strings.Join(rebuiltA, ""), "Rebuilt first input for case %q", tt.name)
+			assert.Equal(t, tt.b, strings.Join(rebuiltB, ""), "Rebuilt second input for case %q", tt.name)
+		})
+	}
+
+	// Indices tests that every operation refers to the right positions of both inputs.
+	t.Run("Indices", func(t *testing.T) {
+		edits := Diff([]string{"a", "b", "c"}, []string{"x", "a", "c", "y"})
+		expected := []Edit[string]{
+			{Kind: EditInsert, AIndex: 0, BIndex: 0, Value: "x"},
+			{Kind: EditEqual, AIndex: 0, BIndex: 1, Value: "a"},
+			{Kind: EditDelete, AIndex: 1, BIndex: 2, Value: "b"},
+			{Kind: EditEqual, AIndex: 2, BIndex: 2, Value: "c"},
+			{Kind: EditInsert, AIndex: 3, BIndex: 3, Value: "y"},
+		}
+		assert.Equal(t, expected, edits, "Every edit should carry the indices of both inputs")
+	})
+
+	// KindString tests the names of the operations.
+	t.Run("KindString", func(t *testing.T) {
+		names := Map([]EditKind{EditEqual, EditDelete, EditInsert, EditKind(7)}, EditKind.String)
+		assert.Equal(t, []string{"equal", "delete", "insert", "EditKind(7)"}, names, "Operation names")
+	})
+
+	// Laws tests that the script is valid and minimal on generated inputs.
+	t.Run("Laws", func(t *testing.T) {
+		proptest.Check(t, lawInts, func(e []int) error {
+			a, b := halves(e)
+			edits := Diff(a, b)
+
+			rebuiltA, rebuiltB := applyEdits(edits)
+			if !slices.Equal(rebuiltA, a) || !slices.Equal(rebuiltB, b) {
+				return fmt.Errorf("script %v does not rebuild %v and %v", edits, a, b)
+			}
+
+			// A shortest script keeps exactly a longest common subsequence.
+			if kept := len(LCS(a, b)); kept != lcsLength(a, b) {
+				return fmt.Errorf("kept %d elements, the longest common subsequence has %d", kept, lcsLength(a, b))
+			}
+
+			// The indices must match the elements and never go backwards.
+			ai, bi := 0, 0
+			for _, edit := range edits {
+				if edit.AIndex != ai || edit.BIndex != bi {
+					return fmt.Errorf("edit %+v expected at indices %d, %d", edit, ai, bi)
+				}
+				if edit.Kind != EditInsert {
+					ai++
+				}
+				if edit.Kind != EditDelete {
+					bi++
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func TestDiffFunc(t *testing.T) {
+	t.Parallel()
+
+	// Compare case-insensitively; equal elements are taken from the first slice.
+	edits := DiffFunc([]string{"Alpha", "beta", "gamma"}, []string{"ALPHA", "delta", "GAMMA"}, strings.EqualFold)
+	assert.Equal(t, "=Alpha -beta +delta =gamma", renderEdits(edits), "Custom equality should be used")
+
+	// Non-comparable elements can be diffed as well.
+	a := [][]int{{1}, {2}, {3}}
+	b := [][]int{{1}, {3}}
+	sliceEdits := DiffFunc(a, b, slices.Equal[[]int])
+	assert.Equal(t, []EditKind{EditEqual, EditDelete, EditEqual}, Map(sliceEdits, func(e Edit[[]int]) EditKind { return e.Kind }), "Slices of slices")
+}
+
+func TestLCS(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"c", "a", "b", "a"}, LCS(strings.Split("abcabba", ""), strings.Split("cbabac", "")), "Classic example")
+	assert.Nil(t, LCS([]int{1, 2}, []int{3, 4}), "No common element")
+	assert.Nil(t, LCS[int](nil, nil), "Empty inputs")
+	assert.Equal(t, []string{"X", "y"}, LCSFunc([]string{"X", "y", "z"}, []string{"x", "Y"}, strings.EqualFold), "Custom equality")
+}
+
+func TestUnified(t *testing.T) {
+	t.Parallel()
+
+	lines := func(s string) []string { return strings.Split(s, " ") }
+
+	// Define test cases with the expected text.
+	cases := []struct {
+		name     string
+		a, b     []string
+		context  int
+		expected string
+	}{
+		{name: "No changes", a: lines("a b"), b: lines("a b"), context: 3, expected: ""},
+		{
+			name: "Single change with context", a: lines("1 2 3 4 5 6 7"), b: lines("1 2 3 x 5 6 7"), context: 1,
+			expected: "--- old\n+++ new\n@@ -3,3 +3,3 @@\n 3\n-4\n+x\n 5\n",
+		},
+		{
+			name: "Separate hunks", a: lines("1 2 3 4 5 6 7 8"), b: lines("x 2 3 4 5 6 7 y"), context: 1,
+			expected: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n-1\n+x\n 2\n@@ -7,2 +7,2 @@\n 7\n-8\n+y\n",
+		},
+		{
+			name: "Merged hunks", a: lines("1 2 3 4"), b: lines("x 2 3 y"), context: 1,
+			expected: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n-4\n+y\n",
+		},
+		{
+			name: "Pure insertion without context", a: lines("1 2"), b: lines("1 x 2"), context: 0,
+			expected: "--- old\n+++ new\n@@ -1,0 +2 @@\n+x\n",
+		},
+		{
+			name: "Everything deleted", a: lines("1 2"), b: nil, context: 3,
+			expected: "--- old\n+++ new\n@@ -1,2 +0,0 @@\n-1\n-2\n",
+		},
+	}
+
+	// Iterate over the test cases, rendering the diff of both inputs.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Unified(Diff(tt.a, tt.b), "old", "new", tt.context, nil)
+			assert.Equal(t, tt.expected, result, "Unified diff for case %q", tt.name)
+		})
+	}
+
+	// Format tests that a custom formatter is applied to every line.
+	t.Run("Format", func(t *testing.T) {
+		result := Unified(Diff([]int{1, 2}, []int{1, 3}), "a", "b", 0, func(v int) string { return "#" + string(rune('0'+v)) })
+		assert.Equal(t, "--- a\n+++ b\n@@ -2 +2 @@\n-#2\n+#3\n", result, "Formatted lines")
+	})
+}
@@ -0,0 +1,183 @@
+package slice
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorPolicy controls how the error-returning helpers react when the callback fails for an element.
+// The helpers panic when given a value other than StopOnError and CollectErrors.
+type ErrorPolicy int
+
+const (
+	// StopOnError aborts the operation at the first failing element and returns its error.
+	StopOnError ErrorPolicy = iota
+	// CollectErrors processes every element and returns all failures joined into a single error.
+	CollectErrors
+)
+
+// validate panics when the policy is not one of the declared constants, so that a policy converted from an
+// unchecked integer does not silently behave as CollectErrors.
+func (p ErrorPolicy) validate() {
+	if p != StopOnError && p != CollectErrors {
+		panic(fmt.Sprintf("slice: unknown ErrorPolicy %d", int(p)))
+	}
+}
+
+// IndexError records the failure of a callback for the element at a specific position of the input slice.
+// Every error returned by MapErr, FilterErr, ReduceErr and ForEachErr is either an *IndexError or,
+// under the CollectErrors policy, an errors.Join of *IndexError values ordered by index.
+// KeyBy also reports the position of a rejected duplicate key as an *IndexError.
+type IndexError struct {
+	// Index is the position of the failing element in the input slice.
+	Index int
+	// Err is the error returned by the callback.
+	Err error
+}
+
+// Error returns the callback error prefixed with the position of the failing element.
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the callback error, so errors.Is and errors.As can inspect it.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// MapErr applies a fallible transformation function to each element of a slice.
+// Under StopOnError it returns a nil slice and the first failure as soon as it happens.
+// Under CollectErrors it transforms every element and returns a slice with the same length as the input,
+// holding the zero value at every failing position, together with all failures joined into one error.
+func MapErr[A, B any](elements []A, policy ErrorPolicy, fn func(A) (B, error)) ([]B, error) {
+	// Reject unknown policies before calling the function for any element.
+	policy.validate()
+
+	// Create a slice of type B with the same length as the input slice, exactly like Map.
+	result := make([]B, len(elements))
+
+	// Gather the failures reported under the CollectErrors policy.
+	var errs []error
+
+	// Iterate over the input slice to transform each element.
+	for i, v := range elements {
+		// Apply the transformation function to the current element.
+		transformed, err := fn(v)
+		if err != nil {
+			// Stop immediately under the StopOnError policy and discard the partial result.
+			if policy == StopOnError {
+				return nil, &IndexError{Index: i, Err: err}
+			}
+
+			// Otherwise remember the failure and leave the zero value at the failing position.
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+
+		// Store the transformed element at the position of the source element.
+		result[i] = transformed
+	}
+
+	// Return the transformed elements together with the joined failures, if any.
+	return result, errors.Join(errs...)
+}
+
+// FilterErr filters a slice of elements based on a fallible predicate function.
+// Under StopOnError it returns a nil slice and the first failure as soon as it happens.
+// Under CollectErrors it evaluates the predicate for every element, treats failing elements as not matching,
+// and returns the matching elements together with all failures joined into one error.
+func FilterErr[T any](elements []T, policy ErrorPolicy, fn func(T) (bool, error)) ([]T, error) {
+	// Reject unknown policies before calling the function for any element.
+	policy.validate()
+
+	var result []T
+
+	// Gather the failures reported under the CollectErrors policy.
+	var errs []error
+
+	// Iterate over each element in the input slice and evaluate the predicate.
+	for i, v := range elements {
+		ok, err := fn(v)
+		if err != nil {
+			// Stop immediately under the StopOnError policy and discard the partial result.
+			if policy == StopOnError {
+				return nil, &IndexError{Index: i, Err: err}
+			}
+
+			// Otherwise remember the failure and leave the element out of the result.
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+
+		// Append the element to the result when it satisfies the predicate.
+		if ok {
+			result = append(result, v)
+		}
+	}
+
+	// Return the matching elements together with the joined failures, if any.
+	return result, errors.Join(errs...)
+}
+
+// ReduceErr folds a slice into a single value using a fallible accumulator function, starting from initial.
+// Under StopOnError it returns the accumulator reached before the failing element together with the failure.
+// Under CollectErrors a failing element leaves the accumulator unchanged, the fold continues with the next
+// element, and all failures are returned joined into one error.
+func ReduceErr[T, R any](elements []T, initial R, policy ErrorPolicy, fn func(R, T) (R, error)) (R, error) {
+	// Reject unknown policies before calling the function for any element.
+	policy.validate()
+
+	// Start the fold from the provided initial value.
+	acc := initial
+
+	// Gather the failures reported under the CollectErrors policy.
+	var errs []error
+
+	// Iterate over each element in the input slice and fold it into the accumulator.
+	for i, v := range elements {
+		next, err := fn(acc, v)
+		if err != nil {
+			// Stop immediately under the StopOnError policy, keeping the last successful accumulator.
+			if policy == StopOnError {
+				return acc, &IndexError{Index: i, Err: err}
+			}
+
+			// Otherwise remember the failure and skip the element.
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+
+		// Advance the accumulator with the successful result.
+		acc = next
+	}
+
+	// Return the final accumulator together with the joined failures, if any.
+	return acc, errors.Join(errs...)
+}
+
+// ForEachErr calls a fallible function for each element of a slice, in order.
+// Under StopOnError it stops at the first failure and returns it.
+// Under CollectErrors it calls the function for every element and returns all failures joined into one error.
+func ForEachErr[T any](elements []T, policy ErrorPolicy, fn func(T) error) error {
+	// Reject unknown policies before calling the function for any element.
+	policy.validate()
+
+	// Gather the failures reported under the CollectErrors policy.
+	var errs []error
+
+	// Iterate over each element in the input slice and call the function.
+	for i, v := range elements {
+		if err := fn(v); err != nil {
+			// Stop immediately under the StopOnError policy.
+			if policy == StopOnError {
+				return &IndexError{Index: i, Err: err}
+			}
+
+			// Otherwise remember the failure and continue with the next element.
+			errs = append(errs, &IndexError{Index: i, Err: err})
+		}
+	}
+
+	// Return the joined failures, or nil when every call succeeded.
+	return errors.Join(errs...)
+}
@@ -0,0 +1,245 @@
+package slice
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// errNegative is the sentinel error returned by the test callbacks for negative numbers.
+var errNegative = errors.New("negative number")
+
+func TestIndexError(t *testing.T) {
+	t.Parallel()
+
+	// Wrap the sentinel error with a position.
+	err := &IndexError{Index: 3, Err: errNegative}
+
+	// The message must report the position, and the sentinel must remain reachable through unwrapping.
+	assert.EqualError(t, err, "index 3: negative number", "IndexError should prefix the position")
+	assert.ErrorIs(t, err, errNegative, "IndexError should unwrap to the callback error")
+}
+
+func TestMapErr(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases covering both policies. The transformation parses strings into integers,
+	// which fails for every input that is not a number.
+	cases := []struct {
+		name        string
+		elements    []string
+		policy      ErrorPolicy
+		expected    []int
+		expectedErr string
+	}{
+		{name: "Empty slice", elements: []string{}, policy: StopOnError, expected: []int{}},
+		{name: "All succeed", elements: []string{"1", "2", "3"}, policy: StopOnError, expected: []int{1, 2, 3}},
+		{name: "Stop at first failure", elements: []string{"1", "x", "3", "y"}, policy: StopOnError, expected: nil, expectedErr: `index 1: strconv.Atoi: parsing "x": invalid syntax`},
+		{name: "Collect every failure", elements: []string{"1", "x", "3", "y"}, policy: CollectErrors, expected: []int{1, 0, 3, 0}, expectedErr: "index 1: strconv.Atoi: parsing \"x\": invalid syntax\nindex 3: strconv.Atoi: parsing \"y\": invalid syntax"},
+		{name: "Collect without failures", elements: []string{"4", "5"}, policy: CollectErrors, expected: []int{4, 5}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute the MapErr function with the parsing transformation.
+			result, err := MapErr(tt.elements, tt.policy, strconv.Atoi)
+
+			// Verify the transformed elements.
+			assert.Equal(t, tt.expected, result, "MapErr output should match expected value for test case: %s", tt.name)
+
+			// Verify the reported failures, if any are expected.
+			if tt.expectedErr == "" {
+				assert.NoError(t, err, "MapErr should not fail for test case: %s", tt.name)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr, "MapErr error should match for test case: %s", tt.name)
+		})
+	}
+
+	// StopsCallingAfterFailure tests that no element after the failing one is transformed under StopOnError.
+	t.Run("StopsCallingAfterFailure", func(t *testing.T) {
+		// Record every element handed to the transformation.
+		var seen []int
+		_, err := MapErr([]int{1, -2, 3}, StopOnError, func(n int) (int, error) {
+			seen = append(seen, n)
+			if n < 0 {
+				return 0, errNegative
+			}
+			return n, nil
+		})
+
+		// The failure must be reported with its position and no later element must be visited.
+		var indexErr *IndexError
+		assert.ErrorAs(t, err, &indexErr, "MapErr should return an IndexError")
+		assert.Equal(t, 1, indexErr.Index, "MapErr should report the failing position")
+		assert.Equal(t, []int{1, -2}, seen, "MapErr should stop at the first failure")
+	})
+}
+
+func TestFilterErr(t *testing.T) {
+	t.Parallel()
+
+	// isEven reports whether a number is even and fails for negative numbers.
+	isEven := func(n int) (bool, error) {
+		if n < 0 {
+			return false, errNegative
+		}
+		return n%2 == 0, nil
+	}
+
+	// Define test cases covering both policies.
+	cases := []struct {
+		name          string
+		elements      []int
+		policy        ErrorPolicy
+		expected      []int
+		expectedIndex []int
+	}{
+		{name: "Empty slice", elements: []int{}, policy: StopOnError, expected: nil},
+		{name: "All succeed", elements: []int{1, 2, 3, 4}, policy: StopOnError, expected: []int{2, 4}},
+		{name: "Stop at first failure", elements: []int{2, -1, 4, -3}, policy: StopOnError, expected: nil, expectedIndex: []int{1}},
+		{name: "Collect every failure", elements: []int{2, -1, 4, -3}, policy: CollectErrors, expected: []int{2, 4}, expectedIndex: []int{1, 3}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute the FilterErr function with the fallible predicate.
+			result, err := FilterErr(tt.elements, tt.policy, isEven)
+
+			// Verify the matching elements and the failing positions.
+			assert.Equal(t, tt.expected, result, "For case '%s', expected %v but got %v", tt.name, tt.expected, result)
+			assert.Equal(t, tt.expectedIndex, failingIndices(err), "Failing positions should match for case: %s", tt.name)
+		})
+	}
+}
+
+func TestReduceErr(t *testing.T) {
+	t.Parallel()
+
+	// sum adds a number to the accumulator and fails for negative numbers.
+	sum := func(acc, n int) (int, error) {
+		if n < 0 {
+			return acc, fmt.Errorf("cannot add %d: %w", n, errNegative)
+		}
+		return acc + n, nil
+	}
+
+	// Define test cases covering both policies.
+	cases := []struct {
+		name          string
+		elements      []int
+		policy        ErrorPolicy
+		expected      int
+		expectedIndex []int
+	}{
+		{name: "Empty slice keeps the initial value", elements: nil, policy: StopOnError, expected: 10},
+		{name: "All succeed", elements: []int{1, 2, 3}, policy: StopOnError, expected: 16},
+		{name: "Stop keeps the last accumulator", elements: []int{1, 2, -3, 4}, policy: StopOnError, expected: 13, expectedIndex: []int{2}},
+		{name: "Collect skips failing elements", elements: []int{1, 2, -3, 4, -5}, policy: CollectErrors, expected: 17, expectedIndex: []int{2, 4}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Fold the elements starting from ten.
+			result, err := ReduceErr(tt.elements, 10, tt.policy, sum)
+
+			// Verify the final accumulator and the failing positions.
+			assert.Equal(t, tt.expected, result, "ReduceErr result should match for case: %s", tt.name)
+			assert.Equal(t, tt.expectedIndex, failingIndices(err), "Failing positions should match for case: %s", tt.name)
+
+			// The sentinel error must remain reachable through the joined error.
+			if tt.expectedIndex != nil {
+				assert.ErrorIs(t, err, errNegative, "ReduceErr should keep the callback error for case: %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestForEachErr(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases covering both policies.
+	cases := []struct {
+		name          string
+		elements      []int
+		policy        ErrorPolicy
+		expectedSeen  []int
+		expectedIndex []int
+	}{
+		{name: "Nil slice", elements: nil, policy: CollectErrors, expectedSeen: nil},
+		{name: "All succeed", elements: []int{1, 2}, policy: StopOnError, expectedSeen: []int{1, 2}},
+		{name: "Stop at first failure", elements: []int{1, -2, 3, -4}, policy: StopOnError, expectedSeen: []int{1, -2}, expectedIndex: []int{1}},
+		{name: "Collect every failure", elements: []int{1, -2, 3, -4}, policy: CollectErrors, expectedSeen: []int{1, -2, 3, -4}, expectedIndex: []int{1, 3}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Record every element handed to the function.
+			var seen []int
+			err := ForEachErr(tt.elements, tt.policy, func(n int) error {
+				seen = append(seen, n)
+				if n < 0 {
+					return errNegative
+				}
+				return nil
+			})
+
+			// Verify the visited elements and the failing positions.
+			assert.Equal(t, tt.expectedSeen, seen, "Visited elements should match for case: %s", tt.name)
+			assert.Equal(t, tt.expectedIndex, failingIndices(err), "Failing positions should match for case: %s", tt.name)
+		})
+	}
+}
+
+func TestUnknownErrorPolicy(t *testing.T) {
+	t.Parallel()
+
+	// Every helper must reject a policy outside the declared constants before calling the function.
+	policy := ErrorPolicy(7)
+	fail := func(int) error { return errNegative }
+	message := "slice: unknown ErrorPolicy 7"
+	assert.PanicsWithValue(t, message, func() {
+		_, _ = MapErr([]int{1}, policy, func(n int) (int, error) { return n, fail(n) })
+	}, "MapErr should panic on an unknown policy")
+	assert.PanicsWithValue(t, message, func() {
+		_, _ = FilterErr([]int{1}, policy, func(n int) (bool, error) { return true, fail(n) })
+	}, "FilterErr should panic on an unknown policy")
+	assert.PanicsWithValue(t, message, func() {
+		_, _ = ReduceErr([]int{1}, 0, policy, func(acc, n int) (int, error) { return acc + n, fail(n) })
+	}, "ReduceErr should panic on an unknown policy")
+	assert.PanicsWithValue(t, message, func() { _ = ForEachErr(nil, policy, fail) }, "ForEachErr should panic on an unknown policy")
+}
+
+// failingIndices extracts the positions reported by an error returned from the error-returning helpers.
+// It understands both a single *IndexError and an errors.Join of them, and returns nil for a nil error.
+func failingIndices(err error) []int {
+	if err == nil {
+		return nil
+	}
+
+	// Unwrap a joined error into its individual failures.
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+
+	// Collect the position of every failure.
+	var indices []int
+	for _, e := range errs {
+		var indexErr *IndexError
+		if errors.As(e, &indexErr) {
+			indices = append(indices, indexErr.Index)
+		}
+	}
+
+	return indices
+}
@@ -0,0 +1,172 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/spacemagneto/common/slice/proptest"
+)
+
+// The fuzz targets in this file decode the fuzzer's bytes into slices of small integers and check the same laws
+// as laws_test.go. Without -fuzz they only run their seed corpus, so they are part of every go test run:
+//
+//	go test -run '^$' -fuzz FuzzUnique -fuzztime 30s .
+
+// fuzzInts decodes the bytes into integers in [0, 16), so the fuzzer easily produces duplicates and runs.
+func fuzzInts(data []byte) []int {
+	if len(data) == 0 {
+		return nil
+	}
+	return Map(data, func(b byte) int { return int(b % 16) })
+}
+
+// fuzzSeed encodes a slice of integers as fuzzer bytes, for seeding the corpus from the test fixtures.
+func fuzzSeed(elements []int) []byte {
+	return Map(elements, func(v int) byte { return byte(v) })
+}
+
+// addFuzzSeeds seeds the corpus with the shapes used by the table tests.
+func addFuzzSeeds(f *testing.F, extra ...any) {
+	for _, seed := range [][]byte{
+		nil,
+		{1},
+		{3, 3, 3},
+		{1, 2, 1, 3, 2},
+		fuzzSeed(createSequenceWithRepeats(40, 7)),
+		fuzzSeed(createSequenceWithoutRepeats(40)),
+	} {
+		f.Add(append([]any{seed}, extra...)...)
+	}
+}
+
+// checkLaw fails the fuzz run with the violation reported by the property, if any.
+func checkLaw[T any](t *testing.T, prop proptest.Property[T], value T) {
+	t.Helper()
+	if err := prop(value); err != nil {
+		t.Fatalf("input %v: %v", value, err)
+	}
+}
+
+func FuzzUnique(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkLaw(t, proptest.All(
+			proptest.Idempotent(Unique[int]),
+			proptest.HasNoDuplicates(Unique[int]),
+			proptest.IsSubsequence(Unique[int]),
+			proptest.DoesNotMutate(Unique[int]),
+			proptest.Equivalent(func(e []int) []int { return UniqueBy(e, identity[int]) }, Unique[int]),
+			proptest.Equivalent(func(e []int) []int { return UniqueFunc(e, func(a, b int) bool { return a == b }) }, Unique[int]),
+			proptest.Equivalent(func(e []int) []int { return UniqueInPlace(Merge(e, nil)) }, Unique[int]),
+		), fuzzInts(data))
+	})
+}
+
+func FuzzUniqueInto(f *testing.F) {
+	addFuzzSeeds(f, 0)
+	pool := NewSeenPool[int]()
+	f.Fuzz(func(t *testing.T, data []byte, threshold int) {
+		into := func(e []int) []int {
+			return UniqueInto(nil, e, WithLinearScanThreshold[int](threshold), WithSeenPool(pool))
+		}
+		checkLaw(t, proptest.Equivalent(into, Unique[int]), fuzzInts(data))
+	})
+}
+
+func FuzzExclude(f *testing.F) {
+	addFuzzSeeds(f, 3)
+	f.Fuzz(func(t *testing.T, data []byte, element int) {
+		exclude := func(e []int) []int { return Exclude(Merge(e, nil), element) }
+		checkLaw(t, proptest.All(
+			proptest.IsSubsequence(exclude),
+			proptest.Idempotent(exclude),
+			proptest.Equivalent(exclude, func(e []int) []int { return Filter(e, func(v int) bool { return v != element }) }),
+			proptest.Equivalent(exclude, func(e []int) []int { return ExcludeFunc(Merge(e, nil), func(v int) bool { return v == element }) }),
+		), fuzzInts(data))
+	})
+}
+
+func FuzzFilter(f *testing.F) {
+	addFuzzSeeds(f, 3)
+	f.Fuzz(func(t *testing.T, data []byte, divisor int) {
+		if divisor == 0 {
+			divisor = 1
+		}
+		pred := func(v int) bool { return v%divisor == 0 }
+		filter := func(e []int) []int { return Filter(e, pred) }
+		checkLaw(t, proptest.All(
+			proptest.PartitionsInput(Filter[int], pred),
+			proptest.IsSubsequence(filter),
+			proptest.DoesNotMutate(filter),
+			proptest.Equivalent(func(e []int) []int { return FilterInPlace(Merge(e, nil), pred) }, filter),
+			proptest.Equivalent(func(e []int) []int { return From(e).Filter(pred).Collect() }, filter),
+		), fuzzInts(data))
+	})
+}
+
+func FuzzCompact(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkLaw(t, proptest.All(
+			proptest.Idempotent(Compact[int]),
+			proptest.IsSubsequence(Compact[int]),
+			proptest.Equivalent(func(e []int) []int { return CompactInPlace(Merge(e, nil)) }, Compact[int]),
+			proptest.Equivalent(func(e []int) []int { return Compact(sortedInts(e)) }, func(e []int) []int { return SortedDedup(sortedInts(e)) }),
+		), fuzzInts(data))
+	})
+}
+
+func FuzzRotate(f *testing.F) {
+	addFuzzSeeds(f, 2)
+	f.Fuzz(func(t *testing.T, data []byte, k int) {
+		rotate := func(e []int) []int { return Rotate(e, k) }
+		checkLaw(t, proptest.All(
+			proptest.PreservesElements(rotate),
+			proptest.DoesNotMutate(rotate),
+			proptest.Equivalent(func(e []int) []int { return Rotate(rotate(e), -k) }, identity[[]int]),
+			proptest.Equivalent(func(e []int) []int { c := Merge(e, nil); RotateInPlace(c, k); return c }, rotate),
+		), fuzzInts(data))
+	})
+}
+
+func FuzzSortedSetOperations(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		elements := fuzzInts(data)
+		a, b := halves(elements)
+		a, b = sortedInts(a), sortedInts(b)
+
+		// Every result must be sorted, free of duplicates, and agree with the membership of its inputs.
+		for name, op := range map[string]struct {
+			result []int
+			keep   func(inA, inB bool) bool
+		}{
+			"SortedUnion":      {SortedUnion(a, b), func(inA, inB bool) bool { return inA || inB }},
+			"SortedIntersect":  {SortedIntersect(a, b), func(inA, inB bool) bool { return inA && inB }},
+			"SortedDifference": {SortedDifference(a, b), func(inA, inB bool) bool { return inA && !inB }},
+		} {
+			expected := Filter(SortedDedup(sortedInts(elements)), func(v int) bool { return op.keep(Contains(a, v), Contains(b, v)) })
+			checkLaw(t, proptest.Equivalent(func([]int) []int { return op.result }, func([]int) []int { return expected }), elements)
+			if len(op.result) != len(SortedDedup(op.result)) {
+				t.Fatalf("%s(%v, %v) = %v has duplicates", name, a, b, op.result)
+			}
+		}
+		checkLaw(t, proptest.Equivalent(func([]int) []int { return SortedMergeK(a, b) }, sortedInts), elements)
+	})
+}
+
+func FuzzChunk(f *testing.F) {
+	addFuzzSeeds(f, 3)
+	f.Fuzz(func(t *testing.T, data []byte, n int) {
+		checkLaw(t, proptest.All(
+			proptest.Equivalent(func(e []int) []int {
+				if n <= 0 {
+					return e
+				}
+				return concat(Chunk(e, n))
+			}, identity[[]int]),
+			proptest.Equivalent(func(e []int) []int { return concat(ChunkBy(e, isEvenLaw)) }, identity[[]int]),
+			proptest.NoPanic(func(e []int) { SlidingWindow(e, n, n%4) }),
+			proptest.NoPanic(func(e []int) { Batch(e, n, n, func(v int) int { return v }) }),
+		), fuzzInts(data))
+	})
+}
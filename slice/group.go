@@ -0,0 +1,124 @@
+package slice
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateKey is reported by KeyBy under the RejectDuplicates policy when two elements share a key.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// DuplicateKeyPolicy controls which element KeyBy keeps when several elements produce the same key.
+type DuplicateKeyPolicy int
+
+const (
+	// KeepFirst keeps the first element produced for every key and ignores the later ones.
+	KeepFirst DuplicateKeyPolicy = iota
+	// KeepLast keeps the last element produced for every key, overwriting the earlier ones.
+	KeepLast
+	// RejectDuplicates fails with ErrDuplicateKey as soon as a key is produced a second time.
+	RejectDuplicates
+)
+
+// Group holds the elements of a slice that share the same key, as returned by GroupByOrdered.
+type Group[K comparable, T any] struct {
+	// Key is the key shared by every element of the group.
+	Key K
+	// Elements holds the elements of the group, in the order of the input slice.
+	Elements []T
+}
+
+// GroupBy buckets the elements of a slice by the key computed for each of them.
+// Inside every bucket the elements keep the order of the input slice. The returned map is never nil.
+func GroupBy[T any, K comparable](elements []T, key func(T) K) map[K][]T {
+	// Create the map of buckets.
+	result := make(map[K][]T)
+
+	// Append every element to the bucket of its key.
+	for _, v := range elements {
+		k := key(v)
+		result[k] = append(result[k], v)
+	}
+
+	// Return the buckets.
+	return result
+}
+
+// GroupByOrdered buckets the elements of a slice by the key computed for each of them, like GroupBy,
+// but returns the groups in the order in which their keys were first seen, which makes the output deterministic.
+func GroupByOrdered[T any, K comparable](elements []T, key func(T) K) []Group[K, T] {
+	var result []Group[K, T]
+	// Map every key to the position of its group in the result.
+	positions := make(map[K]int)
+
+	// Append every element to the group of its key, creating the group on first sight.
+	for _, v := range elements {
+		k := key(v)
+		i, ok := positions[k]
+		if !ok {
+			i = len(result)
+			positions[k] = i
+			result = append(result, Group[K, T]{Key: k})
+		}
+		result[i].Elements = append(result[i].Elements, v)
+	}
+
+	// Return the groups in first-seen order.
+	return result
+}
+
+// Partition splits a slice into the elements that satisfy the predicate and the elements that do not.
+// Both results keep the order of the input slice and are nil when empty, like Filter.
+// The predicate is evaluated exactly once per element.
+func Partition[T any](elements []T, fn func(T) bool) (yes, no []T) {
+	// Route every element to one of the two results.
+	for _, v := range elements {
+		if fn(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+
+	// Return both partitions.
+	return yes, no
+}
+
+// KeyBy indexes the elements of a slice by the key computed for each of them.
+// The policy decides what happens when several elements produce the same key. Under RejectDuplicates the
+// first repeated key is reported as an *IndexError wrapping ErrDuplicateKey, together with a nil map.
+func KeyBy[T any, K comparable](elements []T, key func(T) K, policy DuplicateKeyPolicy) (map[K]T, error) {
+	// Create the index with room for every element.
+	result := make(map[K]T, len(elements))
+
+	// Store every element under its key, resolving duplicates according to the policy.
+	for i, v := range elements {
+		k := key(v)
+		if _, exists := result[k]; exists {
+			switch policy {
+			case KeepFirst:
+				continue
+			case RejectDuplicates:
+				return nil, &IndexError{Index: i, Err: fmt.Errorf("%w: %v", ErrDuplicateKey, k)}
+			}
+		}
+		result[k] = v
+	}
+
+	// Return the index.
+	return result, nil
+}
+
+// CountBy counts the elements of a slice per key computed for each of them. The returned map is never nil.
+func CountBy[T any, K comparable](elements []T, key func(T) K) map[K]int {
+	// Create the map of counters.
+	result := make(map[K]int)
+
+	// Increment the counter of the key of every element.
+	for _, v := range elements {
+		result[key(v)]++
+	}
+
+	// Return the counters.
+	return result
+}
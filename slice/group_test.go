@@ -0,0 +1,187 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ticket is the record type used by the grouping tests.
+type ticket struct {
+	id     int
+	tenant string
+	status string
+}
+
+// tickets is a shared fixture with several tenants and statuses in a mixed order.
+var tickets = []ticket{
+	{1, "acme", "open"},
+	{2, "globex", "closed"},
+	{3, "acme", "closed"},
+	{4, "initech", "open"},
+	{5, "globex", "open"},
+	{6, "acme", "open"},
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for grouping the shared fixture by different keys.
+	cases := []struct {
+		name     string
+		elements []ticket
+		key      func(ticket) string
+		expected map[string][]int
+	}{
+		{name: "Nil slice", elements: nil, key: func(t ticket) string { return t.tenant }, expected: map[string][]int{}},
+		{name: "By tenant", elements: tickets, key: func(t ticket) string { return t.tenant }, expected: map[string][]int{"acme": {1, 3, 6}, "globex": {2, 5}, "initech": {4}}},
+		{name: "By status", elements: tickets, key: func(t ticket) string { return t.status }, expected: map[string][]int{"open": {1, 4, 5, 6}, "closed": {2, 3}}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := GroupBy(tt.elements, tt.key)
+
+			// Compare the identifiers of every bucket, which also verifies the order inside the buckets.
+			ids := make(map[string][]int, len(groups))
+			for k, v := range groups {
+				ids[k] = Map(v, func(t ticket) int { return t.id })
+			}
+			assert.Equal(t, tt.expected, ids, "GroupBy result for case %q", tt.name)
+		})
+	}
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	t.Parallel()
+
+	// ByTenant tests that groups follow the order in which their keys were first seen.
+	t.Run("ByTenant", func(t *testing.T) {
+		groups := GroupByOrdered(tickets, func(t ticket) string { return t.tenant })
+
+		assert.Equal(t, []string{"acme", "globex", "initech"}, Map(groups, func(g Group[string, ticket]) string { return g.Key }), "Keys should be in first-seen order")
+		assert.Equal(t, []ticket{tickets[0], tickets[2], tickets[5]}, groups[0].Elements, "Elements should keep the input order")
+	})
+
+	// AfterFilter tests grouping right after filtering, which is the common report-building chain.
+	t.Run("AfterFilter", func(t *testing.T) {
+		open := Filter(tickets, func(t ticket) bool { return t.status == "open" })
+		groups := GroupByOrdered(open, func(t ticket) string { return t.tenant })
+
+		assert.Equal(t, []Group[string, int]{{"acme", []int{1, 6}}, {"initech", []int{4}}, {"globex", []int{5}}},
+			Map(groups, func(g Group[string, ticket]) Group[string, int] {
+				return Group[string, int]{Key: g.Key, Elements: Map(g.Elements, func(t ticket) int { return t.id })}
+			}), "Open tickets should be grouped by tenant in first-seen order")
+	})
+
+	// Empty tests that an empty input produces no group.
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, GroupByOrdered([]ticket(nil), func(t ticket) string { return t.tenant }), "An empty input should produce no group")
+	})
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases mirroring the Filter cases; the second result holds the elements Filter drops.
+	cases := []struct {
+		name        string
+		elements    []int
+		fn          func(int) bool
+		expectedYes []int
+		expectedNo  []int
+	}{
+		{name: "Even and odd", elements: []int{1, 2, 3, 4, 5}, fn: func(n int) bool { return n%2 == 0 }, expectedYes: []int{2, 4}, expectedNo: []int{1, 3, 5}},
+		{name: "Empty slice", elements: []int{}, fn: func(n int) bool { return n%2 == 0 }, expectedYes: nil, expectedNo: nil},
+		{name: "All match", elements: []int{2, 4}, fn: func(n int) bool { return n%2 == 0 }, expectedYes: []int{2, 4}, expectedNo: nil},
+		{name: "None match", elements: []int{1, 3}, fn: func(n int) bool { return n%2 == 0 }, expectedYes: nil, expectedNo: []int{1, 3}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			yes, no := Partition(tt.elements, tt.fn)
+
+			assert.Equal(t, tt.expectedYes, yes, "Matching partition for case %q", tt.name)
+			assert.Equal(t, tt.expectedNo, no, "Non-matching partition for case %q", tt.name)
+			assert.Equal(t, Filter(tt.elements, tt.fn), yes, "The matching partition should equal Filter for case %q", tt.name)
+		})
+	}
+}
+
+func TestKeyBy(t *testing.T) {
+	t.Parallel()
+
+	byTenant := func(t ticket) string { return t.tenant }
+
+	// Policies tests every duplicate key policy on the shared fixture, where acme and globex are repeated.
+	t.Run("Policies", func(t *testing.T) {
+		cases := []struct {
+			name          string
+			policy        DuplicateKeyPolicy
+			expected      map[string]int
+			expectedIndex int
+		}{
+			{name: "KeepFirst", policy: KeepFirst, expected: map[string]int{"acme": 1, "globex": 2, "initech": 4}},
+			{name: "KeepLast", policy: KeepLast, expected: map[string]int{"acme": 6, "globex": 5, "initech": 4}},
+			{name: "RejectDuplicates", policy: RejectDuplicates, expected: nil, expectedIndex: 2},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				index, err := KeyBy(tickets, byTenant, tt.policy)
+
+				// Verify the reported duplicate, if the policy rejects them.
+				if tt.expected == nil {
+					var indexErr *IndexError
+					assert.ErrorIs(t, err, ErrDuplicateKey, "KeyBy should report the duplicate key")
+					assert.ErrorAs(t, err, &indexErr, "KeyBy should report the position of the duplicate")
+					assert.Equal(t, tt.expectedIndex, indexErr.Index, "KeyBy should report the first repeated position")
+					assert.EqualError(t, err, "index 2: duplicate key: acme", "KeyBy should include the key in the error")
+					assert.Nil(t, index, "KeyBy should not return a partial index")
+					return
+				}
+
+				// Compare the identifiers of the indexed elements.
+				assert.NoError(t, err, "KeyBy should not fail for case %q", tt.name)
+				ids := make(map[string]int, len(index))
+				for k, v := range index {
+					ids[k] = v.id
+				}
+				assert.Equal(t, tt.expected, ids, "KeyBy result for case %q", tt.name)
+			})
+		}
+	})
+
+	// UniqueKeys tests that every policy succeeds when the keys are unique.
+	t.Run("UniqueKeys", func(t *testing.T) {
+		index, err := KeyBy(tickets, func(t ticket) int { return t.id }, RejectDuplicates)
+		assert.NoError(t, err, "Unique keys should be accepted")
+		assert.Len(t, index, len(tickets), "Every element should be indexed")
+	})
+}
+
+func TestCountBy(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for counting the shared fixture by different keys.
+	cases := []struct {
+		name     string
+		elements []ticket
+		key      func(ticket) string
+		expected map[string]int
+	}{
+		{name: "Nil slice", elements: nil, key: func(t ticket) string { return t.tenant }, expected: map[string]int{}},
+		{name: "By tenant", elements: tickets, key: func(t ticket) string { return t.tenant }, expected: map[string]int{"acme": 3, "globex": 2, "initech": 1}},
+		{name: "By status", elements: tickets, key: func(t ticket) string { return t.status }, expected: map[string]int{"open": 4, "closed": 2}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CountBy(tt.elements, tt.key), "CountBy result for case %q", tt.name)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package slice
+
+// The functions in this file come in two families with explicit aliasing contracts.
+//
+// The InPlace functions reuse the backing array of their input. They overwrite the caller's elements, and when
+// they shorten the slice they set the elements between the new length and the old length to the zero value,
+// so removed pointers do not keep their targets reachable. After calling them, the caller must only use the
+// returned slice, since the input slice still has the old length and its tail now holds zero values.
+//
+// The allocating functions never write to their input and always return a slice with its own backing array,
+// like Map, Filter and Unique. Exclude and ExcludeFunc predate this split: they reuse the backing array like
+// the InPlace family but leave the removed tail untouched.
+
+// FilterInPlace keeps only the elements that satisfy the predicate, preserving their order, and returns the
+// shortened slice. It reuses the backing array of the input and zeroes the removed tail.
+// Use Filter for an allocating version that leaves the input untouched.
+func FilterInPlace[T any](elements []T, fn func(T) bool) []T {
+	// Initialize the result slice with the same underlying array as the original slice.
+	result := elements[:0]
+
+	// Move every matching element to the front of the backing array.
+	for _, v := range elements {
+		if fn(v) {
+			result = append(result, v)
+		}
+	}
+
+	// Zero the removed tail so the garbage collector can reclaim what it referenced.
+	clear(elements[len(result):])
+
+	// Return the filtered slice.
+	return result
+}
+
+// UniqueInPlace removes duplicate elements, keeping the first occurrence of every element in its original order,
+// and returns the shortened slice. It reuses the backing array of the input and zeroes the removed tail.
+// Use Unique for an allocating version that leaves the input untouched.
+func UniqueInPlace[T comparable](elements []T) []T {
+	// Create a map to track the elements that have already been kept.
+	seen := make(map[T]struct{}, len(elements))
+
+	// Keep only the first occurrence of every element.
+	return FilterInPlace(elements, func(v T) bool {
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+		return true
+	})
+}
+
+// CompactInPlace replaces every run of consecutive equal elements with a single copy and returns the shortened
+// slice. It reuses the backing array of the input and zeroes the removed tail. On sorted input it removes every
+// duplicate without allocating a map. Use Compact for an allocating version that leaves the input untouched.
+func CompactInPlace[T comparable](elements []T) []T {
+	// A slice with fewer than two elements has no consecutive duplicates.
+	if len(elements) < 2 {
+		return elements
+	}
+
+	// Keep the first element and every element that differs from the last kept one.
+	result := elements[:1]
+	for _, v := range elements[1:] {
+		if v != result[len(result)-1] {
+			result = append(result, v)
+		}
+	}
+
+	// Zero the removed tail so the garbage collector can reclaim what it referenced.
+	clear(elements[len(result):])
+
+	// Return the compacted slice.
+	return result
+}
+
+// ReverseInPlace reverses the order of the elements of the slice, overwriting the caller's elements.
+// Use Reverse for an allocating version that leaves the input untouched.
+func ReverseInPlace[T any](elements []T) {
+	// Swap the elements pairwise from both ends towards the middle.
+	for i, j := 0, len(elements)-1; i < j; i, j = i+1, j-1 {
+		elements[i], elements[j] = elements[j], elements[i]
+	}
+}
+
+// RotateInPlace rotates the elements of the slice to the left by k positions, overwriting the caller's elements.
+// The element at position k moves to the front. A negative k rotates to the right, and k is taken modulo the
+// length of the slice. Use Rotate for an allocating version that leaves the input untouched.
+func RotateInPlace[T any](elements []T, k int) {
+	// Normalize the rotation amount into [0, len).
+	k = rotation(len(elements), k)
+	if k == 0 {
+		return
+	}
+
+	// Rotating left by k is reversing both parts and then reversing the whole slice, which needs no extra memory.
+	ReverseInPlace(elements[:k])
+	ReverseInPlace(elements[k:])
+	ReverseInPlace(elements)
+}
+
+// Compact returns a new slice in which every run of consecutive equal elements is replaced with a single copy.
+// The input slice is never modified. It returns nil for an empty input, like Unique.
+func Compact[T comparable](elements []T) []T {
+	var result []T
+
+	// Keep the first element and every element that differs from the last kept one.
+	for i, v := range elements {
+		if i == 0 || v != elements[i-1] {
+			result = append(result, v)
+		}
+	}
+
+	// Return the compacted copy.
+	return result
+}
+
+// Reverse returns a new slice holding the elements in reverse order. The input slice is never modified.
+func Reverse[T any](elements []T) []T {
+	// Create the result with the same length as the input, exactly like Map.
+	result := make([]T, len(elements))
+
+	// Copy every element to its mirrored position.
+	for i, v := range elements {
+		result[len(elements)-1-i] = v
+	}
+
+	// Return the reversed copy.
+	return result
+}
+
+// Rotate returns a new slice holding the elements rotated to the left by k positions, with the same rules as
+// RotateInPlace. The input slice is never modified.
+func Rotate[T any](elements []T, k int) []T {
+	// Normalize the rotation amount into [0, len).
+	k = rotation(len(elements), k)
+
+	// Copy the part starting at k to the front, followed by the part before k.
+	return Merge(elements[k:], elements[:k])
+}
+
+// rotation normalizes a rotation amount for a slice of length n into the range [0, n).
+func rotation(n, k int) int {
+	// Nothing can be rotated in an empty slice.
+	if n == 0 {
+		return 0
+	}
+
+	// Take the amount modulo the length, turning negative amounts into the equivalent left rotation.
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	return k
+}
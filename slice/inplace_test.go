@@ -0,0 +1,184 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterInPlace(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases mirroring the Filter cases. expectedInput is the state of the caller's slice after the
+	// call, which documents the aliasing contract: the kept elements are moved to the front and the removed
+	// tail is zeroed.
+	cases := []struct {
+		name          string
+		elements      []int
+		expected      []int
+		expectedInput []int
+	}{
+		{name: "Filter even numbers", elements: []int{1, 2, 3, 4, 5}, expected: []int{2, 4}, expectedInput: []int{2, 4, 0, 0, 0}},
+		{name: "All elements match", elements: []int{2, 4, 6}, expected: []int{2, 4, 6}, expectedInput: []int{2, 4, 6}},
+		{name: "No elements match", elements: []int{1, 3}, expected: []int{}, expectedInput: []int{0, 0}},
+		{name: "Empty slice", elements: []int{}, expected: []int{}, expectedInput: []int{}},
+		{name: "Nil slice", elements: nil, expected: nil, expectedInput: nil},
+	}
+
+	// Iterate through each test case and execute the FilterInPlace function.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterInPlace(tt.elements, func(n int) bool { return n%2 == 0 })
+
+			assert.Equal(t, tt.expected, result, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expectedInput, tt.elements, "Input state for test case %s", tt.name)
+		})
+	}
+
+	// ReleasesPointers tests that the removed tail no longer references the removed values.
+	t.Run("ReleasesPointers", func(t *testing.T) {
+		elements := []*string{strPtr("keep"), strPtr("drop"), strPtr("drop")}
+		result := FilterInPlace(elements, func(s *string) bool { return *s == "keep" })
+
+		assert.Len(t, result, 1, "Only one element should be kept")
+		assert.Equal(t, []*string{elements[0], nil, nil}, elements, "Removed pointers should be cleared")
+	})
+
+	// SharesBackingArray tests that the result is a prefix of the input, unlike Filter.
+	t.Run("SharesBackingArray", func(t *testing.T) {
+		elements := []int{1, 2, 3, 4}
+		result := FilterInPlace(elements, func(n int) bool { return n > 2 })
+		result[0] = 30
+		assert.Equal(t, 30, elements[0], "The result should share the backing array of the input")
+
+		allocated := Filter(elements, func(n int) bool { return n > 2 })
+		allocated[0] = 300
+		assert.Equal(t, 30, elements[0], "Filter should never share the backing array of the input")
+	})
+}
+
+func TestUniqueInPlace(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases mirroring the Unique cases, including the state of the caller's slice after the call.
+	cases := []struct {
+		name          string
+		elements      []string
+		expected      []string
+		expectedInput []string
+	}{
+		{name: "Duplicates", elements: []string{"a", "b", "a", "c", "b"}, expected: []string{"a", "b", "c"}, expectedInput: []string{"a", "b", "c", "", ""}},
+		{name: "No duplicates", elements: []string{"a", "b"}, expected: []string{"a", "b"}, expectedInput: []string{"a", "b"}},
+		{name: "All equal", elements: []string{"x", "x", "x"}, expected: []string{"x"}, expectedInput: []string{"x", "", ""}},
+		{name: "Nil slice", elements: nil, expected: nil, expectedInput: nil},
+	}
+
+	// Iterate through each test case and execute the UniqueInPlace function.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]string(nil), tt.elements...)
+			result := UniqueInPlace(tt.elements)
+
+			assert.Equal(t, tt.expected, result, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expectedInput, tt.elements, "Input state for test case %s", tt.name)
+			if original != nil {
+				assert.Equal(t, Unique(original), result, "UniqueInPlace should agree with Unique for test case %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for collapsing consecutive duplicates.
+	cases := []struct {
+		name          string
+		elements      []int
+		expected      []int
+		expectedInput []int
+	}{
+		{name: "Runs", elements: []int{1, 1, 2, 3, 3, 3, 1}, expected: []int{1, 2, 3, 1}, expectedInput: []int{1, 2, 3, 1, 0, 0, 0}},
+		{name: "No runs", elements: []int{1, 2, 1}, expected: []int{1, 2, 1}, expectedInput: []int{1, 2, 1}},
+		{name: "Single element", elements: []int{5}, expected: []int{5}, expectedInput: []int{5}},
+		{name: "Nil slice", elements: nil, expected: nil, expectedInput: nil},
+	}
+
+	// Iterate through each test case and execute both the allocating and the in-place functions.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// The allocating version must leave the input untouched.
+			original := append([]int(nil), tt.elements...)
+			assert.Equal(t, tt.expected, Compact(tt.elements), "Compact result for test case %s", tt.name)
+			assert.Equal(t, original, tt.elements, "Compact should not modify the input for test case %s", tt.name)
+
+			// The in-place version must produce the same result and zero the removed tail.
+			assert.Equal(t, tt.expected, CompactInPlace(tt.elements), "CompactInPlace result for test case %s", tt.name)
+			assert.Equal(t, tt.expectedInput, tt.elements, "Input state for test case %s", tt.name)
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for reversing slices of even and odd length.
+	cases := []struct {
+		name     string
+		elements []int
+		expected []int
+	}{
+		{name: "Empty slice", elements: []int{}, expected: []int{}},
+		{name: "Single element", elements: []int{1}, expected: []int{1}},
+		{name: "Even length", elements: []int{1, 2, 3, 4}, expected: []int{4, 3, 2, 1}},
+		{name: "Odd length", elements: []int{1, 2, 3}, expected: []int{3, 2, 1}},
+	}
+
+	// Iterate through each test case and execute both the allocating and the in-place functions.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// The allocating version must leave the input untouched.
+			original := append([]int{}, tt.elements...)
+			assert.Equal(t, tt.expected, Reverse(tt.elements), "Reverse result for test case %s", tt.name)
+			assert.Equal(t, original, tt.elements, "Reverse should not modify the input for test case %s", tt.name)
+
+			// The in-place version must overwrite the input with the reversed elements.
+			ReverseInPlace(tt.elements)
+			assert.Equal(t, tt.expected, tt.elements, "ReverseInPlace result for test case %s", tt.name)
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for left, right, full and oversized rotations.
+	cases := []struct {
+		name     string
+		elements []int
+		k        int
+		expected []int
+	}{
+		{name: "Empty slice", elements: []int{}, k: 3, expected: []int{}},
+		{name: "No rotation", elements: []int{1, 2, 3}, k: 0, expected: []int{1, 2, 3}},
+		{name: "Rotate left", elements: []int{1, 2, 3, 4, 5}, k: 2, expected: []int{3, 4, 5, 1, 2}},
+		{name: "Rotate right", elements: []int{1, 2, 3, 4, 5}, k: -1, expected: []int{5, 1, 2, 3, 4}},
+		{name: "Full rotation", elements: []int{1, 2, 3}, k: 3, expected: []int{1, 2, 3}},
+		{name: "Oversized rotation", elements: []int{1, 2, 3}, k: 7, expected: []int{2, 3, 1}},
+		{name: "Oversized negative rotation", elements: []int{1, 2, 3}, k: -4, expected: []int{3, 1, 2}},
+	}
+
+	// Iterate through each test case and execute both the allocating and the in-place functions.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// The allocating version must leave the input untouched.
+			original := append([]int{}, tt.elements...)
+			assert.Equal(t, tt.expected, Rotate(tt.elements, tt.k), "Rotate result for test case %s", tt.name)
+			assert.Equal(t, original, tt.elements, "Rotate should not modify the input for test case %s", tt.name)
+
+			// The in-place version must overwrite the input with the rotated elements.
+			RotateInPlace(tt.elements, tt.k)
+			assert.Equal(t, tt.expected, tt.elements, "RotateInPlace result for test case %s", tt.name)
+		})
+	}
+}
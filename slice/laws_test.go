@@ -0,0 +1,566 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/spacemagneto/common/slice/proptest"
+)
+
+// The tests in this file check algebraic laws of every exported function against generated inputs. The table
+// tests pin the behavior on hand-picked cases, while these laws catch the edge cases nobody thought of.
+// A failure prints the seed that reproduces it; set PROPTEST_SEED to replay it.
+
+var (
+	// lawInts generates short slices of small integers, so duplicates, runs and collisions are frequent.
+	lawInts = proptest.IntSlices(40, 12)
+	// lawRepeating generates slices shaped like createSequenceWithRepeats.
+	lawRepeating = proptest.Repeating(120)
+	// lawStrings generates slices of short strings over a small alphabet.
+	lawStrings = proptest.StringSlices(30)
+	// lawRecords generates slices of comparable structs with colliding IDs and names.
+	lawRecords = proptest.Records(30, 6)
+)
+
+func isEvenLaw(v int) bool              { return v%2 == 0 }
+func recordIDLaw(r proptest.Record) int { return r.ID }
+func identity[T any](v T) T             { return v }
+
+// sortedInts returns a sorted copy of the elements.
+func sortedInts(elements []int) []int {
+	sorted := slices.Clone(elements)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// halves splits the elements into two parts, which turns one generated slice into two related inputs.
+func halves[T any](elements []T) ([]T, []T) {
+	return elements[:len(elements)/2], elements[len(elements)/2:]
+}
+
+// concat joins the slices into one, returning nil when there is nothing to join.
+func concat[T any](parts [][]T) []T {
+	var result []T
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+	return result
+}
+
+func TestLawsSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Merge", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.Equivalent(func(e []int) []int { a, b := halves(e); return Merge(a, b) }, identity[[]int]),
+			proptest.DoesNotMutate(func(e []int) []int { return Merge(e, e) }),
+		))
+	})
+
+	t.Run("Exclude", func(t *testing.T) {
+		excludeFirst := func(e []int) []int {
+			if len(e) == 0 {
+				return Exclude(e, 0)
+			}
+			return Exclude(e, e[0])
+		}
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.IsSubsequence(excludeFirst),
+			proptest.Idempotent(func(e []int) []int { return Exclude(e, 3) }),
+			proptest.Equivalent(func(e []int) []int { return Exclude(slices.Clone(e), 3) }, func(e []int) []int {
+				return Filter(e, func(v int) bool { return v != 3 })
+			}),
+		))
+		proptest.Check(t, lawStrings, proptest.Equivalent(func(e []string) []string { return Exclude(slices.Clone(e), "a") }, func(e []string) []string {
+			return Filter(e, func(v string) bool { return v != "a" })
+		}))
+	})
+
+	t.Run("ExcludeFunc", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.Equivalent(func(e []int) []int { return ExcludeFunc(slices.Clone(e), isEvenLaw) }, func(e []int) []int {
+			return Filter(e, func(v int) bool { return !isEvenLaw(v) })
+		}))
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		type query struct {
+			elements []int
+			target   int
+		}
+		queries := func(e []int) []query {
+			return Map(append([]int{-1, 0, 5}, e...), func(v int) query { return query{e, v} })
+		}
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.Equivalent(func(e []int) []bool {
+				return Map(queries(e), func(q query) bool { return Contains(q.elements, q.target) })
+			}, func(e []int) []bool {
+				return Map(queries(e), func(q query) bool { return slices.Contains(q.elements, q.target) })
+			}),
+			proptest.Equivalent(func(e []int) []bool {
+				return Map(queries(e), func(q query) bool { return ContainsFunc(q.elements, func(v int) bool { return v == q.target }) })
+			}, func(e []int) []bool {
+				return Map(queries(e), func(q query) bool { return Contains(q.elements, q.target) })
+			}),
+			proptest.Equivalent(func(e []int) []bool {
+				sorted := sortedInts(e)
+				return Map(queries(e), func(q query) bool { return ContainsCmp(sorted, q.target, compareInts) })
+			}, func(e []int) []bool {
+				return Map(queries(e), func(q query) bool { return Contains(q.elements, q.target) })
+			}),
+		))
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		double := func(e []int) []int { return Map(e, func(v int) int { return v * 2 }) }
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.PreservesLength(double),
+			proptest.DoesNotMutate(double),
+			proptest.Equivalent(func(e []int) []int { return Map(e, identity[int]) }, identity[[]int]),
+		))
+		proptest.Check(t, lawRecords, proptest.PreservesLength(func(e []proptest.Record) []int { return Map(e, recordIDLaw) }))
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		evens := func(e []int) []int { return Filter(e, isEvenLaw) }
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.PartitionsInput(Filter[int], isEvenLaw),
+			proptest.IsSubsequence(evens),
+			proptest.Idempotent(evens),
+			proptest.DoesNotMutate(evens),
+		))
+		proptest.Check(t, lawStrings, proptest.PartitionsInput(Filter[string], func(s string) bool { return len(s) > 1 }))
+	})
+
+	t.Run("FilterInto", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.Equivalent(func(e []int) []int {
+			a, b := halves(e)
+			return FilterInto(slices.Clone(a), b, isEvenLaw)
+		}, func(e []int) []int {
+			a, b := halves(e)
+			return Merge(a, Filter(b, isEvenLaw))
+		}))
+	})
+
+	t.Run("ParallelMap", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.Equivalent(func(e []int) []int {
+			result, _ := ParallelMap(context.Background(), e, 3, func(v int) int { return v + 1 })
+			return result
+		}, func(e []int) []int {
+			return Map(e, func(v int) int { return v + 1 })
+		}))
+	})
+
+	t.Run("ParallelFilter", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.Equivalent(func(e []int) []int {
+			result, _ := ParallelFilter(context.Background(), e, 3, isEvenLaw)
+			return result
+		}, func(e []int) []int {
+			return Filter(e, isEvenLaw)
+		}))
+	})
+}
+
+func TestLawsUnique(t *testing.T) {
+	t.Parallel()
+
+	// uniqueLaws are the laws every deduplication function must satisfy.
+	uniqueLaws := func(fn func([]int) []int) proptest.Property[[]int] {
+		return proptest.All(
+			proptest.Idempotent(fn),
+			proptest.HasNoDuplicates(fn),
+			proptest.IsSubsequence(fn),
+			proptest.Equivalent(func(e []int) []int { return Unique(fn(e)) }, Unique[int]),
+		)
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.All(uniqueLaws(Unique[int]), proptest.DoesNotMutate(Unique[int])))
+		proptest.Check(t, lawRepeating, uniqueLaws(Unique[int]))
+		proptest.Check(t, lawStrings, proptest.All(proptest.Idempotent(Unique[string]), proptest.HasNoDuplicates(Unique[string])))
+		proptest.Check(t, lawRecords, proptest.All(proptest.Idempotent(Unique[proptest.Record]), proptest.HasNoDuplicates(Unique[proptest.Record])))
+	})
+
+	t.Run("UniqueBy", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.Equivalent(func(e []int) []int { return UniqueBy(e, identity[int]) }, Unique[int]))
+		proptest.Check(t, lawRecords, proptest.HasNoDuplicates(func(e []proptest.Record) []int {
+			return Map(UniqueBy(e, recordIDLaw), recordIDLaw)
+		}))
+	})
+
+	t.Run("UniqueFunc", func(t *testing.T) {
+		proptest.Check(t, lawRecords, proptest.Equivalent(func(e []proptest.Record) []proptest.Record {
+			return UniqueFunc(e, func(a, b proptest.Record) bool { return a.ID == b.ID })
+		}, func(e []proptest.Record) []proptest.Record {
+			return UniqueBy(e, recordIDLaw)
+		}))
+	})
+
+	t.Run("UniqueInto", func(t *testing.T) {
+		pool := NewSeenPool[int]()
+		for _, threshold := range []int{0, 4, DefaultLinearScanThreshold, 1 << 10} {
+			into := func(e []int) []int {
+				return UniqueInto(nil, e, WithLinearScanThreshold[int](threshold), WithSeenPool(pool))
+			}
+			proptest.Check(t, lawRepeating, proptest.All(uniqueLaws(into), proptest.Equivalent(into, Unique[int])))
+		}
+	})
+
+	t.Run("UniqueInPlace", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.All(uniqueLaws(UniqueInPlace[int]), proptest.Equivalent(func(e []int) []int {
+			return UniqueInPlace(slices.Clone(e))
+		}, Unique[int])))
+	})
+}
+
+func TestLawsPipeline(t *testing.T) {
+	t.Parallel()
+
+	proptest.Check(t, lawInts, proptest.All(
+		proptest.Equivalent(func(e []int) []string {
+			p := From(e).Filter(isEvenLaw).Map(func(v int) int { return v + 1 }).Skip(1).Take(5)
+			return MapTo(p, func(v int) string { return fmt.Sprint(v) }).Collect()
+		}, func(e []int) []string {
+			mapped := Map(Filter(e, isEvenLaw), func(v int) int { return v + 1 })
+			if len(mapped) > 0 {
+				mapped = mapped[1:]
+			}
+			return Map(mapped[:min(5, len(mapped))], func(v int) string { return fmt.Sprint(v) })
+		}),
+		proptest.Equivalent(func(e []int) []int { return PipeUnique(FromSeq(From(e).Seq())).Collect() }, Unique[int]),
+		proptest.Equivalent(func(e []int) []int { return PipeExclude(From(e), 3).Collect() }, func(e []int) []int {
+			return Exclude(slices.Clone(e), 3)
+		}),
+		proptest.Equivalent(func(e []int) int { return From(e).Filter(isEvenLaw).Count() }, func(e []int) int {
+			return len(Filter(e, isEvenLaw))
+		}),
+		proptest.Equivalent(func(e []int) []int {
+			v, ok := From(e).First()
+			if !ok {
+				return nil
+			}
+			return []int{v}
+		}, func(e []int) []int { return e[:min(1, len(e))] }),
+	))
+}
+
+func TestLawsErrors(t *testing.T) {
+	t.Parallel()
+
+	// Without errors, the error-aware functions must behave like their plain counterparts under both policies.
+	for _, policy := range []ErrorPolicy{StopOnError, CollectErrors} {
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.Equivalent(func(e []int) []int {
+				result, _ := MapErr(e, policy, func(v int) (int, error) { return v * 3, nil })
+				return result
+			}, func(e []int) []int { return Map(e, func(v int) int { return v * 3 }) }),
+			proptest.Equivalent(func(e []int) []int {
+				result, _ := FilterErr(e, policy, func(v int) (bool, error) { return isEvenLaw(v), nil })
+				return result
+			}, func(e []int) []int { return Filter(e, isEvenLaw) }),
+			proptest.Equivalent(func(e []int) int {
+				result, _ := ReduceErr(e, 0, policy, func(acc, v int) (int, error) { return acc + v, nil })
+				return result
+			}, Sum[int]),
+			proptest.Equivalent(func(e []int) []int {
+				var visited []int
+				_ = ForEachErr(e, policy, func(v int) error { visited = append(visited, v); return nil })
+				return visited
+			}, identity[[]int]),
+		))
+	}
+
+	// Under CollectErrors, every failing element is reported and the others are still mapped.
+	proptest.Check(t, lawInts, func(e []int) error {
+		result, err := MapErr(e, CollectErrors, func(v int) (int, error) {
+			if v%3 == 0 {
+				return 0, errNegative
+			}
+			return v, nil
+		})
+		var indexErr *IndexError
+		failures := len(Filter(e, func(v int) bool { return v%3 == 0 }))
+		if failures > 0 && !errors.As(err, &indexErr) {
+			return fmt.Errorf("expected an IndexError, got %v", err)
+		}
+		if len(result) != len(e) {
+			return fmt.Errorf("CollectErrors should keep the length, got %d of %d", len(result), len(e))
+		}
+		return nil
+	})
+}
+
+func TestLawsSorted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SortedIndex", func(t *testing.T) {
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.Equivalent(func(e []int) []int { return NewSortedIndex(e).Values() }, sortedInts),
+			proptest.Equivalent(func(e []int) []bool {
+				index := NewSortedIndex(e)
+				return Map([]int{-1, 0, 3, 7, 13}, index.Has)
+			}, func(e []int) []bool {
+				return Map([]int{-1, 0, 3, 7, 13}, func(v int) bool { return Contains(e, v) })
+			}),
+			proptest.Equivalent(func(e []int) []int {
+				return Map([]int{-1, 0, 3, 7, 13}, NewSortedIndex(e).Rank)
+			}, func(e []int) []int {
+				return Map([]int{-1, 0, 3, 7, 13}, func(v int) int { return len(Filter(e, func(x int) bool { return x < v })) })
+			}),
+			proptest.Equivalent(func(e []int) []int { return NewSortedIndex(e).Range(3, 8) }, func(e []int) []int {
+				return Filter(sortedInts(e), func(v int) bool { return v >= 3 && v < 8 })
+			}),
+		))
+	})
+
+	// The sorted set operations must agree with the same operations computed on maps.
+	setOp := func(keep func(inA, inB bool) bool) func(e []int) []int {
+		return func(e []int) []int {
+			a, b := halves(e)
+			return Filter(Unique(sortedInts(e)), func(v int) bool { return keep(Contains(a, v), Contains(b, v)) })
+		}
+	}
+	sortedPair := func(op func(a, b []int) []int) func(e []int) []int {
+		return func(e []int) []int {
+			a, b := halves(e)
+			return op(sortedInts(a), sortedInts(b))
+		}
+	}
+	proptest.Check(t, lawInts, proptest.All(
+		proptest.Equivalent(func(e []int) []int { return SortedDedup(sortedInts(e)) }, func(e []int) []int { return Unique(sortedInts(e)) }),
+		proptest.Equivalent(func(e []int) []int { return SortedDedupFunc(sortedInts(e), compareInts) }, func(e []int) []int { return Compact(sortedInts(e)) }),
+		proptest.Equivalent(sortedPair(SortedUnion[int]), setOp(func(inA, inB bool) bool { return inA || inB })),
+		proptest.Equivalent(sortedPair(SortedIntersect[int]), setOp(func(inA, inB bool) bool { return inA && inB })),
+		proptest.Equivalent(sortedPair(SortedDifference[int]), setOp(func(inA, inB bool) bool { return inA && !inB })),
+		proptest.Equivalent(sortedPair(func(a, b []int) []int { return SortedUnionFunc(a, b, compareInts) }), sortedPair(SortedUnion[int])),
+		proptest.Equivalent(sortedPair(func(a, b []int) []int { return SortedIntersectFunc(a, b, compareInts) }), sortedPair(SortedIntersect[int])),
+		proptest.Equivalent(sortedPair(func(a, b []int) []int { return SortedDifferenceFunc(a, b, compareInts) }), sortedPair(SortedDifference[int])),
+		proptest.HasNoDuplicates(sortedPair(SortedUnion[int])),
+		proptest.Equivalent(func(e []int) []int {
+			return SortedMergeK(Map(Chunk(e, 5), sortedInts)...)
+		}, sortedInts),
+		proptest.Equivalent(func(e []int) []int {
+			return SortedMergeKFunc(compareInts, Map(Chunk(e, 3), sortedInts)...)
+		}, sortedInts),
+	))
+}
+
+func TestLawsAggregate(t *testing.T) {
+	t.Parallel()
+
+	add := func(a, b int) int { return a + b }
+	proptest.Check(t, lawInts, proptest.All(
+		proptest.Equivalent(func(e []int) int { return FoldLeft(e, 0, add) }, Sum[int]),
+		proptest.Equivalent(func(e []int) int { return FoldRight(e, 0, add) }, Sum[int]),
+		proptest.Equivalent(func(e []int) int { v, _ := Reduce(e, add); return v }, Sum[int]),
+		proptest.Equivalent(func(e []int) int { return FoldLeft(e, 1, func(a, b int) int { return a * b }) }, Product[int]),
+		proptest.Equivalent(func(e []int) []int {
+			return FoldRight(e, []int(nil), func(v int, acc []int) []int { return append(acc, v) })
+		}, Reverse[int]),
+		proptest.PreservesLength(func(e []int) []int { return Scan(e, 0, add) }),
+		proptest.Equivalent(func(e []int) []int {
+			scan := Scan(e, 0, add)
+			return scan[max(0, len(scan)-1):]
+		}, func(e []int) []int {
+			if len(e) == 0 {
+				return nil
+			}
+			return []int{Sum(e)}
+		}),
+		proptest.Equivalent(func(e []int) []int {
+			lo, _ := Min(e)
+			hi, _ := Max(e)
+			return []int{lo, hi}
+		}, func(e []int) []int {
+			if len(e) == 0 {
+				return []int{0, 0}
+			}
+			return []int{slices.Min(e), slices.Max(e)}
+		}),
+		func(e []int) error {
+			if avg, ok := Average(e); ok && (avg < float64(slices.Min(e)) || avg > float64(slices.Max(e))) {
+				return fmt.Errorf("average %v outside of [min, max]", avg)
+			}
+			return nil
+		},
+	))
+
+	// MinBy and MaxBy return the first element with the extreme key.
+	proptest.Check(t, lawRecords, proptest.All(
+		proptest.Equivalent(func(e []proptest.Record) []proptest.Record {
+			v, ok := MinBy(e, recordIDLaw)
+			if !ok {
+				return nil
+			}
+			return []proptest.Record{v}
+		}, func(e []proptest.Record) []proptest.Record {
+			if len(e) == 0 {
+				return nil
+			}
+			minID := slices.Min(Map(e, recordIDLaw))
+			return Filter(e, func(r proptest.Record) bool { return r.ID == minID })[:1]
+		}),
+		proptest.Equivalent(func(e []proptest.Record) []proptest.Record {
+			v, ok := MaxBy(e, recordIDLaw)
+			if !ok {
+				return nil
+			}
+			return []proptest.Record{v}
+		}, func(e []proptest.Record) []proptest.Record {
+			if len(e) == 0 {
+				return nil
+			}
+			maxID := slices.Max(Map(e, recordIDLaw))
+			return Filter(e, func(r proptest.Record) bool { return r.ID == maxID })[:1]
+		}),
+	))
+}
+
+func TestLawsGroup(t *testing.T) {
+	t.Parallel()
+
+	proptest.Check(t, lawRecords, proptest.All(
+		// The groups together hold every element exactly once, and every group only holds its key.
+		proptest.PreservesElements(func(e []proptest.Record) []proptest.Record {
+			return concat(Map(GroupByOrdered(e, recordIDLaw), func(g Group[int, proptest.Record]) []proptest.Record { return g.Elements }))
+		}),
+		func(e []proptest.Record) error {
+			for key, group := range GroupBy(e, recordIDLaw) {
+				if !slices.Equal(group, Filter(e, func(r proptest.Record) bool { return r.ID == key })) {
+					return fmt.Errorf("group %d = %v does not hold exactly the elements with its key", key, group)
+				}
+			}
+			return nil
+		},
+		proptest.Equivalent(func(e []proptest.Record) []int {
+			return Map(GroupByOrdered(e, recordIDLaw), func(g Group[int, proptest.Record]) int { return g.Key })
+		}, func(e []proptest.Record) []int { return Unique(Map(e, recordIDLaw)) }),
+		proptest.PartitionsInput(func(e []proptest.Record, p func(proptest.Record) bool) []proptest.Record {
+			yes, _ := Partition(e, p)
+			return yes
+		}, func(r proptest.Record) bool { return r.ID%2 == 0 }),
+		proptest.Equivalent(func(e []proptest.Record) map[int]proptest.Record {
+			result, _ := KeyBy(e, recordIDLaw, KeepFirst)
+			return result
+		}, func(e []proptest.Record) map[int]proptest.Record {
+			result := make(map[int]proptest.Record)
+			for _, r := range UniqueBy(e, recordIDLaw) {
+				result[r.ID] = r
+			}
+			return result
+		}),
+		proptest.Equivalent(func(e []proptest.Record) map[int]int { return CountBy(e, recordIDLaw) }, func(e []proptest.Record) map[int]int {
+			result := make(map[int]int)
+			for key, group := range GroupBy(e, recordIDLaw) {
+				result[key] = len(group)
+			}
+			return result
+		}),
+	))
+}
+
+func TestLawsChunk(t *testing.T) {
+	t.Parallel()
+
+	weight := func(v int) int { return v%4 + 1 }
+	proptest.Check(t, lawInts, proptest.All(
+		// Splitting and joining again must give the input back.
+		proptest.Equivalent(func(e []int) []int { return concat(Chunk(e, 3)) }, identity[[]int]),
+		proptest.Equivalent(func(e []int) []int { return concat(ChunkBy(e, isEvenLaw)) }, identity[[]int]),
+		proptest.Equivalent(func(e []int) []int { return concat(Batch(e, 4, 6, weight)) }, identity[[]int]),
+		proptest.Equivalent(func(e []int) []int { return concat(SlidingWindow(e, 2, 2)) }, func(e []int) []int { return e[:len(e)/2*2] }),
+		// The Seq variants must yield the same views.
+		proptest.Equivalent(func(e []int) [][]int { return slices.Collect(ChunkSeq(e, 3)) }, func(e []int) [][]int { return Chunk(e, 3) }),
+		proptest.Equivalent(func(e []int) [][]int { return slices.Collect(ChunkBySeq(e, isEvenLaw)) }, func(e []int) [][]int { return ChunkBy(e, isEvenLaw) }),
+		proptest.Equivalent(func(e []int) [][]int { return slices.Collect(SlidingWindowSeq(e, 3, 1)) }, func(e []int) [][]int { return SlidingWindow(e, 3, 1) }),
+		proptest.Equivalent(func(e []int) [][]int { return slices.Collect(BatchSeq(e, 4, 6, weight)) }, func(e []int) [][]int { return Batch(e, 4, 6, weight) }),
+		// Every window has the requested size and every batch respects its limits.
+		func(e []int) error {
+			for _, w := range SlidingWindow(e, 3, 1) {
+				if len(w) != 3 {
+					return fmt.Errorf("window %v has the wrong size", w)
+				}
+			}
+			for _, batch := range Batch(e, 4, 6, weight) {
+				if len(batch) > 4 || (len(batch) > 1 && Sum(Map(batch, weight)) > 6) {
+					return fmt.Errorf("batch %v exceeds its limits", batch)
+				}
+			}
+			return nil
+		},
+	))
+}
+
+func TestLawsZip(t *testing.T) {
+	t.Parallel()
+
+	proptest.Check(t, lawInts, proptest.All(
+		// Zipping and unzipping again must give both inputs back.
+		proptest.Equivalent(func(e []int) [][]int {
+			a, b := halves(e)
+			pairs, _ := Zip(a, b[:len(a)], RequireEqualLength)
+			first, second := Unzip(pairs)
+			return [][]int{first, second}
+		}, func(e []int) [][]int {
+			a, b := halves(e)
+			return [][]int{a, b[:len(a)]}
+		}),
+		proptest.Equivalent(func(e []int) [][]int {
+			triples, _ := Zip3(e, Reverse(e), e, RequireEqualLength)
+			first, second, third := Unzip3(triples)
+			return [][]int{first, second, third}
+		}, func(e []int) [][]int { return [][]int{e, Reverse(e), e} }),
+		proptest.Equivalent(func(e []int) []int {
+			a, b := halves(e)
+			sums, _ := ZipWith(a, b, TruncateToShortest, func(x, y int) int { return x + y })
+			return sums
+		}, func(e []int) []int {
+			a, b := halves(e)
+			return Map(Enumerate(a), func(p Pair[int, int]) int { return p.Second + b[p.First] })
+		}),
+		proptest.Equivalent(func(e []int) int {
+			a, b := halves(e)
+			return len(ZipLongest(a, b[:len(b)/2], -1, -1))
+		}, func(e []int) int {
+			a, b := halves(e)
+			return max(len(a), len(b)/2)
+		}),
+		proptest.Equivalent(func(e []int) int {
+			a, b := halves(e)
+			return len(CartesianProduct(a, b))
+		}, func(e []int) int {
+			a, b := halves(e)
+			return len(a) * len(b)
+		}),
+		proptest.Equivalent(func(e []int) []int { return Map(Enumerate(e), func(p Pair[int, int]) int { return p.Second }) }, identity[[]int]),
+	))
+}
+
+func TestLawsInPlace(t *testing.T) {
+	t.Parallel()
+
+	proptest.Check(t, lawInts, proptest.All(
+		proptest.Equivalent(func(e []int) []int { return FilterInPlace(slices.Clone(e), isEvenLaw) }, func(e []int) []int {
+			return Filter(e, isEvenLaw)
+		}),
+		proptest.Idempotent(Compact[int]),
+		proptest.IsSubsequence(Compact[int]),
+		proptest.DoesNotMutate(Compact[int]),
+		proptest.Equivalent(func(e []int) []int { return CompactInPlace(slices.Clone(e)) }, Compact[int]),
+		proptest.Equivalent(func(e []int) []int { return Compact(sortedInts(e)) }, func(e []int) []int { return Unique(sortedInts(e)) }),
+		// Reverse is an involution that only reorders the elements.
+		proptest.Equivalent(func(e []int) []int { return Reverse(Reverse(e)) }, identity[[]int]),
+		proptest.PreservesElements(Reverse[int]),
+		proptest.DoesNotMutate(Reverse[int]),
+		proptest.Equivalent(func(e []int) []int { c := slices.Clone(e); ReverseInPlace(c); return c }, Reverse[int]),
+		// Rotating back and forth by any amount must give the input back.
+		proptest.Equivalent(func(e []int) []int { return Rotate(Rotate(e, 7), -7) }, identity[[]int]),
+		proptest.PreservesElements(func(e []int) []int { return Rotate(e, 3) }),
+		proptest.DoesNotMutate(func(e []int) []int { return Rotate(e, 3) }),
+		proptest.Equivalent(func(e []int) []int { c := slices.Clone(e); RotateInPlace(c, -5); return c }, func(e []int) []int {
+			return Rotate(e, -5)
+		}),
+	))
+}
@@ -0,0 +1,108 @@
+package slice
+
+import (
+	"iter"
+
+	"github.com/spacemagneto/common/slice/seq"
+)
+
+// Pipeline is a lazy, chainable sequence of slice operations.
+// Every step only records how the elements should be processed, and nothing is evaluated until a terminal
+// operation such as Collect, Count or First is called. The recorded steps are fused into a single pass over
+// the source, so no intermediate slice is allocated and the source stops being read as soon as the result is known.
+// A Pipeline is an immutable value: every step returns a new Pipeline and leaves the receiver untouched.
+type Pipeline[T any] struct {
+	// seq produces the elements of the pipeline on demand.
+	seq iter.Seq[T]
+}
+
+// From starts a pipeline over the elements of the provided slice.
+// The slice is only read when a terminal operation is executed and is never modified.
+func From[T any](elements []T) Pipeline[T] {
+	return Pipeline[T]{seq: seq.FromSlice(elements)}
+}
+
+// FromSeq starts a pipeline over the values produced by the provided sequence.
+func FromSeq[T any](values iter.Seq[T]) Pipeline[T] {
+	return Pipeline[T]{seq: values}
+}
+
+// Filter adds a step that keeps only the elements satisfying the predicate function.
+// It applies the same semantics as the Filter function, lazily.
+func (p Pipeline[T]) Filter(fn func(T) bool) Pipeline[T] {
+	return Pipeline[T]{seq: seq.Filter(p.seq, fn)}
+}
+
+// Map adds a step that transforms every element without changing its type.
+// Use MapTo to change the element type, since methods cannot declare their own type parameters.
+func (p Pipeline[T]) Map(fn func(T) T) Pipeline[T] {
+	return Pipeline[T]{seq: seq.Map(p.seq, fn)}
+}
+
+// Take adds a step that stops the pipeline after at most n elements have passed through it.
+// Once the limit is reached no further element is read from the source.
+func (p Pipeline[T]) Take(n int) Pipeline[T] {
+	return Pipeline[T]{seq: seq.Take(p.seq, n)}
+}
+
+// Skip adds a step that discards the first n elements reaching it.
+func (p Pipeline[T]) Skip(n int) Pipeline[T] {
+	return Pipeline[T]{seq: seq.Skip(p.seq, n)}
+}
+
+// Seq returns the pipeline as a sequence, so it can be ranged over or passed to the seq package.
+func (p Pipeline[T]) Seq() iter.Seq[T] {
+	return p.seq
+}
+
+// Collect evaluates the pipeline and returns the resulting elements in a new slice.
+// A pipeline that produces no elements returns a nil slice, matching the Filter function.
+func (p Pipeline[T]) Collect() []T {
+	return seq.Collect(p.seq)
+}
+
+// Count evaluates the pipeline and returns the number of elements it produces.
+func (p Pipeline[T]) Count() int {
+	// Initialize the counter for the produced elements.
+	count := 0
+
+	// Drain the pipeline, counting every element without storing it.
+	for range p.seq {
+		count++
+	}
+
+	// Return the number of produced elements.
+	return count
+}
+
+// First evaluates the pipeline up to its first element and returns it.
+// The boolean result is false when the pipeline produces no elements.
+func (p Pipeline[T]) First() (T, bool) {
+	// Return the first produced element, which also stops the source from being read further.
+	for v := range p.seq {
+		return v, true
+	}
+
+	// The pipeline is empty, so return the zero value of the element type.
+	var zero T
+	return zero, false
+}
+
+// MapTo adds a step that transforms every element of the pipeline into a different type.
+// It is a function rather than a method because Go methods cannot declare their own type parameters.
+func MapTo[A, B any](p Pipeline[A], fn func(A) B) Pipeline[B] {
+	return Pipeline[B]{seq: seq.Map(p.seq, fn)}
+}
+
+// PipeUnique adds a step that removes duplicate elements, preserving the order of first occurrence.
+// It applies the same semantics as the Unique function and requires a comparable element type,
+// which is why it cannot be expressed as a method of Pipeline.
+func PipeUnique[T comparable](p Pipeline[T]) Pipeline[T] {
+	return Pipeline[T]{seq: seq.Unique(p.seq)}
+}
+
+// PipeExclude adds a step that removes every element equal to the specified value.
+// It applies the same semantics as the Exclude function, but never modifies the source slice.
+func PipeExclude[T comparable](p Pipeline[T], element T) Pipeline[T] {
+	return Pipeline[T]{seq: seq.Exclude(p.seq, element)}
+}
@@ -0,0 +1,130 @@
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	// Steps tests the individual pipeline steps against the eager functions they wrap.
+	// Each case builds a pipeline and the expected result is computed with the existing helpers,
+	// so the lazy and the eager implementations must agree.
+	t.Run("Steps", func(t *testing.T) {
+		// Define a shared input containing duplicates, evens and odds.
+		input := []int{1, 2, 2, 3, 4, 4, 5, 6}
+		isEven := func(n int) bool { return n%2 == 0 }
+		double := func(n int) int { return n * 2 }
+
+		cases := []struct {
+			name     string
+			pipeline Pipeline[int]
+			expected []int
+		}{
+			{name: "From only", pipeline: From(input), expected: []int{1, 2, 2, 3, 4, 4, 5, 6}},
+			{name: "Filter", pipeline: From(input).Filter(isEven), expected: Filter(input, isEven)},
+			{name: "Map", pipeline: From(input).Map(double), expected: Map(input, double)},
+			{name: "Unique", pipeline: PipeUnique(From(input)), expected: Unique(input)},
+			{name: "Exclude", pipeline: PipeExclude(From(input), 4), expected: []int{1, 2, 2, 3, 5, 6}},
+			{name: "Take", pipeline: From(input).Take(3), expected: []int{1, 2, 2}},
+			{name: "Skip", pipeline: From(input).Skip(6), expected: []int{5, 6}},
+			{name: "Chained", pipeline: PipeUnique(From(input).Filter(isEven)).Map(double).Take(2), expected: []int{4, 8}},
+			{name: "Nil source", pipeline: From[int](nil).Filter(isEven), expected: nil},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				// Evaluate the pipeline and compare it with the eager result.
+				assert.Equal(t, tt.expected, tt.pipeline.Collect(), "Pipeline result for case %q did not match", tt.name)
+			})
+		}
+	})
+
+	// MapTo tests the typed step that changes the element type of the pipeline.
+	t.Run("MapTo", func(t *testing.T) {
+		// Convert integers into labels after filtering.
+		result := MapTo(From([]int{1, 2, 3, 4}).Filter(func(n int) bool { return n > 2 }), func(n int) string {
+			return fmt.Sprintf("Num:%d", n)
+		}).Collect()
+
+		// The labels must follow the order of the filtered input.
+		assert.Equal(t, []string{"Num:3", "Num:4"}, result, "MapTo should change the element type")
+	})
+
+	// SinglePass tests that the steps are fused into one pass and that the source stops being read early.
+	t.Run("SinglePass", func(t *testing.T) {
+		// Count how many times each step is invoked.
+		var filterCalls, mapCalls int
+		input := createSequenceWithoutRepeats(1000)
+
+		// Build a pipeline that only needs the first three matching elements.
+		result := From(input).
+			Filter(func(n int) bool { filterCalls++; return n%2 == 0 }).
+			Map(func(n int) int { mapCalls++; return n * 10 }).
+			Take(3).
+			Collect()
+
+		// The first three even elements are 2, 4 and 6, which are reached after reading six source elements.
+		assert.Equal(t, []int{20, 40, 60}, result, "Pipeline should produce the first three transformed evens")
+		assert.Equal(t, 6, filterCalls, "Filter should stop once Take is satisfied")
+		assert.Equal(t, 3, mapCalls, "Map should only run on the elements that passed the filter")
+	})
+
+	// SourceUntouched tests that excluding through a pipeline never rewrites the caller's slice,
+	// unlike the eager Exclude function which reuses the backing array.
+	t.Run("SourceUntouched", func(t *testing.T) {
+		// Prepare the input and a copy of it.
+		input := []int{1, 2, 1, 3}
+		original := append([]int(nil), input...)
+
+		// Exclude a value through the pipeline.
+		result := PipeExclude(From(input), 1).Collect()
+
+		// The result is filtered while the input is left as it was.
+		assert.Equal(t, []int{2, 3}, result, "PipeExclude should remove the value")
+		assert.Equal(t, original, input, "The source slice should not be modified")
+	})
+
+	// Terminals tests the Count, First and Seq terminal operations.
+	t.Run("Terminals", func(t *testing.T) {
+		// Build a pipeline that keeps the odd elements.
+		odd := From([]int{2, 3, 4, 5, 7}).Filter(func(n int) bool { return n%2 != 0 })
+
+		// Count every produced element.
+		assert.Equal(t, 3, odd.Count(), "Count should report the number of odd elements")
+
+		// First must return the first produced element.
+		first, ok := odd.First()
+		assert.True(t, ok, "First should report a produced element")
+		assert.Equal(t, 3, first, "First should return the first odd element")
+
+		// First on an empty pipeline reports that nothing was produced.
+		_, ok = From([]int{2, 4}).Filter(func(n int) bool { return n%2 != 0 }).First()
+		assert.False(t, ok, "First should report an empty pipeline")
+
+		// Ranging over Seq yields the same elements as Collect.
+		var ranged []int
+		for v := range odd.Seq() {
+			ranged = append(ranged, v)
+		}
+		assert.Equal(t, odd.Collect(), ranged, "Seq should yield the same elements as Collect")
+	})
+
+	// Immutable tests that adding a step does not change the pipeline it was added to.
+	t.Run("Immutable", func(t *testing.T) {
+		// Build a base pipeline and derive a limited one from it.
+		base := From([]int{1, 2, 3})
+		limited := base.Take(1)
+
+		// The base pipeline still produces every element.
+		assert.Equal(t, []int{1, 2, 3}, base.Collect(), "The base pipeline should not be affected")
+		assert.Equal(t, []int{1}, limited.Collect(), "The derived pipeline should be limited")
+
+		// FromSeq accepts any sequence, including another pipeline.
+		assert.Equal(t, []int{1}, FromSeq(limited.Seq()).Collect(), "FromSeq should wrap an existing sequence")
+	})
+}
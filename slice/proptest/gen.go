@@ -0,0 +1,206 @@
+package proptest
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// Record is the struct element type produced by Records. It is comparable, so it can be used with Unique,
+// Exclude and the other functions that require comparable elements.
+type Record struct {
+	ID   int
+	Name string
+}
+
+// Ints generates integers in the closed range [lo, hi] and shrinks them towards the value of the range
+// closest to zero.
+func Ints(lo, hi int) Gen[int] {
+	return Gen[int]{
+		Generate: func(r *rand.Rand, _ int) int {
+			// Compute the width of the range in uint64, where it cannot overflow even for [math.MinInt,
+			// math.MaxInt], and draw any uint64 when the range covers every one of them.
+			span := uint64(hi) - uint64(lo)
+			if span == math.MaxUint64 {
+				return int(r.Uint64())
+			}
+			return lo + int(r.Uint64N(span+1))
+		},
+		Shrink: func(v int) iter.Seq[int] {
+			return shrinkInt(v, clamp(0, lo, hi))
+		},
+	}
+}
+
+// Strings generates strings of up to maxLen runes drawn from the alphabet, and shrinks them by removing runes
+// and by replacing runes with the first rune of the alphabet.
+func Strings(alphabet string, maxLen int) Gen[string] {
+	runes := []rune(alphabet)
+	return Gen[string]{
+		Generate: func(r *rand.Rand, _ int) string {
+			s := make([]rune, r.IntN(maxLen+1))
+			for i := range s {
+				s[i] = runes[r.IntN(len(runes))]
+			}
+			return string(s)
+		},
+		Shrink: func(v string) iter.Seq[string] {
+			return func(yield func(string) bool) {
+				elems := Gen[rune]{Shrink: func(c rune) iter.Seq[rune] {
+					return func(yield func(rune) bool) {
+						if c != runes[0] {
+							yield(runes[0])
+						}
+					}
+				}}
+				for s := range shrinkSlice(elems, []rune(v)) {
+					if !yield(string(s)) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// SliceOf generates slices of elements produced by elem. The length of the slices grows with the size hint,
+// up to maxLen. Failing slices shrink by dropping chunks of elements, then by shrinking single elements.
+// An empty slice is generated as nil.
+func SliceOf[T any](elem Gen[T], maxLen int) Gen[[]T] {
+	return Gen[[]T]{
+		Generate: func(r *rand.Rand, size int) []T {
+			n := r.IntN(min(size, maxLen) + 1)
+			if n == 0 {
+				return nil
+			}
+			s := make([]T, n)
+			for i := range s {
+				s[i] = elem.Generate(r, size)
+			}
+			return s
+		},
+		Shrink: func(v []T) iter.Seq[[]T] {
+			return shrinkSlice(elem, v)
+		},
+	}
+}
+
+// IntSlices generates slices of up to maxLen integers in [0, maxValue]. A small maxValue yields many duplicates.
+func IntSlices(maxLen, maxValue int) Gen[[]int] {
+	return SliceOf(Ints(0, maxValue), maxLen)
+}
+
+// StringSlices generates slices of up to maxLen short strings over a small alphabet, so duplicates are common.
+func StringSlices(maxLen int) Gen[[]string] {
+	return SliceOf(Strings("abc", 3), maxLen)
+}
+
+// Records generates slices of up to maxLen records whose IDs are in [0, maxID] and whose names are short
+// strings, so records that are equal, share an ID or share a name are all common.
+func Records(maxLen, maxID int) Gen[[]Record] {
+	ids, names := Ints(0, maxID), Strings("xy", 2)
+	elem := Gen[Record]{
+		Generate: func(r *rand.Rand, size int) Record {
+			return Record{ID: ids.Generate(r, size), Name: names.Generate(r, size)}
+		},
+		Shrink: func(v Record) iter.Seq[Record] {
+			return func(yield func(Record) bool) {
+				for id := range ids.Shrink(v.ID) {
+					if !yield(Record{ID: id, Name: v.Name}) {
+						return
+					}
+				}
+				for name := range names.Shrink(v.Name) {
+					if !yield(Record{ID: v.ID, Name: name}) {
+						return
+					}
+				}
+			}
+		},
+	}
+	return SliceOf(elem, maxLen)
+}
+
+// SequenceWithRepeats returns a slice of the integers [0, size) in which every position that is a multiple of
+// period holds repeatedElement instead, which is the shape of the createSequenceWithRepeats fixture of the
+// slice tests with a configurable period.
+func SequenceWithRepeats(size, period, repeatedElement int) []int {
+	// Initialize a slice with the specified size.
+	sequence := make([]int, size)
+
+	// Insert the repeated element at every multiple of the period and the index everywhere else.
+	for i := range sequence {
+		if i%period == 0 {
+			sequence[i] = repeatedElement
+		} else {
+			sequence[i] = i
+		}
+	}
+	return sequence
+}
+
+// Repeating generates slices shaped by SequenceWithRepeats with a random length of up to maxLen, a random period
+// and a random repeated element, optionally shuffled. Failing slices shrink like the ones of IntSlices.
+func Repeating(maxLen int) Gen[[]int] {
+	shrinkable := IntSlices(maxLen, maxLen)
+	return Gen[[]int]{
+		Generate: func(r *rand.Rand, size int) []int {
+			n := r.IntN(min(size, maxLen) + 1)
+			if n == 0 {
+				return nil
+			}
+			s := SequenceWithRepeats(n, 1+r.IntN(max(1, n/2)), r.IntN(n))
+			if r.IntN(2) == 0 {
+				r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+			}
+			return s
+		},
+		Shrink: shrinkable.Shrink,
+	}
+}
+
+// shrinkInt yields candidates between v and target, starting with target itself and halving the distance.
+func shrinkInt(v, target int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for diff := v - target; diff != 0; diff /= 2 {
+			if !yield(v - diff) {
+				return
+			}
+		}
+	}
+}
+
+// shrinkSlice yields shorter slices first, by removing chunks of halving length, and then slices in which a
+// single element was shrunk with elem.
+func shrinkSlice[T any](elem Gen[T], v []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		// Try to remove ever smaller chunks at every position.
+		for chunk := len(v); chunk > 0; chunk /= 2 {
+			for start := 0; start+chunk <= len(v); start += chunk {
+				candidate := append(append([]T(nil), v[:start]...), v[start+chunk:]...)
+				if !yield(candidate) {
+					return
+				}
+			}
+		}
+
+		// Try to shrink every element in place.
+		if elem.Shrink == nil {
+			return
+		}
+		for i := range v {
+			for e := range elem.Shrink(v[i]) {
+				candidate := append([]T(nil), v...)
+				candidate[i] = e
+				if !yield(candidate) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// clamp restricts v to the closed range [lo, hi].
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
@@ -0,0 +1,222 @@
+package proptest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// All combines properties into one that reports the first violation, so several laws can be checked against
+// the same generated values.
+func All[T any](props ...Property[T]) Property[T] {
+	return func(value T) error {
+		for _, prop := range props {
+			if err := prop(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Idempotent checks that applying fn twice gives the same result as applying it once, as for Unique or Compact.
+func Idempotent[T comparable](fn func([]T) []T) Property[[]T] {
+	return func(elements []T) error {
+		once := fn(clone(elements))
+		twice := fn(clone(once))
+		if !slices.Equal(once, twice) {
+			return fmt.Errorf("not idempotent: f(x) = %v, f(f(x)) = %v", once, twice)
+		}
+		return nil
+	}
+}
+
+// PreservesLength checks that fn returns as many elements as it receives, as for Map or Reverse.
+func PreservesLength[A, B any](fn func([]A) []B) Property[[]A] {
+	return func(elements []A) error {
+		if result := fn(clone(elements)); len(result) != len(elements) {
+			return fmt.Errorf("length changed from %d to %d", len(elements), len(result))
+		}
+		return nil
+	}
+}
+
+// PartitionsInput checks that splitting the input with filter and pred, and with filter and the negated pred,
+// gives two slices that together are a permutation of the input, as for Filter or Partition.
+func PartitionsInput[T comparable](filter func([]T, func(T) bool) []T, pred func(T) bool) Property[[]T] {
+	return func(elements []T) error {
+		yes := filter(clone(elements), pred)
+		no := filter(clone(elements), func(v T) bool { return !pred(v) })
+		if !IsPermutation(append(clone(yes), no...), elements) {
+			return fmt.Errorf("filter(p) = %v and filter(!p) = %v are not a partition of the input", yes, no)
+		}
+		for _, v := range yes {
+			if !pred(v) {
+				return fmt.Errorf("filter(p) kept %v, which does not satisfy p", v)
+			}
+		}
+		return nil
+	}
+}
+
+// IsSubsequence checks that fn returns elements of its input in their original relative order, possibly with
+// some of them removed, as for Filter, Unique or Exclude.
+func IsSubsequence[T comparable](fn func([]T) []T) Property[[]T] {
+	return func(elements []T) error {
+		result := fn(clone(elements))
+		i := 0
+		for _, v := range elements {
+			if i < len(result) && result[i] == v {
+				i++
+			}
+		}
+		if i != len(result) {
+			return fmt.Errorf("%v is not a subsequence of the input", result)
+		}
+		return nil
+	}
+}
+
+// HasNoDuplicates checks that fn returns no element twice, as for Unique or the sorted set operations.
+func HasNoDuplicates[T comparable, A any](fn func(A) []T) Property[A] {
+	return func(input A) error {
+		result := fn(input)
+		seen := make(map[T]struct{}, len(result))
+		for i, v := range result {
+			if _, ok := seen[v]; ok {
+				return fmt.Errorf("element %v is repeated at index %d of %v", v, i, result)
+			}
+			seen[v] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// PreservesElements checks that fn returns a permutation of its input, as for Reverse, Rotate or a sort.
+func PreservesElements[T comparable](fn func([]T) []T) Property[[]T] {
+	return func(elements []T) error {
+		if result := fn(clone(elements)); !IsPermutation(result, elements) {
+			return fmt.Errorf("%v is not a permutation of the input", result)
+		}
+		return nil
+	}
+}
+
+// DoesNotMutate checks that fn leaves its input untouched, for the functions that document that they never
+// modify their input.
+func DoesNotMutate[T, R any](fn func([]T) R) Property[[]T] {
+	return func(elements []T) error {
+		input := clone(elements)
+		fn(input)
+		if !reflect.DeepEqual(input, elements) {
+			return fmt.Errorf("input changed from %v to %v", elements, input)
+		}
+		return nil
+	}
+}
+
+// Equivalent checks that fn and model return deeply equal results, where a nil and an empty slice are
+// considered equal. It compares an implementation with a simpler reference, such as UniqueInto with Unique.
+// Each of them receives its own copy of the input, so an implementation working in place cannot change the
+// input of the other.
+func Equivalent[T, R any](fn, model func([]T) R) Property[[]T] {
+	return func(elements []T) error {
+		got, want := fn(clone(elements)), model(clone(elements))
+		if !equalValues(reflect.ValueOf(got), reflect.ValueOf(want)) {
+			return fmt.Errorf("got %v, want %v", got, want)
+		}
+		return nil
+	}
+}
+
+// NoPanic checks that fn returns normally, which is the minimum contract of every function on any input.
+func NoPanic[A any](fn func(A)) Property[A] {
+	return func(input A) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.New(fmt.Sprint("panic: ", r))
+			}
+		}()
+		fn(input)
+		return nil
+	}
+}
+
+// IsPermutation reports whether a and b hold the same elements with the same multiplicities, in any order.
+func IsPermutation[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	// Count the elements of a and consume the counts with the elements of b.
+	counts := make(map[T]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		if counts[v] == 0 {
+			return false
+		}
+		counts[v]--
+	}
+	return true
+}
+
+// clone copies the slice, keeping nil as nil, so a property can pass its input to a function that may modify it.
+func clone[T any](elements []T) []T {
+	if elements == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(elements)), elements...)
+}
+
+// equalValues is reflect.DeepEqual, except that a nil and an empty slice are equal at any depth.
+func equalValues(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := range a.Len() {
+			if !equalValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if !equalValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if !b.MapIndex(key).IsValid() || !equalValues(a.MapIndex(key), b.MapIndex(key)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return equalValues(a.Elem(), b.Elem())
+	default:
+		if a.CanInterface() && b.CanInterface() {
+			return reflect.DeepEqual(a.Interface(), b.Interface())
+		}
+		return reflect.DeepEqual(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
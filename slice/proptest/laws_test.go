@@ -0,0 +1,107 @@
+package proptest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaws(t *testing.T) {
+	t.Parallel()
+
+	// dedup is a correct reference implementation used to check that the laws accept valid functions.
+	dedup := func(elements []int) []int {
+		var result []int
+		for _, v := range elements {
+			if !slices.Contains(result, v) {
+				result = append(result, v)
+			}
+		}
+		return result
+	}
+	filter := func(elements []int, pred func(int) bool) []int {
+		var result []int
+		for _, v := range elements {
+			if pred(v) {
+				result = append(result, v)
+			}
+		}
+		return result
+	}
+	isEven := func(v int) bool { return v%2 == 0 }
+	reverseInPlace := func(elements []int) []int { slices.Reverse(elements); return elements }
+
+	// Define pairs of properties with the expected outcome, to check both the acceptance and the rejection of
+	// every law.
+	cases := []struct {
+		name  string
+		prop  Property[[]int]
+		holds bool
+	}{
+		{name: "Idempotent dedup", prop: Idempotent(dedup), holds: true},
+		{name: "Idempotent reverse", prop: Idempotent(reverseInPlace), holds: false},
+		{name: "PreservesLength reverse", prop: PreservesLength(reverseInPlace), holds: true},
+		{name: "PreservesLength dedup", prop: PreservesLength(dedup), holds: false},
+		{name: "PartitionsInput filter", prop: PartitionsInput(filter, isEven), holds: true},
+		{name: "PartitionsInput lossy filter", prop: PartitionsInput(func(e []int, p func(int) bool) []int { return dedup(filter(e, p)) }, isEven), holds: false},
+		{name: "IsSubsequence dedup", prop: IsSubsequence(dedup), holds: true},
+		{name: "IsSubsequence reverse", prop: IsSubsequence(reverseInPlace), holds: false},
+		{name: "HasNoDuplicates dedup", prop: HasNoDuplicates(dedup), holds: true},
+		{name: "HasNoDuplicates identity", prop: HasNoDuplicates(func(e []int) []int { return e }), holds: false},
+		{name: "PreservesElements reverse", prop: PreservesElements(reverseInPlace), holds: true},
+		{name: "PreservesElements dedup", prop: PreservesElements(dedup), holds: false},
+		{name: "DoesNotMutate dedup", prop: DoesNotMutate(dedup), holds: true},
+		{name: "DoesNotMutate reverse", prop: DoesNotMutate(reverseInPlace), holds: false},
+		{name: "Equivalent", prop: Equivalent(dedup, func(e []int) []int { return slices.Compact(slices.Clone(e)) }), holds: false},
+		{name: "NoPanic", prop: NoPanic(func(e []int) { _ = e[0] }), holds: false},
+		{name: "All", prop: All(Idempotent(dedup), HasNoDuplicates(dedup), DoesNotMutate(dedup)), holds: true},
+	}
+
+	// Run every law against generated slices with many duplicates.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := Run(IntSlices(20, 4), tt.prop, WithSeed(42))
+			if tt.holds {
+				assert.Nil(t, failure, "Law %q should hold", tt.name)
+			} else {
+				assert.NotNil(t, failure, "Law %q should be violated", tt.name)
+			}
+		})
+	}
+
+	// Equivalent treats nil and empty slices as equal, but nothing else.
+	t.Run("EquivalentNilAndEmpty", func(t *testing.T) {
+		prop := Equivalent(func([]int) []int { return nil }, func([]int) []int { return []int{} })
+		assert.NoError(t, prop(nil), "A nil and an empty slice should be equivalent")
+
+		nested := Equivalent(func([]int) [][]int { return [][]int{nil} }, func([]int) [][]int { return [][]int{{}} })
+		assert.NoError(t, nested(nil), "Nested nil and empty slices should be equivalent")
+
+		different := Equivalent(func([]int) []int { return []int{1} }, func([]int) []int { return []int{2} })
+		assert.Error(t, different(nil), "Different slices should not be equivalent")
+	})
+
+	// EquivalentInPlace tests that an implementation working in place does not change the input of the model.
+	t.Run("EquivalentInPlace", func(t *testing.T) {
+		zeroFirst := func(e []int) []int {
+			if len(e) > 0 {
+				e[0] = 0
+			}
+			return e
+		}
+		prop := Equivalent(zeroFirst, func(e []int) []int { return append([]int{0}, e[1:]...) })
+		input := []int{5, 6}
+		assert.NoError(t, prop(input), "The model should see the original input")
+		assert.Equal(t, []int{5, 6}, input, "The input should not be modified")
+	})
+}
+
+func TestIsPermutation(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsPermutation([]int{1, 2, 2}, []int{2, 1, 2}), "Reordered elements are a permutation")
+	assert.True(t, IsPermutation[int](nil, []int{}), "Nil and empty slices are permutations of each other")
+	assert.False(t, IsPermutation([]int{1, 1, 2}, []int{1, 2, 2}), "Multiplicities must match")
+	assert.False(t, IsPermutation([]int{1}, []int{1, 1}), "Lengths must match")
+}
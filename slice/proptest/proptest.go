@@ -0,0 +1,190 @@
+// Package proptest is a small property-based testing toolkit for generic collection functions.
+//
+// A Gen produces random values and knows how to shrink them. Check runs a Property against many generated
+// values and, when one fails, shrinks it to a minimal counterexample before failing the test with the seed that
+// reproduces it. The law constructors in laws.go turn common algebraic laws, such as idempotence or length
+// preservation, into reusable properties.
+//
+// The package does not depend on the slice package, so the tests of the slice package can use it.
+package proptest
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// SeedEnv is the environment variable that overrides the seed of every Check, to replay a reported failure.
+const SeedEnv = "PROPTEST_SEED"
+
+const (
+	// DefaultRuns is the number of generated values a property is checked against.
+	DefaultRuns = 200
+	// DefaultMaxSize is the largest size hint passed to the generators.
+	DefaultMaxSize = 64
+	// DefaultMaxShrinks is the maximum number of successful shrinking steps applied to a counterexample.
+	DefaultMaxShrinks = 1000
+)
+
+// Gen generates random values of type T and shrinks failing ones towards simpler values.
+type Gen[T any] struct {
+	// Generate returns a random value. The size hint grows from zero to the configured maximum over the runs,
+	// so the first values are small and cheap to check.
+	Generate func(r *rand.Rand, size int) T
+	// Shrink yields simpler candidates for a value, the most aggressive first. It may be nil when values of the
+	// type cannot be shrunk.
+	Shrink func(value T) iter.Seq[T]
+}
+
+// Property is a predicate over generated values. It returns nil when the value satisfies the property and an
+// error describing the violation otherwise.
+type Property[T any] func(value T) error
+
+// Option configures a Check.
+type Option func(*config)
+
+// config holds the settings selected through Option values.
+type config struct {
+	// runs is the number of generated values to check.
+	runs int
+	// seed initializes the random source, so a failing run can be replayed.
+	seed uint64
+	// maxSize is the largest size hint passed to the generator.
+	maxSize int
+	// maxShrinks bounds the number of shrinking steps.
+	maxShrinks int
+}
+
+// WithRuns sets the number of generated values a property is checked against.
+func WithRuns(n int) Option {
+	return func(c *config) {
+		c.runs = n
+	}
+}
+
+// WithSeed fixes the seed of the random source. By default every Check uses a new seed derived from the clock,
+// unless the PROPTEST_SEED environment variable is set.
+func WithSeed(seed uint64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// WithMaxSize sets the largest size hint passed to the generator, which bounds the length of generated slices.
+func WithMaxSize(n int) Option {
+	return func(c *config) {
+		c.maxSize = n
+	}
+}
+
+// WithMaxShrinks sets the maximum number of shrinking steps applied to a counterexample.
+func WithMaxShrinks(n int) Option {
+	return func(c *config) {
+		c.maxShrinks = n
+	}
+}
+
+// Failure describes a value that violates a property, before and after shrinking.
+type Failure[T any] struct {
+	// Seed is the seed that reproduces the failure.
+	Seed uint64
+	// Run is the zero-based index of the run that found the failure.
+	Run int
+	// Original is the generated value that first failed.
+	Original T
+	// Shrunk is the simplest failing value found by shrinking, and Err is the violation it reports.
+	Shrunk T
+	Err    error
+	// Shrinks is the number of successful shrinking steps.
+	Shrinks int
+}
+
+// Error formats the failure together with the instructions to replay it.
+func (f *Failure[T]) Error() string {
+	return fmt.Sprintf("property failed after %d runs (seed %d, replay with %s=%d)\n  original: %#v\n  shrunk (%d steps): %#v\n  error: %v",
+		f.Run+1, f.Seed, SeedEnv, f.Seed, f.Original, f.Shrinks, f.Shrunk, f.Err)
+}
+
+// Unwrap returns the violation reported by the shrunk value.
+func (f *Failure[T]) Unwrap() error {
+	return f.Err
+}
+
+// Check runs the property against values produced by the generator and fails the test with the shrunk
+// counterexample if any of them violates it.
+func Check[T any](t testing.TB, gen Gen[T], prop Property[T], opts ...Option) {
+	t.Helper()
+
+	if failure := Run(gen, prop, opts...); failure != nil {
+		t.Fatal(failure.Error())
+	}
+}
+
+// Run runs the property against values produced by the generator, like Check, and returns the shrunk failure
+// instead of failing a test. It returns nil when every value satisfies the property.
+func Run[T any](gen Gen[T], prop Property[T], opts ...Option) *Failure[T] {
+	// Start from the defaults and apply the options.
+	cfg := config{runs: DefaultRuns, seed: defaultSeed(), maxSize: DefaultMaxSize, maxShrinks: DefaultMaxShrinks}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	r := rand.New(rand.NewPCG(cfg.seed, cfg.seed^0x9e3779b97f4a7c15))
+	for run := 0; run < cfg.runs; run++ {
+		// Grow the size hint over the runs, so small values are tried first.
+		size := cfg.maxSize
+		if cfg.runs > 1 {
+			size = run * cfg.maxSize / (cfg.runs - 1)
+		}
+
+		value := gen.Generate(r, size)
+		err := prop(value)
+		if err == nil {
+			continue
+		}
+
+		// Shrink the counterexample and report the simplest failing value.
+		shrunk, shrunkErr, steps := shrink(gen, prop, value, err, cfg.maxShrinks)
+		return &Failure[T]{Seed: cfg.seed, Run: run, Original: value, Shrunk: shrunk, Err: shrunkErr, Shrinks: steps}
+	}
+	return nil
+}
+
+// shrink greedily replaces the failing value with its first shrink candidate that still fails, until no
+// candidate fails or the step budget is spent.
+func shrink[T any](gen Gen[T], prop Property[T], value T, err error, maxSteps int) (T, error, int) {
+	if gen.Shrink == nil {
+		return value, err, 0
+	}
+
+	steps := 0
+	for steps < maxSteps {
+		progressed := false
+		for candidate := range gen.Shrink(value) {
+			if candidateErr := prop(candidate); candidateErr != nil {
+				value, err = candidate, candidateErr
+				progressed = true
+				break
+			}
+		}
+		if !progressed {
+			break
+		}
+		steps++
+	}
+	return value, err, steps
+}
+
+// defaultSeed returns the seed from the PROPTEST_SEED environment variable, or a new one from the clock.
+func defaultSeed() uint64 {
+	if s, ok := os.LookupEnv(SeedEnv); ok {
+		if seed, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return seed
+		}
+	}
+	return uint64(time.Now().UnixNano())
+}
@@ -0,0 +1,175 @@
+package proptest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fatalRecorder is a testing.TB that records the message of Fatal instead of stopping the test.
+type fatalRecorder struct {
+	testing.TB
+	message string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatal(args ...any) {
+	r.message = fmt.Sprint(args...)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	// Passing tests that a property that always holds reports no failure.
+	t.Run("Passing", func(t *testing.T) {
+		failure := Run(IntSlices(20, 5), func([]int) error { return nil }, WithSeed(1))
+		assert.Nil(t, failure, "A property that always holds should not fail")
+	})
+
+	// ShrinksToMinimalCounterexample tests that shrinking removes every irrelevant element and value.
+	t.Run("ShrinksToMinimalCounterexample", func(t *testing.T) {
+		shortSlices := func(elements []int) error {
+			if len(elements) >= 3 {
+				return fmt.Errorf("length %d", len(elements))
+			}
+			return nil
+		}
+
+		failure := Run(IntSlices(50, 100), shortSlices, WithSeed(7))
+		assert.NotNil(t, failure, "Slices of three or more elements should be generated")
+		assert.Equal(t, []int{0, 0, 0}, failure.Shrunk, "The counterexample should shrink to three zeros")
+		assert.EqualError(t, failure.Err, "length 3", "The error should come from the shrunk value")
+		assert.Positive(t, failure.Shrinks, "At least one shrinking step should succeed")
+	})
+
+	// ShrinksElements tests that single elements shrink towards the smallest failing value.
+	t.Run("ShrinksElements", func(t *testing.T) {
+		noLargeValue := func(elements []int) error {
+			for _, v := range elements {
+				if v >= 42 {
+					return errors.New("large value")
+				}
+			}
+			return nil
+		}
+
+		failure := Run(IntSlices(20, 1000), noLargeValue, WithSeed(3))
+		assert.NotNil(t, failure, "Values of 42 or more should be generated")
+		assert.Equal(t, []int{42}, failure.Shrunk, "The counterexample should shrink to the boundary value")
+	})
+
+	// SameSeedSameValues tests that a seed reproduces the same sequence of values.
+	t.Run("SameSeedSameValues", func(t *testing.T) {
+		record := func(seed uint64) [][]int {
+			var seen [][]int
+			Run(Repeating(30), func(v []int) error { seen = append(seen, v); return nil }, WithSeed(seed), WithRuns(20))
+			return seen
+		}
+		assert.Equal(t, record(11), record(11), "The same seed should generate the same values")
+		assert.NotEqual(t, record(11), record(12), "Different seeds should generate different values")
+	})
+
+	// SizeGrows tests that the size hint grows from zero to the maximum size over the runs.
+	t.Run("SizeGrows", func(t *testing.T) {
+		var sizes []int
+		gen := Gen[int]{Generate: func(_ *rand.Rand, size int) int { sizes = append(sizes, size); return 0 }}
+		Run(gen, func(int) error { return nil }, WithRuns(5), WithMaxSize(8))
+		assert.Equal(t, []int{0, 2, 4, 6, 8}, sizes, "The size hint should grow linearly")
+	})
+
+	// MaxShrinks tests that the number of shrinking steps is bounded.
+	t.Run("MaxShrinks", func(t *testing.T) {
+		failure := Run(Ints(0, 1000), func(v int) error {
+			if v > 0 {
+				return errors.New("positive")
+			}
+			return nil
+		}, WithSeed(5), WithMaxShrinks(0))
+		assert.NotNil(t, failure, "Positive values should be generated")
+		assert.Equal(t, failure.Original, failure.Shrunk, "No shrinking step should be applied")
+	})
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	// A failing Check reports the seed, the original and the shrunk values.
+	recorder := &fatalRecorder{}
+	Check(recorder, StringSlices(10), func(elements []string) error {
+		if len(elements) > 0 {
+			return errors.New("not empty")
+		}
+		return nil
+	}, WithSeed(99))
+
+	assert.Contains(t, recorder.message, "seed 99", "The message should include the seed")
+	assert.Contains(t, recorder.message, SeedEnv+"=99", "The message should explain how to replay the failure")
+	assert.Contains(t, recorder.message, `shrunk (`, "The message should include the shrunk value")
+	assert.True(t, strings.HasSuffix(recorder.message, "error: not empty"), "The message should end with the violation")
+}
+
+func TestGenerators(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(1, 2))
+
+	// Every generator must respect its bounds for every size hint.
+	for size := 0; size <= 64; size++ {
+		ints := IntSlices(10, 3).Generate(r, size)
+		assert.LessOrEqual(t, len(ints), min(size, 10), "IntSlices length for size %d", size)
+		for _, v := range ints {
+			assert.True(t, v >= 0 && v <= 3, "IntSlices value %d out of range", v)
+		}
+
+		for _, s := range StringSlices(10).Generate(r, size) {
+			assert.LessOrEqual(t, len(s), 3, "StringSlices element %q is too long", s)
+			assert.Empty(t, strings.Trim(s, "abc"), "StringSlices element %q uses another alphabet", s)
+		}
+
+		for _, rec := range Records(10, 4).Generate(r, size) {
+			assert.True(t, rec.ID >= 0 && rec.ID <= 4, "Records ID %d out of range", rec.ID)
+		}
+
+		repeating := Repeating(40).Generate(r, size)
+		assert.LessOrEqual(t, len(repeating), min(size, 40), "Repeating length for size %d", size)
+	}
+
+	// Empty slices are generated as nil.
+	assert.Nil(t, IntSlices(10, 3).Generate(r, 0), "A zero size should generate a nil slice")
+
+	// Ints with a range that excludes zero shrink towards the bound closest to zero.
+	assert.Equal(t, []int{5, 8, 9}, collect(Ints(5, 20).Shrink(10)), "Ints should shrink towards the lower bound")
+	assert.Equal(t, []int{-5, -8, -9}, collect(Ints(-20, -5).Shrink(-10)), "Ints should shrink towards the upper bound")
+	assert.Empty(t, collect(Ints(0, 9).Shrink(0)), "Zero cannot be shrunk")
+
+	// Ints covers ranges whose width overflows an int, up to every int.
+	for _, bounds := range [][2]int{{math.MinInt, math.MaxInt}, {math.MinInt, 0}, {-1, math.MaxInt}, {math.MaxInt, math.MaxInt}} {
+		gen := Ints(bounds[0], bounds[1])
+		for range 100 {
+			v := gen.Generate(r, 10)
+			assert.True(t, v >= bounds[0] && v <= bounds[1], "Ints(%d, %d) generated %d", bounds[0], bounds[1], v)
+		}
+	}
+}
+
+func TestSequenceWithRepeats(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []int{7, 1, 2, 7, 4, 5, 7}, SequenceWithRepeats(7, 3, 7), "Every third position should hold the repeated element")
+	assert.Equal(t, []int{}, SequenceWithRepeats(0, 3, 7), "A zero size should give an empty slice")
+}
+
+// collect drains a sequence into a slice.
+func collect[T any](seq func(func(T) bool)) []T {
+	var result []T
+	for v := range seq {
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package slice
+
+import (
+	"cmp"
+	"sort"
+)
+
+// The functions in this file search slices that are already sorted in ascending order, in O(log n) time and
+// without copying or modifying them. The result is undefined when the slice is not sorted, or not sorted by the
+// same comparison function for the Func variants. Use SortBy or NewSortedIndex to obtain a sorted slice.
+
+// LowerBound returns the index of the first element of the sorted slice that is not less than the target,
+// or len(sorted) when every element is less. It is the position at which the target would be inserted before
+// any equal element.
+func LowerBound[T cmp.Ordered](sorted []T, target T) int {
+	return LowerBoundFunc(sorted, target, cmp.Compare[T])
+}
+
+// LowerBoundFunc is the variant of LowerBound for slices sorted by the comparison function.
+func LowerBoundFunc[T any](sorted []T, target T, compare func(a, b T) int) int {
+	// `sort.Search` returns the index of the first element for which the predicate holds.
+	return sort.Search(len(sorted), func(i int) bool {
+		return compare(sorted[i], target) >= 0
+	})
+}
+
+// UpperBound returns the index of the first element of the sorted slice that is greater than the target,
+// or len(sorted) when no element is greater. It is the position at which the target would be inserted after
+// any equal element.
+func UpperBound[T cmp.Ordered](sorted []T, target T) int {
+	return UpperBoundFunc(sorted, target, cmp.Compare[T])
+}
+
+// UpperBoundFunc is the variant of UpperBound for slices sorted by the comparison function.
+func UpperBoundFunc[T any](sorted []T, target T, compare func(a, b T) int) int {
+	return sort.Search(len(sorted), func(i int) bool {
+		return compare(sorted[i], target) > 0
+	})
+}
+
+// EqualRange returns the half-open range [lo, hi) of the elements of the sorted slice that are equal to the
+// target, so sorted[lo:hi] holds every occurrence. When the target is absent, lo equals hi and is the position
+// at which it would be inserted.
+func EqualRange[T cmp.Ordered](sorted []T, target T) (lo, hi int) {
+	return EqualRangeFunc(sorted, target, cmp.Compare[T])
+}
+
+// EqualRangeFunc is the variant of EqualRange for slices sorted by the comparison function.
+func EqualRangeFunc[T any](sorted []T, target T, compare func(a, b T) int) (lo, hi int) {
+	// Search the upper bound only in the part after the lower bound.
+	lo = LowerBoundFunc(sorted, target, compare)
+	hi = lo + UpperBoundFunc(sorted[lo:], target, compare)
+	return lo, hi
+}
+
+// BinarySearch returns the index of the first occurrence of the target in the sorted slice and true, or the
+// position at which the target would be inserted and false when it is absent.
+func BinarySearch[T cmp.Ordered](sorted []T, target T) (int, bool) {
+	return BinarySearchFunc(sorted, target, cmp.Compare[T])
+}
+
+// BinarySearchFunc is the variant of BinarySearch for slices sorted by the comparison function.
+func BinarySearchFunc[T any](sorted []T, target T, compare func(a, b T) int) (int, bool) {
+	// The lower bound holds the target if it is present at all.
+	index := LowerBoundFunc(sorted, target, compare)
+	return index, index < len(sorted) && compare(sorted[index], target) == 0
+}
@@ -0,0 +1,97 @@
+package slice
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spacemagneto/common/slice/proptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	sorted := []int{1, 3, 3, 3, 5, 8}
+
+	// Define test cases against a sorted slice with a run of duplicates.
+	cases := []struct {
+		name          string
+		target        int
+		expectedLower int
+		expectedUpper int
+		expectedFound bool
+	}{
+		{name: "Below every element", target: 0, expectedLower: 0, expectedUpper: 0, expectedFound: false},
+		{name: "First element", target: 1, expectedLower: 0, expectedUpper: 1, expectedFound: true},
+		{name: "Run of duplicates", target: 3, expectedLower: 1, expectedUpper: 4, expectedFound: true},
+		{name: "Missing element in the middle", target: 4, expectedLower: 4, expectedUpper: 4, expectedFound: false},
+		{name: "Last element", target: 8, expectedLower: 5, expectedUpper: 6, expectedFound: true},
+		{name: "Above every element", target: 9, expectedLower: 6, expectedUpper: 6, expectedFound: false},
+	}
+
+	// Iterate over the test cases, checking every search against the same expectations.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedLower, LowerBound(sorted, tt.target), "LowerBound for case %q", tt.name)
+			assert.Equal(t, tt.expectedUpper, UpperBound(sorted, tt.target), "UpperBound for case %q", tt.name)
+
+			lo, hi := EqualRange(sorted, tt.target)
+			assert.Equal(t, tt.expectedLower, lo, "EqualRange start for case %q", tt.name)
+			assert.Equal(t, tt.expectedUpper, hi, "EqualRange end for case %q", tt.name)
+
+			index, found := BinarySearch(sorted, tt.target)
+			assert.Equal(t, tt.expectedLower, index, "BinarySearch index for case %q", tt.name)
+			assert.Equal(t, tt.expectedFound, found, "BinarySearch presence for case %q", tt.name)
+		})
+	}
+
+	// Empty tests that every search of an empty slice returns position zero.
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, 0, LowerBound[int](nil, 1), "LowerBound of nil")
+		assert.Equal(t, 0, UpperBound[int](nil, 1), "UpperBound of nil")
+		lo, hi := EqualRange[int](nil, 1)
+		assert.Equal(t, [2]int{0, 0}, [2]int{lo, hi}, "EqualRange of nil")
+		index, found := BinarySearch[int](nil, 1)
+		assert.Equal(t, 0, index, "BinarySearch index of nil")
+		assert.False(t, found, "BinarySearch presence of nil")
+	})
+
+	// Func tests the variants on a slice sorted case-insensitively, where differently cased words are equal.
+	t.Run("Func", func(t *testing.T) {
+		words := []string{"alpha", "Bravo", "bravo", "BRAVO", "charlie"}
+		compare := func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }
+
+		assert.Equal(t, 1, LowerBoundFunc(words, "bRaVo", compare), "LowerBoundFunc")
+		assert.Equal(t, 4, UpperBoundFunc(words, "bRaVo", compare), "UpperBoundFunc")
+		lo, hi := EqualRangeFunc(words, "BRAVO", compare)
+		assert.Equal(t, []string{"Bravo", "bravo", "BRAVO"}, words[lo:hi], "EqualRangeFunc")
+		index, found := BinarySearchFunc(words, "Charlie", compare)
+		assert.Equal(t, 4, index, "BinarySearchFunc index")
+		assert.True(t, found, "BinarySearchFunc presence")
+	})
+
+	// Laws tests the searches against the standard library on generated sorted inputs.
+	t.Run("Laws", func(t *testing.T) {
+		proptest.Check(t, lawInts, func(e []int) error {
+			sorted := sortedInts(e)
+			for target := -1; target <= 13; target++ {
+				if lo := LowerBound(sorted, target); lo != sort.SearchInts(sorted, target) {
+					return fmt.Errorf("LowerBound(%v, %d) = %d, sort.SearchInts gives %d", sorted, target, lo, sort.SearchInts(sorted, target))
+				}
+				index, found := BinarySearch(sorted, target)
+				expectedIndex, expectedFound := slices.BinarySearch(sorted, target)
+				if index != expectedIndex || found != expectedFound {
+					return fmt.Errorf("BinarySearch(%v, %d) = %d, %t, want %d, %t", sorted, target, index, found, expectedIndex, expectedFound)
+				}
+				lo, hi := EqualRange(sorted, target)
+				if count := len(Filter(sorted, func(v int) bool { return v == target })); hi-lo != count {
+					return fmt.Errorf("EqualRange(%v, %d) = [%d, %d) covers %d elements, want %d", sorted, target, lo, hi, hi-lo, count)
+				}
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,300 @@
+// Package seq provides iterator-native counterparts of the slice helpers.
+// Every function accepts and returns iter.Seq or iter.Seq2 values, so chains of
+// operations are evaluated lazily, element by element, without allocating an
+// intermediate slice between steps and stopping as soon as the consumer stops.
+package seq
+
+import "iter"
+
+// FromSlice adapts a slice into a sequence that yields its elements in order.
+// The returned sequence reads the slice lazily, so changes made to the slice before
+// iteration starts are observed by the consumer.
+func FromSlice[T any](elements []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Walk the slice in index order and hand each element to the consumer.
+		for _, v := range elements {
+			// Stop immediately when the consumer signals that it does not want more values.
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate adapts a slice into a two-value sequence of index and element pairs.
+// It is the iterator counterpart of ranging over a slice with both the index and the value.
+func Enumerate[T any](elements []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		// Walk the slice in index order and hand each index and element to the consumer.
+		for i, v := range elements {
+			// Stop immediately when the consumer signals that it does not want more values.
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect drains a sequence into a newly allocated slice, preserving the order of the values.
+// A sequence that yields nothing produces a nil slice, which matches the behaviour of slice.Filter.
+func Collect[T any](seq iter.Seq[T]) []T {
+	// Declare the result slice lazily so an empty sequence results in a nil slice.
+	var result []T
+
+	// Append every value produced by the sequence to the result slice.
+	for v := range seq {
+		result = append(result, v)
+	}
+
+	// Return the materialized values.
+	return result
+}
+
+// Collect2 drains a two-value sequence into a map.
+// When the sequence yields the same key more than once, the last value wins.
+func Collect2[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	// Allocate the destination map up front so an empty sequence still produces a usable map.
+	result := make(map[K]V)
+
+	// Store every key and value pair produced by the sequence.
+	for k, v := range seq {
+		result[k] = v
+	}
+
+	// Return the populated map.
+	return result
+}
+
+// Keys projects a two-value sequence onto its first element.
+func Keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		// Forward only the key of every pair, stopping when the consumer stops.
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values projects a two-value sequence onto its second element.
+func Values[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		// Forward only the value of every pair, stopping when the consumer stops.
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Merge concatenates two sequences into a single sequence.
+// All values of the first sequence are yielded before any value of the second sequence,
+// and the second sequence is never started if the consumer stops while the first one is running.
+func Merge[T any](first, second iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Forward every value of the first sequence.
+		for v := range first {
+			if !yield(v) {
+				return
+			}
+		}
+
+		// Forward every value of the second sequence once the first one is exhausted.
+		for v := range second {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Merge2 concatenates two two-value sequences into a single two-value sequence.
+func Merge2[K, V any](first, second iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		// Forward every pair of the first sequence.
+		for k, v := range first {
+			if !yield(k, v) {
+				return
+			}
+		}
+
+		// Forward every pair of the second sequence once the first one is exhausted.
+		for k, v := range second {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Exclude yields every value of the sequence that is not equal to the specified element.
+// Unlike slice.Exclude it never touches any backing array, because it does not own one.
+func Exclude[T comparable](seq iter.Seq[T], element T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Inspect every value produced by the source sequence.
+		for v := range seq {
+			// Skip the values that are equal to the excluded element.
+			if v == element {
+				continue
+			}
+
+			// Forward the remaining values, stopping when the consumer stops.
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Contains reports whether the sequence yields the specified element.
+// The sequence is consumed only up to the first match, so infinite sequences are supported
+// as long as they eventually produce the element.
+func Contains[T comparable](seq iter.Seq[T], element T) bool {
+	// Compare every value produced by the sequence with the searched element.
+	for v := range seq {
+		// Stop the iteration as soon as the element is found.
+		if v == element {
+			return true
+		}
+	}
+
+	// The sequence was exhausted without a match.
+	return false
+}
+
+// Map applies a transformation function to each value of the sequence and yields the results.
+// The transformation is applied lazily, only when the consumer asks for the next value.
+func Map[A, B any](seq iter.Seq[A], fn func(A) B) iter.Seq[B] {
+	return func(yield func(B) bool) {
+		// Transform every value of the source sequence and forward the result.
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 applies a transformation function to each pair of the two-value sequence and yields the results.
+func Map2[K1, V1, K2, V2 any](seq iter.Seq2[K1, V1], fn func(K1, V1) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		// Transform every pair of the source sequence and forward the resulting pair.
+		for k, v := range seq {
+			if !yield(fn(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter yields only the values of the sequence that satisfy the predicate function.
+// The predicate is evaluated lazily, only when the consumer asks for the next value.
+func Filter[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Evaluate the predicate for every value of the source sequence.
+		for v := range seq {
+			// Skip the values that do not satisfy the predicate.
+			if !fn(v) {
+				continue
+			}
+
+			// Forward the matching values, stopping when the consumer stops.
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 yields only the pairs of the two-value sequence that satisfy the predicate function.
+func Filter2[K, V any](seq iter.Seq2[K, V], fn func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		// Evaluate the predicate for every pair of the source sequence.
+		for k, v := range seq {
+			// Skip the pairs that do not satisfy the predicate.
+			if !fn(k, v) {
+				continue
+			}
+
+			// Forward the matching pairs, stopping when the consumer stops.
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Unique yields the values of the sequence with duplicates removed, preserving the order of first occurrence.
+// The set of already seen values is allocated per iteration, so the returned sequence can be ranged over
+// several times and every iteration starts from a clean state.
+func Unique[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Create a map to track the values that have already been yielded during this iteration.
+		seen := make(map[T]struct{})
+
+		// Inspect every value produced by the source sequence.
+		for v := range seq {
+			// Skip the values that have already been yielded.
+			if _, ok := seen[v]; ok {
+				continue
+			}
+
+			// Remember the value so later duplicates are skipped.
+			seen[v] = struct{}{}
+
+			// Forward the first occurrence of the value, stopping when the consumer stops.
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take yields at most n values of the sequence and then stops the source sequence.
+// A non-positive n produces an empty sequence that never starts the source.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Do not start the source sequence when nothing is requested.
+		if n <= 0 {
+			return
+		}
+
+		// Count the values that have been forwarded so far.
+		count := 0
+
+		// Forward values until the limit is reached.
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+
+			// Stop the source sequence once the requested number of values has been forwarded.
+			count++
+			if count >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip discards the first n values of the sequence and yields the rest.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Count the values that have been discarded so far.
+		skipped := 0
+
+		// Forward values only after the requested number of values has been discarded.
+		for v := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
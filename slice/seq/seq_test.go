@@ -0,0 +1,349 @@
+package seq
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSliceAndCollect(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases that round-trip a slice through FromSlice and Collect.
+	// An empty or nil input is expected to collect into a nil slice, the same way slice.Filter behaves.
+	cases := []struct {
+		name     string
+		elements []int
+		expected []int
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "Empty slice", elements: []int{}, expected: nil},
+		{name: "Single element", elements: []int{42}, expected: []int{42}},
+		{name: "Multiple elements", elements: []int{3, 1, 2}, expected: []int{3, 1, 2}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Convert the slice into a sequence and drain it back into a slice.
+			result := Collect(FromSlice(tt.elements))
+
+			// The round trip must preserve every element and its order.
+			assert.Equal(t, tt.expected, result, "Result for test case %q did not match expected output", tt.name)
+		})
+	}
+}
+
+func TestEnumerateKeysValues(t *testing.T) {
+	t.Parallel()
+
+	// Enumerate yields index and value pairs, so projecting it onto keys and values must
+	// reproduce the indices and the original slice respectively.
+	elements := []string{"a", "b", "c"}
+
+	// Verify the projection onto the indices.
+	assert.Equal(t, []int{0, 1, 2}, Collect(Keys(Enumerate(elements))), "Keys should yield every index in order")
+
+	// Verify the projection onto the values.
+	assert.Equal(t, elements, Collect(Values(Enumerate(elements))), "Values should yield every element in order")
+
+	// Verify that a two-value sequence can be drained into a map.
+	assert.Equal(t, map[int]string{0: "a", 1: "b", 2: "c"}, Collect2(Enumerate(elements)), "Collect2 should build an index map")
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases mirroring the slice.Merge cases.
+	cases := []struct {
+		name     string
+		first    []int
+		second   []int
+		expected []int
+	}{
+		{name: "Both sequences empty", first: nil, second: nil, expected: nil},
+		{name: "First sequence empty", first: nil, second: []int{1, 2, 3}, expected: []int{1, 2, 3}},
+		{name: "Second sequence empty", first: []int{4, 5, 6}, second: nil, expected: []int{4, 5, 6}},
+		{name: "Both sequences have elements", first: []int{7, 8, 9}, second: []int{10, 11, 12}, expected: []int{7, 8, 9, 10, 11, 12}},
+		{name: "Overlapping elements", first: []int{1, 2, 3}, second: []int{3, 4, 5}, expected: []int{1, 2, 3, 3, 4, 5}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Concatenate both sequences and drain the result.
+			result := Collect(Merge(FromSlice(tt.first), FromSlice(tt.second)))
+
+			// The result must contain the elements of the first sequence followed by the second one.
+			assert.Equal(t, tt.expected, result, "Result for test case %q did not match expected output", tt.name)
+		})
+	}
+
+	// ShortCircuit verifies that the second sequence is never started when the consumer
+	// stops while the first sequence is still producing values.
+	t.Run("ShortCircuit", func(t *testing.T) {
+		// Track whether the second sequence has been started.
+		started := false
+		second := func(yield func(int) bool) { started = true }
+
+		// Consume only the first value of the merged sequence.
+		result := Collect(Take(Merge(FromSlice([]int{1, 2}), second), 1))
+
+		// Only the first value should be produced and the second sequence must remain untouched.
+		assert.Equal(t, []int{1}, result, "Only the first value should be produced")
+		assert.False(t, started, "The second sequence should not be started")
+	})
+
+	// Merge2 verifies the two-value concatenation.
+	t.Run("Merge2", func(t *testing.T) {
+		// Concatenate two enumerations and project the values.
+		result := Collect(Values(Merge2(Enumerate([]string{"a"}), Enumerate([]string{"b", "c"}))))
+
+		// The values must follow the order of the two inputs.
+		assert.Equal(t, []string{"a", "b", "c"}, result, "Merge2 should concatenate both sequences")
+	})
+}
+
+func TestExclude(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases mirroring the slice.Exclude cases.
+	cases := []struct {
+		name     string
+		elements []int
+		element  int
+		expected []int
+	}{
+		{name: "ExcludeSingleElement", elements: []int{1, 2, 3, 4, 5}, element: 3, expected: []int{1, 2, 4, 5}},
+		{name: "ExcludeMultipleOccurrences", elements: []int{1, 2, 3, 3, 4, 3, 5}, element: 3, expected: []int{1, 2, 4, 5}},
+		{name: "ExcludeNonexistentElement", elements: []int{1, 2, 3, 4, 5}, element: 6, expected: []int{1, 2, 3, 4, 5}},
+		{name: "ExcludeSingleElementSequence", elements: []int{1}, element: 1, expected: nil},
+		{name: "ExcludeEmptySequence", elements: nil, element: 1, expected: nil},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Keep a copy of the input to verify that it is left untouched.
+			original := append([]int(nil), tt.elements...)
+
+			// Exclude the element from the sequence and drain the result.
+			result := Collect(Exclude(FromSlice(tt.elements), tt.element))
+
+			// Verify the result and that the input slice has not been modified.
+			assert.Equal(t, tt.expected, result, "Test case %s failed", tt.name)
+			assert.Equal(t, original, tt.elements, "Input slice should not be modified in test case %s", tt.name)
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases mirroring the slice.Contains cases.
+	cases := []struct {
+		name     string
+		elements []string
+		element  string
+		expected bool
+	}{
+		{name: "Nil sequence", elements: nil, element: "test", expected: false},
+		{name: "Element present", elements: []string{"beta", "alpha", "gamma"}, element: "alpha", expected: true},
+		{name: "Element absent", elements: []string{"beta", "alpha", "gamma"}, element: "delta", expected: false},
+		{name: "Element at the end", elements: []string{"alpha", "beta", "gamma"}, element: "gamma", expected: true},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Search the sequence for the element.
+			result := Contains(FromSlice(tt.elements), tt.element)
+
+			// Verify the search result.
+			assert.Equal(t, tt.expected, result, "Expected Contains(%v, %v) to be %v", tt.elements, tt.element, tt.expected)
+		})
+	}
+
+	// InfiniteSequence verifies that Contains stops consuming the sequence at the first match.
+	t.Run("InfiniteSequence", func(t *testing.T) {
+		// Search an infinite sequence of natural numbers for a value that eventually appears.
+		assert.True(t, Contains(naturals(), 1000), "Contains should stop at the first match")
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases mirroring the slice.Map cases.
+	cases := []struct {
+		name          string
+		elements      []int
+		transformFunc func(int) string
+		expected      []string
+	}{
+		{name: "Empty sequence", elements: nil, transformFunc: func(i int) string { return fmt.Sprintf("%d", i) }, expected: nil},
+		{name: "Single element", elements: []int{42}, transformFunc: func(i int) string { return fmt.Sprintf("Num:%d", i) }, expected: []string{"Num:42"}},
+		{name: "Multiple elements", elements: []int{1, 2, 3}, transformFunc: func(i int) string { return fmt.Sprintf("%d", i*10) }, expected: []string{"10", "20", "30"}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Transform the sequence and drain the result.
+			result := Collect(Map(FromSlice(tt.elements), tt.transformFunc))
+
+			// Verify that every element was transformed in order.
+			assert.Equal(t, tt.expected, result, "Map output should match expected value for test case: %s", tt.name)
+		})
+	}
+
+	// Lazy verifies that the transformation is only applied to the values actually consumed.
+	t.Run("Lazy", func(t *testing.T) {
+		// Count how many times the transformation is invoked.
+		calls := 0
+		double := func(i int) int { calls++; return i * 2 }
+
+		// Consume only the first two values of an infinite mapped sequence.
+		result := Collect(Take(Map(naturals(), double), 2))
+
+		// Only two transformations should have been performed.
+		assert.Equal(t, []int{0, 2}, result, "Only the first two values should be produced")
+		assert.Equal(t, 2, calls, "The transformation should be applied lazily")
+	})
+
+	// Map2 verifies the two-value transformation.
+	t.Run("Map2", func(t *testing.T) {
+		// Swap the index and the value of every pair.
+		swapped := Map2(Enumerate([]string{"a", "b"}), func(i int, s string) (string, int) { return strings.ToUpper(s), i })
+
+		// Verify the resulting map.
+		assert.Equal(t, map[string]int{"A": 0, "B": 1}, Collect2(swapped), "Map2 should transform every pair")
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases mirroring the slice.Filter cases.
+	cases := []struct {
+		name     string
+		elements []int
+		fn       func(int) bool
+		expected []int
+	}{
+		{name: "Filter even numbers", elements: []int{1, 2, 3, 4, 5}, fn: func(n int) bool { return n%2 == 0 }, expected: []int{2, 4}},
+		{name: "Filter odd numbers", elements: []int{1, 2, 3, 4, 5}, fn: func(n int) bool { return n%2 != 0 }, expected: []int{1, 3, 5}},
+		{name: "Empty sequence", elements: nil, fn: func(n int) bool { return n%2 == 0 }, expected: nil},
+		{name: "No elements match predicate", elements: []int{1, 3, 5, 7}, fn: func(n int) bool { return n%2 == 0 }, expected: nil},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Filter the sequence and drain the result.
+			result := Collect(Filter(FromSlice(tt.elements), tt.fn))
+
+			// Verify that only the matching elements remain, in order.
+			assert.Equal(t, tt.expected, result, "For case '%s', expected %v but got %v", tt.name, tt.expected, result)
+		})
+	}
+
+	// Filter2 verifies the two-value filtering.
+	t.Run("Filter2", func(t *testing.T) {
+		// Keep only the pairs with an even index.
+		even := Filter2(Enumerate([]string{"a", "b", "c"}), func(i int, _ string) bool { return i%2 == 0 })
+
+		// Verify the remaining values.
+		assert.Equal(t, []string{"a", "c"}, Collect(Values(even)), "Filter2 should keep the matching pairs")
+	})
+}
+
+func TestUnique(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases mirroring the slice.Unique cases.
+	cases := []struct {
+		name     string
+		elements []string
+		expected []string
+	}{
+		{name: "Duplicates", elements: []string{"apple", "banana", "apple", "orange", "banana", "grape"}, expected: []string{"apple", "banana", "orange", "grape"}},
+		{name: "Empty sequence", elements: nil, expected: nil},
+		{name: "Single element", elements: []string{"apple"}, expected: []string{"apple"}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Deduplicate the sequence and drain the result.
+			result := Collect(Unique(FromSlice(tt.elements)))
+
+			// Verify that duplicates were removed and the order of first occurrence is preserved.
+			assert.Equal(t, tt.expected, result, "For case '%s', expected %v but got %v", tt.name, tt.expected, result)
+		})
+	}
+
+	// Reusable verifies that ranging over the same deduplicated sequence twice yields the same values.
+	t.Run("Reusable", func(t *testing.T) {
+		// Build the deduplicated sequence once.
+		unique := Unique(FromSlice([]int{1, 1, 2}))
+
+		// Both iterations must start from a clean seen set.
+		assert.Equal(t, []int{1, 2}, Collect(unique), "First iteration should deduplicate")
+		assert.Equal(t, []int{1, 2}, Collect(unique), "Second iteration should deduplicate from scratch")
+	})
+}
+
+func TestTakeSkip(t *testing.T) {
+	t.Parallel()
+
+	// Define a set of test cases for the Take and Skip functions.
+	cases := []struct {
+		name         string
+		elements     []int
+		n            int
+		expectedTake []int
+		expectedSkip []int
+	}{
+		{name: "Zero", elements: []int{1, 2, 3}, n: 0, expectedTake: nil, expectedSkip: []int{1, 2, 3}},
+		{name: "Negative", elements: []int{1, 2, 3}, n: -1, expectedTake: nil, expectedSkip: []int{1, 2, 3}},
+		{name: "Within bounds", elements: []int{1, 2, 3}, n: 2, expectedTake: []int{1, 2}, expectedSkip: []int{3}},
+		{name: "Beyond bounds", elements: []int{1, 2, 3}, n: 5, expectedTake: []int{1, 2, 3}, expectedSkip: nil},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Verify the prefix produced by Take.
+			assert.Equal(t, tt.expectedTake, Collect(Take(FromSlice(tt.elements), tt.n)), "Take result for case %q", tt.name)
+
+			// Verify the suffix produced by Skip.
+			assert.Equal(t, tt.expectedSkip, Collect(Skip(FromSlice(tt.elements), tt.n)), "Skip result for case %q", tt.name)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	// Chain several operations over an infinite sequence. Only the iterator-native versions can do this,
+	// because every step is lazy and Take stops the whole chain once enough values were produced.
+	result := Collect(Take(Unique(Map(Filter(naturals(), func(n int) bool { return n%2 == 0 }), func(n int) int { return n / 4 })), 3))
+
+	// Even naturals divided by four yield 0, 0, 1, 1, 2, ... and deduplication keeps the first three distinct values.
+	assert.Equal(t, []int{0, 1, 2}, result, "Chained sequence should stream and short-circuit")
+}
+
+// naturals returns an infinite sequence of natural numbers starting at zero.
+func naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
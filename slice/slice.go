@@ -1,9 +1,10 @@
 package slice
 
 import (
-	"sort"
-
-	"golang.org/x/exp/constraints"
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
 )
 
 // Merge concatenates two slices into a single slice.
@@ -43,36 +44,68 @@ func Exclude[T comparable](elements []T, element T) []T {
 	return result
 }
 
+// ExcludeFunc removes all elements for which the provided predicate returns true.
+// It is the comparator-based counterpart of Exclude for element types that are not comparable, or when
+// equality should be decided by a custom rule such as a case-insensitive comparison.
+// Like Exclude, it constructs the result by reusing the original slice's underlying array, so the
+// caller's slice is overwritten, and the relative order of the kept elements is preserved.
+func ExcludeFunc[T any](elements []T, fn func(T) bool) []T {
+	// Initialize the result slice with the same underlying array as the original slice.
+	result := elements[:0]
+
+	// Iterate over each item in the original slice.
+	for _, item := range elements {
+		// Keep the item only if the predicate does not select it for removal.
+		if !fn(item) {
+			result = append(result, item)
+		}
+	}
+
+	// Return the filtered slice with the selected elements removed.
+	return result
+}
+
 // Contains checks if the provided element is present in the slice.
-// It first sorts the slice and then performs a binary search to determine if the element exists.
+// It performs a linear scan and stops at the first match, which is O(n) time with no allocations,
+// and only requires the element type to be comparable, so structs and arrays are supported as well.
 // Returns true if the element is found, otherwise false.
-func Contains[T constraints.Ordered](elements []T, element T) bool {
-	// Check if the slice is nil. If it is, return false because there's nothing to search.
-	if elements == nil {
-		return false
+// Use SortedIndex instead when many lookups are made against the same slice.
+func Contains[T comparable](elements []T, element T) bool {
+	// Compare every element of the slice with the searched element.
+	for _, item := range elements {
+		// Stop the scan as soon as the element is found.
+		if item == element {
+			return true
+		}
 	}
 
-	// Create a copy of the input slice to avoid modifying the original slice.
-	copiedElements := make([]T, len(elements))
-	copy(copiedElements, elements)
+	// The element was not found in the slice.
+	return false
+}
 
-	// Sort the slice in ascending order.
-	// Sorting is necessary for binary search to work correctly.
-	sort.Slice(copiedElements, func(i, j int) bool {
-		return copiedElements[i] < copiedElements[j]
-	})
+// ContainsFunc checks if at least one element of the slice satisfies the provided predicate.
+// It performs a linear scan and stops at the first match, like Contains, but works with any element type.
+func ContainsFunc[T any](elements []T, fn func(T) bool) bool {
+	// Evaluate the predicate for every element of the slice.
+	for _, item := range elements {
+		// Stop the scan as soon as a matching element is found.
+		if fn(item) {
+			return true
+		}
+	}
 
-	// Use binary search to find the index of the element.
-	// `sort.Search` will return the index of the first element greater than or equal to `element`.
-	// If no such element is found, it returns the length of the slice.
-	index := sort.Search(len(copiedElements), func(i int) bool {
-		return copiedElements[i] >= element
-	})
+	// No element satisfied the predicate.
+	return false
+}
 
-	// Validate the index to ensure it's within the bounds of the slice.
-	// Check if the element at the found index matches the search element.
-	// Return true if the element at the index equals the search element, otherwise false.
-	return index < len(copiedElements) && copiedElements[index] == element
+// ContainsCmp checks if the provided element is present in a slice sorted according to the comparison function.
+// The comparison function returns a negative number when a < b, zero when a == b and a positive number when a > b.
+// It performs a binary search in O(log n) time without copying the slice, so the slice must already be sorted
+// by the same comparison function; the result is undefined otherwise.
+func ContainsCmp[T any](elements []T, element T, cmp func(a, b T) int) bool {
+	// The first element that is not less than `element` is the only candidate for a match.
+	_, found := BinarySearchFunc(elements, element, cmp)
+	return found
 }
 
 // Map applies a transformation function to each element of a slice and returns a new slice with the transformed elements.
@@ -121,12 +154,144 @@ func Filter[T any](elements []T, fn func(T) bool) []T {
 	return result
 }
 
+// ParallelMap applies a transformation function to each element of a slice using a bounded pool of goroutines.
+// At most limit calls of fn run at the same time; a non-positive limit defaults to runtime.GOMAXPROCS(0).
+// The output preserves the order of the input regardless of the order in which the calls complete.
+// When the context is cancelled, no further element is dispatched and the context error is returned
+// together with a nil slice, after the calls already in flight have returned.
+// A panic in fn stops the dispatch as well, and is raised again in the caller once the other calls have returned.
+func ParallelMap[A, B any](ctx context.Context, elements []A, limit int, fn func(A) B) ([]B, error) {
+	// Allocate the result slice up front so every worker writes into its own index, which keeps the input order
+	// without any additional synchronization on the slice itself.
+	result := make([]B, len(elements))
+
+	// Run the transformation over the input indices with the bounded worker pool.
+	err := parallelEach(ctx, len(elements), limit, func(i int) {
+		// Store the transformed element at the position of the source element.
+		result[i] = fn(elements[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the resulting slice containing the transformed elements.
+	return result, nil
+}
+
+// ParallelFilter filters a slice of elements using a bounded pool of goroutines to evaluate the predicate.
+// At most limit calls of fn run at the same time; a non-positive limit defaults to runtime.GOMAXPROCS(0).
+// The output keeps the matching elements in the order of the input, and is nil when nothing matches,
+// exactly like Filter. When the context is cancelled, the context error is returned together with a nil slice.
+// A panic in fn is raised again in the caller, as in ParallelMap.
+func ParallelFilter[T any](ctx context.Context, elements []T, limit int, fn func(T) bool) ([]T, error) {
+	// Record the predicate outcome for every index so the matching elements can be collected in order afterward.
+	keep := make([]bool, len(elements))
+
+	// Evaluate the predicate over the input indices with the bounded worker pool.
+	err := parallelEach(ctx, len(elements), limit, func(i int) {
+		// Store the predicate outcome at the position of the source element.
+		keep[i] = fn(elements[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []T
+
+	// Collect the matching elements sequentially to preserve the original order.
+	for i, v := range elements {
+		if keep[i] {
+			result = append(result, v)
+		}
+	}
+
+	// Return the resulting slice containing only the elements that satisfy the predicate.
+	return result, nil
+}
+
+// parallelEach calls fn for every index in [0, n) using at most limit goroutines.
+// Workers claim indices through a shared atomic counter, so faster workers pick up more work,
+// and each worker checks the context before claiming the next index.
+// It returns the context error if the context was cancelled before every index had been processed.
+// A panic in fn stops every worker from claiming further indices and is re-raised on the calling goroutine
+// after the workers have returned, so the caller can recover it instead of the process crashing.
+func parallelEach(ctx context.Context, n, limit int, fn func(i int)) error {
+	// Fall back to the number of usable CPUs when no explicit limit is provided.
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+
+	// Never start more workers than there are elements to process.
+	if limit > n {
+		limit = n
+	}
+
+	// next holds the next index to be claimed by a worker, and done counts the indices that were processed.
+	var next, done atomic.Int64
+	var wg sync.WaitGroup
+
+	// failure holds the value of the first panic raised by fn, and failed tells the workers to stop.
+	var failure any
+	var failed atomic.Bool
+	var once sync.Once
+
+	// Start the bounded pool of workers.
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Recover a panic of fn so that it can be raised again on the calling goroutine.
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { failure = r })
+					failed.Store(true)
+				}
+			}()
+
+			// Keep claiming indices until the input is exhausted, the context is cancelled or fn panicked.
+			for ctx.Err() == nil && !failed.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+
+				fn(i)
+				done.Add(1)
+			}
+		}()
+	}
+
+	// Wait for every worker, including the calls already in flight when the context was cancelled.
+	wg.Wait()
+
+	// Raise the panic of fn in the caller, now that no worker is running.
+	if failed.Load() {
+		panic(failure)
+	}
+
+	// Report cancellation only when some indices were left unprocessed, so a context cancelled
+	// right after the last element completed does not discard a complete result.
+	if done.Load() < int64(n) {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
 // Unique removes duplicate elements from a slice of any comparable type.
 // It iterates over each element in the input slice and keeps track of the elements that have already been encountered.
 // If an element has not been encountered before, it is added to the result slice.
 // The result is a new slice containing only the unique elements, preserving their original order.
 // This function is generic and works with any comparable type, including integers, strings, structs, and more.
+// Inputs of up to DefaultLinearScanThreshold elements are deduplicated by a linear scan instead of a map,
+// which avoids allocating the map for the many small slices seen in hot paths.
 func Unique[T comparable](elements []T) []T {
+	// Small inputs are cheaper to deduplicate by scanning the already kept elements than by hashing.
+	if len(elements) <= DefaultLinearScanThreshold {
+		return uniqueLinear(nil, elements)
+	}
+
 	// Declare an empty slice to hold the unique elements.
 	// The result slice will store the final list of elements with duplicates removed.
 	var result []T
@@ -159,3 +324,50 @@ func Unique[T comparable](elements []T) []T {
 	// The order of the elements is preserved.
 	return result
 }
+
+// UniqueBy removes elements whose key, as computed by the key function, has already been encountered.
+// The first element for every key is kept and the original order is preserved, exactly like Unique.
+// It is useful for element types that are not comparable, such as structs holding slices or maps,
+// or when elements should be deduplicated by a field like an ID.
+func UniqueBy[T any, K comparable](elements []T, key func(T) K) []T {
+	// Declare an empty slice to hold the unique elements.
+	var result []T
+	// Create a map to track the keys that have already been encountered.
+	seen := make(map[K]struct{}, len(elements))
+
+	// Iterate over each element in the input slice.
+	for _, elem := range elements {
+		// Compute the key of the current element and skip it if the key has been seen before.
+		k := key(elem)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+
+		// Mark the key as seen and keep the element.
+		seen[k] = struct{}{}
+		result = append(result, elem)
+	}
+
+	// Return the result slice containing one element per key, in the original order.
+	return result
+}
+
+// UniqueFunc removes elements that are equal, according to the provided equality function, to an element
+// that has already been kept. The first occurrence is kept and the original order is preserved, exactly like Unique.
+// Since an arbitrary equality function cannot be hashed, every element is compared with the kept elements,
+// which is O(n*m) for n elements and m unique elements. Prefer UniqueBy when a comparable key can be derived.
+func UniqueFunc[T any](elements []T, eq func(a, b T) bool) []T {
+	// Declare an empty slice to hold the unique elements.
+	var result []T
+
+	// Iterate over each element in the input slice.
+	for _, elem := range elements {
+		// Keep the element only if no previously kept element is equal to it.
+		if !ContainsFunc(result, func(kept T) bool { return eq(kept, elem) }) {
+			result = append(result, elem)
+		}
+	}
+
+	// Return the result slice containing only unique elements, in the original order.
+	return result
+}
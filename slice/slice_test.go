@@ -1,10 +1,13 @@
 package slice
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -124,6 +127,61 @@ func TestExclude(t *testing.T) {
 	})
 }
 
+func TestExcludeFunc(t *testing.T) {
+	t.Parallel()
+
+	// SliceString tests the ExcludeFunc function with a case-insensitive predicate. The test cases mirror the
+	// Exclude cases, and additionally verify that elements differing only in case are removed together.
+	t.Run("SliceString", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			elements []string
+			element  string
+			expected []string
+		}{
+			{name: "ExcludeSingleElement", elements: []string{"a", "b", "c"}, element: "b", expected: []string{"a", "c"}},
+			{name: "ExcludeIgnoringCase", elements: []string{"Go", "rust", "GO", "go", "zig"}, element: "go", expected: []string{"rust", "zig"}},
+			{name: "ExcludeNonexistentElement", elements: []string{"a", "b"}, element: "c", expected: []string{"a", "b"}},
+			{name: "ExcludeEmptySlice", elements: []string{}, element: "a", expected: []string{}},
+			{name: "ExcludeNilSlice", elements: nil, element: "a", expected: nil},
+		}
+
+		// Iterate through each test case and execute the ExcludeFunc function.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				result := ExcludeFunc(tt.elements, func(s string) bool { return strings.EqualFold(s, tt.element) })
+
+				// Assert that the result from the ExcludeFunc function matches the expected value.
+				assert.Equal(t, tt.expected, result, "Test case %s failed", tt.name)
+			})
+		}
+	})
+
+	// SliceNonComparable tests the ExcludeFunc function with structs holding slices, which cannot be used with Exclude.
+	t.Run("SliceNonComparable", func(t *testing.T) {
+		type rule struct {
+			name  string
+			hosts []string
+		}
+
+		// Remove every rule that has no hosts.
+		rules := []rule{{"a", []string{"h1"}}, {"b", nil}, {"c", []string{"h2", "h3"}}, {"d", []string{}}}
+		result := ExcludeFunc(rules, func(r rule) bool { return len(r.hosts) == 0 })
+
+		assert.Equal(t, []rule{{"a", []string{"h1"}}, {"c", []string{"h2", "h3"}}}, result, "Rules without hosts should be removed")
+	})
+
+	// ReusesBackingArray tests that ExcludeFunc follows the same aliasing behaviour as Exclude.
+	t.Run("ReusesBackingArray", func(t *testing.T) {
+		elements := []int{1, 2, 3, 4}
+		result := ExcludeFunc(elements, func(n int) bool { return n%2 == 0 })
+
+		// The kept elements are written to the front of the original backing array.
+		assert.Equal(t, []int{1, 3}, result, "Even numbers should be removed")
+		assert.Equal(t, []int{1, 3, 3, 4}, elements, "The original slice should be overwritten like Exclude does")
+	})
+}
+
 func TestContains(t *testing.T) {
 	t.Parallel()
 
@@ -211,6 +269,112 @@ func TestContains(t *testing.T) {
 			})
 		}
 	})
+
+	// SliceStruct tests the Contains function for slices of structs. Structs are comparable but not ordered,
+	// so this verifies that the linear scan works for element types that cannot be sorted.
+	t.Run("SliceStruct", func(t *testing.T) {
+		type point struct{ x, y int }
+
+		// Define test cases with various scenarios for slices of structs.
+		cases := []struct {
+			name     string
+			elements []point
+			element  point
+			expected bool
+		}{
+			{name: "Nil slice", elements: nil, element: point{1, 2}, expected: false},
+			{name: "Element present", elements: []point{{1, 2}, {3, 4}}, element: point{3, 4}, expected: true},
+			{name: "Element absent", elements: []point{{1, 2}, {3, 4}}, element: point{2, 1}, expected: false},
+			{name: "Zero value present", elements: []point{{1, 2}, {}}, element: point{}, expected: true},
+		}
+
+		// Iterate over each test case and execute the Contains function.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				// Assert that the result matches the expected value.
+				result := Contains(tt.elements, tt.element)
+				assert.Equal(t, tt.expected, result, "Expected Contains(%v, %v) to be %v but result %v", tt.elements, tt.element, tt.expected, result)
+			})
+		}
+	})
+}
+
+func TestContainsFunc(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases mirroring the Contains cases, expressed as predicates.
+	cases := []struct {
+		name     string
+		elements []string
+		fn       func(string) bool
+		expected bool
+	}{
+		{name: "Nil slice", elements: nil, fn: func(s string) bool { return true }, expected: false},
+		{name: "Empty slice", elements: []string{}, fn: func(s string) bool { return true }, expected: false},
+		{name: "Case-insensitive match", elements: []string{"Alpha", "Beta"}, fn: func(s string) bool { return strings.EqualFold(s, "beta") }, expected: true},
+		{name: "No match", elements: []string{"Alpha", "Beta"}, fn: func(s string) bool { return strings.EqualFold(s, "gamma") }, expected: false},
+		{name: "Prefix match", elements: []string{"apple", "banana"}, fn: func(s string) bool { return strings.HasPrefix(s, "ban") }, expected: true},
+	}
+
+	// Iterate through each test case and execute the ContainsFunc function.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ContainsFunc(tt.elements, tt.fn), "result should match the expected value for test case: %s", tt.name)
+		})
+	}
+
+	// StopsAtFirstMatch tests that the predicate is not evaluated after the first match.
+	t.Run("StopsAtFirstMatch", func(t *testing.T) {
+		calls := 0
+		ContainsFunc([]int{1, 2, 3, 4}, func(n int) bool { calls++; return n == 2 })
+		assert.Equal(t, 2, calls, "ContainsFunc should stop at the first match")
+	})
+}
+
+func TestContainsCmp(t *testing.T) {
+	t.Parallel()
+
+	// SliceInt tests the ContainsCmp function on ascending integer slices, mirroring the Contains cases.
+	t.Run("SliceInt", func(t *testing.T) {
+		ascending := func(a, b int) int { return a - b }
+
+		cases := []struct {
+			name     string
+			elements []int
+			element  int
+			expected bool
+		}{
+			{name: "Element is in the slice", elements: []int{1, 2, 3, 4, 5}, element: 3, expected: true},
+			{name: "Element is not in the slice", elements: []int{1, 2, 3, 4, 5}, element: 6, expected: false},
+			{name: "Element below the range", elements: []int{1, 2, 3}, element: 0, expected: false},
+			{name: "Empty slice", elements: []int{}, element: 1, expected: false},
+			{name: "Nil slice", elements: nil, element: 1, expected: false},
+			{name: "Element repeated", elements: []int{1, 2, 3, 3, 4, 5}, element: 3, expected: true},
+			{name: "Element at start", elements: []int{1, 2, 3}, element: 1, expected: true},
+			{name: "Element at end", elements: []int{1, 2, 3}, element: 3, expected: true},
+		}
+
+		// Iterate through each test case and execute the ContainsCmp function.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, ContainsCmp(tt.elements, tt.element, ascending), "result should match the expected value for test case: %s", tt.name)
+			})
+		}
+	})
+
+	// SliceStructByKey tests the ContainsCmp function on structs sorted by a key, with a comparison that only
+	// looks at the key, so an element with the same key but a different payload is considered present.
+	t.Run("SliceStructByKey", func(t *testing.T) {
+		type user struct {
+			id   int
+			tags []string
+		}
+		byID := func(a, b user) int { return a.id - b.id }
+		users := []user{{1, nil}, {4, []string{"admin"}}, {9, nil}}
+
+		assert.True(t, ContainsCmp(users, user{id: 4}, byID), "A user with the same id should be found")
+		assert.False(t, ContainsCmp(users, user{id: 5}, byID), "A missing id should not be found")
+	})
 }
 
 func TestMap(t *testing.T) {
@@ -379,6 +543,144 @@ func TestMap(t *testing.T) {
 	})
 }
 
+func TestParallelMap(t *testing.T) {
+	t.Parallel()
+
+	// SliceIntToString tests the ParallelMap function with the same scenarios as the Map function.
+	// Every case runs with several concurrency limits, and the result must match the sequential Map output,
+	// which verifies that the order of the input is preserved no matter how the calls are scheduled.
+	t.Run("SliceIntToString", func(t *testing.T) {
+		// Define a series of test cases mirroring the Map test cases.
+		cases := []struct {
+			name          string
+			elements      []int
+			transformFunc func(int) string
+			expected      []string
+		}{
+			{name: "Empty slice", elements: []int{}, transformFunc: func(i int) string { return fmt.Sprintf("%d", i) }, expected: []string{}},
+			{name: "Single element slice", elements: []int{42}, transformFunc: func(i int) string { return fmt.Sprintf("Num:%d", i) }, expected: []string{"Num:42"}},
+			{name: "Multiple elements", elements: []int{1, 2, 3}, transformFunc: func(i int) string { return fmt.Sprintf("%d", i*10) }, expected: []string{"10", "20", "30"}},
+			{name: "Negative integers", elements: []int{-1, -2}, transformFunc: func(i int) string { return fmt.Sprintf("%d", i) }, expected: []string{"-1", "-2"}},
+		}
+
+		// Iterate over each test case and every concurrency limit, including the default one.
+		for _, tt := range cases {
+			for _, limit := range []int{0, 1, 4, 100} {
+				t.Run(fmt.Sprintf("%s/limit=%d", tt.name, limit), func(t *testing.T) {
+					// Execute the ParallelMap function with a context that is never cancelled.
+					result, err := ParallelMap(context.Background(), tt.elements, limit, tt.transformFunc)
+
+					// The call must succeed and produce the same output as the sequential Map function.
+					assert.NoError(t, err, "ParallelMap should not fail for test case: %s", tt.name)
+					assert.Equal(t, tt.expected, result, "ParallelMap output should match expected value for test case: %s", tt.name)
+				})
+			}
+		}
+	})
+
+	// GeneratedDataPreservesOrder tests the ParallelMap function with a larger generated sequence and a transformation
+	// that sleeps for a duration depending on the element, so calls complete out of order. The output must still
+	// follow the order of the input.
+	t.Run("GeneratedDataPreservesOrder", func(t *testing.T) {
+		// Generate a sequence of integers and a transformation that finishes in a scrambled order.
+		input := createSequenceWithoutRepeats(500)
+		transform := func(i int) int {
+			time.Sleep(time.Duration(i%7) * time.Microsecond)
+			return i * 2
+		}
+
+		// Execute the transformation in parallel with a bounded pool.
+		result, err := ParallelMap(context.Background(), input, 8, transform)
+
+		// The parallel output must be identical to the sequential output.
+		assert.NoError(t, err, "ParallelMap should not fail")
+		assert.Equal(t, Map(input, transform), result, "ParallelMap output should preserve the input order")
+	})
+
+	// RespectsLimit tests that no more than limit calls of the transformation run at the same time.
+	t.Run("RespectsLimit", func(t *testing.T) {
+		// Track the number of calls in flight and the highest value it reached.
+		var inFlight, peak atomic.Int64
+		transform := func(i int) int {
+			current := inFlight.Add(1)
+			for {
+				observed := peak.Load()
+				if current <= observed || peak.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(100 * time.Microsecond)
+			inFlight.Add(-1)
+			return i
+		}
+
+		// Execute the transformation with a limit of three workers.
+		_, err := ParallelMap(context.Background(), createSequenceWithoutRepeats(200), 3, transform)
+
+		// The peak concurrency must never exceed the limit.
+		assert.NoError(t, err, "ParallelMap should not fail")
+		assert.LessOrEqual(t, peak.Load(), int64(3), "ParallelMap should not exceed the concurrency limit")
+	})
+
+	// CancelledContext tests that ParallelMap stops dispatching elements once the context is cancelled
+	// and reports the cancellation instead of a partial result.
+	t.Run("CancelledContext", func(t *testing.T) {
+		// Cancel the context from within the transformation after a few calls.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls atomic.Int64
+		transform := func(i int) int {
+			if calls.Add(1) == 5 {
+				cancel()
+			}
+			return i
+		}
+
+		// Execute the transformation over a large input with a single worker.
+		result, err := ParallelMap(ctx, createSequenceWithoutRepeats(1000), 1, transform)
+
+		// The cancellation must be reported and the remaining elements must not be processed.
+		assert.ErrorIs(t, err, context.Canceled, "ParallelMap should report the cancellation")
+		assert.Nil(t, result, "ParallelMap should not return a partial result")
+		assert.Equal(t, int64(5), calls.Load(), "ParallelMap should stop dispatching after cancellation")
+	})
+
+	// AlreadyCancelledContext tests that no call is made when the context is cancelled before the call.
+	t.Run("AlreadyCancelledContext", func(t *testing.T) {
+		// Create a context that is already cancelled.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Count the invocations of the transformation.
+		var calls atomic.Int64
+		_, err := ParallelMap(ctx, []int{1, 2, 3}, 2, func(i int) int { calls.Add(1); return i })
+
+		// The cancellation must be reported before any element is processed.
+		assert.ErrorIs(t, err, context.Canceled, "ParallelMap should report the cancellation")
+		assert.Zero(t, calls.Load(), "ParallelMap should not call the transformation")
+	})
+
+	// Panic tests that a panic in the transformation reaches the caller of ParallelMap, where it can be recovered,
+	// and that the workers stop claiming elements after it.
+	t.Run("Panic", func(t *testing.T) {
+		var calls atomic.Int64
+		transform := func(i int) int {
+			calls.Add(1)
+			if i == 10 {
+				panic("transformation failed")
+			}
+			return i
+		}
+
+		// The panic must be raised on the calling goroutine with its original value.
+		assert.PanicsWithValue(t, "transformation failed", func() {
+			_, _ = ParallelMap(context.Background(), createSequenceWithoutRepeats(1000), 1, transform)
+		}, "ParallelMap should raise the panic in the caller")
+		assert.Equal(t, int64(10), calls.Load(), "ParallelMap should stop dispatching after a panic")
+	})
+}
+
 func TestFilter(t *testing.T) {
 	t.Parallel()
 
@@ -614,6 +916,98 @@ func TestFilter(t *testing.T) {
 	})
 }
 
+func TestParallelFilter(t *testing.T) {
+	t.Parallel()
+
+	// FilterInt tests the ParallelFilter function with the same scenarios as the Filter function.
+	// Every case runs with several concurrency limits, and the result must match the sequential Filter output,
+	// including the nil result when no element matches the predicate.
+	t.Run("FilterInt", func(t *testing.T) {
+		// Define a set of test cases mirroring the Filter test cases.
+		cases := []struct {
+			name     string
+			elements []int
+			fn       func(int) bool
+			expected []int
+		}{
+			{name: "Filter even numbers", elements: []int{1, 2, 3, 4, 5}, fn: func(n int) bool { return n%2 == 0 }, expected: []int{2, 4}},
+			{name: "Filter odd numbers", elements: []int{1, 2, 3, 4, 5}, fn: func(n int) bool { return n%2 != 0 }, expected: []int{1, 3, 5}},
+			{name: "Empty slice", elements: []int{}, fn: func(n int) bool { return n%2 == 0 }, expected: nil},
+			{name: "All elements match predicate", elements: []int{2, 4, 6, 8}, fn: func(n int) bool { return n%2 == 0 }, expected: []int{2, 4, 6, 8}},
+			{name: "No elements match predicate", elements: []int{1, 3, 5, 7}, fn: func(n int) bool { return n%2 == 0 }, expected: nil},
+		}
+
+		// Iterate over each test case and every concurrency limit, including the default one.
+		for _, tt := range cases {
+			for _, limit := range []int{0, 1, 4, 100} {
+				t.Run(fmt.Sprintf("%s/limit=%d", tt.name, limit), func(t *testing.T) {
+					// Execute the ParallelFilter function with a context that is never cancelled.
+					result, err := ParallelFilter(context.Background(), tt.elements, limit, tt.fn)
+
+					// The call must succeed and produce the same output as the sequential Filter function.
+					assert.NoError(t, err, "ParallelFilter should not fail for case: %s", tt.name)
+					assert.Equal(t, tt.expected, result, "For case '%s', expected %v but got %v", tt.name, tt.expected, result)
+				})
+			}
+		}
+	})
+
+	// GeneratedDataPreservesOrder tests the ParallelFilter function with a predicate that completes out of order.
+	t.Run("GeneratedDataPreservesOrder", func(t *testing.T) {
+		// Generate a sequence of integers with repeats and a predicate that finishes in a scrambled order.
+		input := createSequenceWithRepeats(500, 7)
+		predicate := func(i int) bool {
+			time.Sleep(time.Duration(i%5) * time.Microsecond)
+			return i%3 != 0
+		}
+
+		// Execute the predicate in parallel with a bounded pool.
+		result, err := ParallelFilter(context.Background(), input, 8, predicate)
+
+		// The parallel output must be identical to the sequential output.
+		assert.NoError(t, err, "ParallelFilter should not fail")
+		assert.Equal(t, Filter(input, predicate), result, "ParallelFilter output should preserve the input order")
+	})
+
+	// CancelledContext tests that ParallelFilter stops evaluating the predicate once the context is cancelled.
+	t.Run("CancelledContext", func(t *testing.T) {
+		// Cancel the context from within the predicate after a few calls.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls atomic.Int64
+		predicate := func(i int) bool {
+			if calls.Add(1) == 3 {
+				cancel()
+			}
+			return true
+		}
+
+		// Execute the predicate over a large input with a single worker.
+		result, err := ParallelFilter(ctx, createSequenceWithoutRepeats(1000), 1, predicate)
+
+		// The cancellation must be reported and the remaining elements must not be evaluated.
+		assert.ErrorIs(t, err, context.Canceled, "ParallelFilter should report the cancellation")
+		assert.Nil(t, result, "ParallelFilter should not return a partial result")
+		assert.Equal(t, int64(3), calls.Load(), "ParallelFilter should stop dispatching after cancellation")
+	})
+
+	// Panic tests that a panic in the predicate reaches the caller of ParallelFilter, where it can be recovered.
+	t.Run("Panic", func(t *testing.T) {
+		predicate := func(i int) bool {
+			if i == 10 {
+				panic("predicate failed")
+			}
+			return true
+		}
+
+		// The panic must be raised on the calling goroutine with its original value.
+		assert.PanicsWithValue(t, "predicate failed", func() {
+			_, _ = ParallelFilter(context.Background(), createSequenceWithoutRepeats(1000), 4, predicate)
+		}, "ParallelFilter should raise the panic in the caller")
+	})
+}
+
 func TestUnique(t *testing.T) {
 	// Define test cases for different data types to check the behavior of the Unique function.
 	// Each test case consists of a name, the input elements (which is the slice to deduplicate),
@@ -719,6 +1113,77 @@ func TestUnique(t *testing.T) {
 	}
 }
 
+func TestUniqueBy(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		id     int
+		labels []string
+	}
+
+	// Define test cases deduplicating by a derived key, including element types that are not comparable.
+	cases := []struct {
+		name     string
+		elements []record
+		expected []record
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "Empty slice", elements: []record{}, expected: nil},
+		{name: "No duplicates", elements: []record{{1, nil}, {2, nil}}, expected: []record{{1, nil}, {2, nil}}},
+		{
+			name:     "First occurrence per key is kept",
+			elements: []record{{1, []string{"a"}}, {2, nil}, {1, []string{"b"}}, {3, nil}, {2, []string{"c"}}},
+			expected: []record{{1, []string{"a"}}, {2, nil}, {3, nil}},
+		},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UniqueBy(tt.elements, func(r record) int { return r.id })
+			assert.Equal(t, tt.expected, result, "For case '%s', expected %v but got %v", tt.name, tt.expected, result)
+		})
+	}
+
+	// IdentityKey tests that using the element itself as the key behaves exactly like Unique.
+	t.Run("IdentityKey", func(t *testing.T) {
+		input := createSequenceWithRepeats(500, 42)
+		assert.Equal(t, Unique(input), UniqueBy(input, func(n int) int { return n }), "UniqueBy with the identity key should match Unique")
+	})
+}
+
+func TestUniqueFunc(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases deduplicating with a custom equality function.
+	cases := []struct {
+		name     string
+		elements []string
+		eq       func(a, b string) bool
+		expected []string
+	}{
+		{name: "Nil slice", elements: nil, eq: strings.EqualFold, expected: nil},
+		{name: "Case-insensitive", elements: []string{"Go", "rust", "GO", "Rust", "go", "zig"}, eq: strings.EqualFold, expected: []string{"Go", "rust", "zig"}},
+		{name: "Exact equality", elements: []string{"a", "b", "a"}, eq: func(a, b string) bool { return a == b }, expected: []string{"a", "b"}},
+		{name: "Same length", elements: []string{"aa", "b", "cc", "d", "eee"}, eq: func(a, b string) bool { return len(a) == len(b) }, expected: []string{"aa", "b", "eee"}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UniqueFunc(tt.elements, tt.eq)
+			assert.Equal(t, tt.expected, result, "For case '%s', expected %v but got %v", tt.name, tt.expected, result)
+		})
+	}
+
+	// NonComparable tests the UniqueFunc function on maps, which cannot be used with Unique at all.
+	t.Run("NonComparable", func(t *testing.T) {
+		configs := []map[string]int{{"a": 1}, {"b": 2}, {"a": 1}}
+		result := UniqueFunc(configs, func(a, b map[string]int) bool { return fmt.Sprint(a) == fmt.Sprint(b) })
+		assert.Equal(t, []map[string]int{{"a": 1}, {"b": 2}}, result, "Equal maps should be collapsed")
+	})
+}
+
 // createSequenceWithRepeats generates a slice of integers with a specified size.
 // The slice contains a repeated element at every 100th position, while other positions
 // are filled with their respective indices.
@@ -0,0 +1,177 @@
+package slice
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+// The functions in this file never modify their input: they sort or select from a copy and return a new slice.
+// Each key function is called exactly once per element, so expensive keys are not recomputed by the
+// O(n log n) comparisons of the sort.
+
+// SortBy returns a copy of the slice sorted in ascending order of the key computed for each element.
+// The order of elements with equal keys is unspecified; use SortStableBy to keep their input order.
+func SortBy[T any, K cmp.Ordered](elements []T, key func(T) K) []T {
+	return sortByKey(elements, key, slices.SortFunc[[]keyed[T, K]])
+}
+
+// SortStableBy returns a copy of the slice sorted in ascending order of the key computed for each element.
+// Elements with equal keys keep their order from the input slice.
+func SortStableBy[T any, K cmp.Ordered](elements []T, key func(T) K) []T {
+	return sortByKey(elements, key, slices.SortStableFunc[[]keyed[T, K]])
+}
+
+// SortKey is one sort criterion of SortByKeys, built with Ascending or Descending.
+type SortKey[T any] struct {
+	// compare compares two elements by the criterion, including its direction.
+	compare func(a, b T) int
+}
+
+// Ascending creates a criterion that sorts elements in ascending order of the key.
+func Ascending[T any, K cmp.Ordered](key func(T) K) SortKey[T] {
+	return SortKey[T]{compare: func(a, b T) int { return cmp.Compare(key(a), key(b)) }}
+}
+
+// Descending creates a criterion that sorts elements in descending order of the key.
+func Descending[T any, K cmp.Ordered](key func(T) K) SortKey[T] {
+	return SortKey[T]{compare: func(a, b T) int { return cmp.Compare(key(b), key(a)) }}
+}
+
+// SortByKeys returns a copy of the slice sorted by several criteria: by the first key, then by the second key
+// among elements with an equal first key, and so on. The sort is stable, so elements that are equal for every
+// key keep their input order. Unlike SortBy, the keys are computed on every comparison.
+func SortByKeys[T any](elements []T, keys ...SortKey[T]) []T {
+	// Sort a copy, so the caller's slice is never modified.
+	result := Merge(elements, nil)
+	slices.SortStableFunc(result, func(a, b T) int {
+		for _, key := range keys {
+			if c := key.compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return result
+}
+
+// TopK returns the k largest elements of the slice in descending order. Among equal elements the ones that
+// come first in the input are preferred. It runs in O(n log k) time with a heap of k elements, so it is much
+// cheaper than sorting when k is small. It returns nil when k is not positive or the slice is empty, and every
+// element when k exceeds the length of the slice.
+func TopK[T cmp.Ordered](elements []T, k int) []T {
+	return TopKBy(elements, k, func(v T) T { return v })
+}
+
+// TopKBy returns the k elements with the largest keys in descending order of their keys, with the same rules
+// as TopK.
+func TopKBy[T any, K cmp.Ordered](elements []T, k int, key func(T) K) []T {
+	return selectK(elements, k, key, func(a, b K) bool { return a > b })
+}
+
+// BottomK returns the k smallest elements of the slice in ascending order, with the same rules as TopK.
+func BottomK[T cmp.Ordered](elements []T, k int) []T {
+	return BottomKBy(elements, k, func(v T) T { return v })
+}
+
+// BottomKBy returns the k elements with the smallest keys in ascending order of their keys, with the same rules
+// as TopK.
+func BottomKBy[T any, K cmp.Ordered](elements []T, k int, key func(T) K) []T {
+	return selectK(elements, k, key, func(a, b K) bool { return a < b })
+}
+
+// keyed pairs an element with its precomputed key and input position.
+type keyed[T any, K cmp.Ordered] struct {
+	key   K
+	index int
+	value T
+}
+
+// sortByKey decorates every element with its key, sorts the decorated copy with the provided sort function and
+// returns the elements in the resulting order.
+func sortByKey[T any, K cmp.Ordered](elements []T, key func(T) K, sortFunc func([]keyed[T, K], func(a, b keyed[T, K]) int)) []T {
+	// Decorate every element with its key, computed once.
+	decorated := make([]keyed[T, K], len(elements))
+	for i, v := range elements {
+		decorated[i] = keyed[T, K]{key: key(v), index: i, value: v}
+	}
+
+	// Sort the decorated copy by key.
+	sortFunc(decorated, func(a, b keyed[T, K]) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	// Strip the keys again.
+	return Map(decorated, func(d keyed[T, K]) T { return d.value })
+}
+
+// selectK keeps the k best elements in a heap whose root is the worst of them, where better orders the keys.
+// Every later element only enters the heap if it is strictly better than the root, which keeps the first of
+// several equal elements.
+func selectK[T any, K cmp.Ordered](elements []T, k int, key func(T) K, better func(a, b K) bool) []T {
+	if k <= 0 || len(elements) == 0 {
+		return nil
+	}
+	k = min(k, len(elements))
+
+	// Fill the heap with the first k elements.
+	h := &selectHeap[T, K]{items: make([]keyed[T, K], 0, k), better: better}
+	for i, v := range elements[:k] {
+		h.items = append(h.items, keyed[T, K]{key: key(v), index: i, value: v})
+	}
+	heap.Init(h)
+
+	// Replace the worst kept element whenever a better one shows up.
+	for i, v := range elements[k:] {
+		candidate := keyed[T, K]{key: key(v), index: k + i, value: v}
+		if better(candidate.key, h.items[0].key) {
+			h.items[0] = candidate
+			heap.Fix(h, 0)
+		}
+	}
+
+	// Pop the kept elements from the worst to the best and fill the result from the back.
+	result := make([]T, k)
+	for i := k - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(keyed[T, K]).value
+	}
+	return result
+}
+
+// selectHeap is a heap of keyed elements with the worst element at the root, implementing heap.Interface.
+type selectHeap[T any, K cmp.Ordered] struct {
+	items  []keyed[T, K]
+	better func(a, b K) bool
+}
+
+// Len returns the number of kept elements.
+func (h *selectHeap[T, K]) Len() int {
+	return len(h.items)
+}
+
+// Less orders the elements from the worst to the best key, and from the last to the first input position
+// among equal keys, so the root is always the first element to give up.
+func (h *selectHeap[T, K]) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if a.key != b.key {
+		return h.better(b.key, a.key)
+	}
+	return a.index > b.index
+}
+
+// Swap exchanges two elements.
+func (h *selectHeap[T, K]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+// Push adds an element to the heap.
+func (h *selectHeap[T, K]) Push(x any) {
+	h.items = append(h.items, x.(keyed[T, K]))
+}
+
+// Pop removes the last element from the heap.
+func (h *selectHeap[T, K]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
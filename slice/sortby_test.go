@@ -0,0 +1,184 @@
+package slice
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spacemagneto/common/slice/proptest"
+	"github.com/stretchr/testify/assert"
+)
+
+// person is a record sorted by the tests of this file.
+type person struct {
+	Name string
+	Age  int
+}
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+
+	people := []person{{"Carol", 35}, {"alice", 30}, {"Bob", 25}, {"dave", 30}, {"Eve", 25}}
+
+	// Stable tests that elements with equal keys keep their input order.
+	t.Run("Stable", func(t *testing.T) {
+		result := SortStableBy(people, func(p person) int { return p.Age })
+		expected := []person{{"Bob", 25}, {"Eve", 25}, {"alice", 30}, {"dave", 30}, {"Carol", 35}}
+		assert.Equal(t, expected, result, "Sorted by age")
+		assert.Equal(t, "Carol", people[0].Name, "The input should not be modified")
+	})
+
+	// Unstable tests that SortBy orders by the key, whatever the order of equal keys.
+	t.Run("Unstable", func(t *testing.T) {
+		result := SortBy(people, func(p person) string { return strings.ToLower(p.Name) })
+		assert.Equal(t, []string{"alice", "Bob", "Carol", "dave", "Eve"}, Map(result, func(p person) string { return p.Name }), "Sorted by name")
+	})
+
+	// KeyCalledOnce tests that the key is computed exactly once per element.
+	t.Run("KeyCalledOnce", func(t *testing.T) {
+		calls := 0
+		key := func(v int) int { calls++; return -v }
+		elements := createSequenceWithoutRepeats(100)
+		result := SortStableBy(elements, key)
+		assert.Equal(t, len(elements), calls, "Key calls")
+		assert.Equal(t, 99, result[0], "Sorted by descending value")
+	})
+
+	// Empty tests that an empty input gives an empty result.
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, SortBy[int](nil, identity[int]), "SortBy of nil")
+		assert.Empty(t, SortStableBy[int](nil, identity[int]), "SortStableBy of nil")
+	})
+
+	// Laws tests the sorts against the standard library on generated inputs.
+	t.Run("Laws", func(t *testing.T) {
+		byValue := func(e []int) []int { return SortBy(e, identity[int]) }
+		proptest.Check(t, lawInts, proptest.All(
+			proptest.DoesNotMutate(byValue),
+			proptest.Equivalent(byValue, sortedInts),
+			proptest.DoesNotMutate(func(e []int) []int { return SortStableBy(e, func(v int) int { return v % 3 }) }),
+			proptest.Equivalent(
+				func(e []int) []int { return SortStableBy(e, func(v int) int { return v % 3 }) },
+				func(e []int) []int {
+					result := slices.Clone(e)
+					slices.SortStableFunc(result, func(a, b int) int { return a%3 - b%3 })
+					return result
+				},
+			),
+		))
+	})
+}
+
+func TestSortByKeys(t *testing.T) {
+	t.Parallel()
+
+	people := []person{{"Carol", 35}, {"alice", 30}, {"Bob", 25}, {"dave", 30}, {"Eve", 25}}
+	name := func(p person) string { return p.Name }
+
+	// Define test cases with different combinations of criteria.
+	cases := []struct {
+		name     string
+		keys     []SortKey[person]
+		expected []string
+	}{
+		{name: "No key keeps the input order", keys: nil, expected: []string{"Carol", "alice", "Bob", "dave", "Eve"}},
+		{
+			name:     "Age ascending, then name ascending",
+			keys:     []SortKey[person]{Ascending(func(p person) int { return p.Age }), Ascending(name)},
+			expected: []string{"Bob", "Eve", "alice", "dave", "Carol"},
+		},
+		{
+			name:     "Age descending, then name descending",
+			keys:     []SortKey[person]{Descending(func(p person) int { return p.Age }), Descending(name)},
+			expected: []string{"Carol", "dave", "alice", "Eve", "Bob"},
+		},
+		{
+			name:     "Single key is stable",
+			keys:     []SortKey[person]{Descending(func(p person) int { return p.Age })},
+			expected: []string{"Carol", "alice", "dave", "Bob", "Eve"},
+		},
+	}
+
+	// Iterate over the test cases, checking the order of the names.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SortByKeys(people, tt.keys...)
+			assert.Equal(t, tt.expected, Map(result, name), "Sorted names for case %q", tt.name)
+			assert.Equal(t, "Carol", people[0].Name, "The input should not be modified for case %q", tt.name)
+		})
+	}
+}
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	elements := []int{5, 1, 9, 3, 9, 7, 2}
+
+	// Define test cases for the largest and smallest elements.
+	cases := []struct {
+		name           string
+		k              int
+		expectedTop    []int
+		expectedBottom []int
+	}{
+		{name: "Zero", k: 0, expectedTop: nil, expectedBottom: nil},
+		{name: "Negative", k: -1, expectedTop: nil, expectedBottom: nil},
+		{name: "One", k: 1, expectedTop: []int{9}, expectedBottom: []int{1}},
+		{name: "Three", k: 3, expectedTop: []int{9, 9, 7}, expectedBottom: []int{1, 2, 3}},
+		{name: "More than the length", k: 10, expectedTop: []int{9, 9, 7, 5, 3, 2, 1}, expectedBottom: []int{1, 2, 3, 5, 7, 9, 9}},
+	}
+
+	// Iterate over the test cases, checking both directions.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedTop, TopK(elements, tt.k), "TopK for case %q", tt.name)
+			assert.Equal(t, tt.expectedBottom, BottomK(elements, tt.k), "BottomK for case %q", tt.name)
+		})
+	}
+
+	// Ties tests that the elements that come first in the input win among equal keys.
+	t.Run("Ties", func(t *testing.T) {
+		people := []person{{"Carol", 35}, {"alice", 30}, {"Bob", 25}, {"dave", 30}, {"Eve", 25}}
+		age := func(p person) int { return p.Age }
+		name := func(p person) string { return p.Name }
+
+		assert.Equal(t, []string{"Carol", "alice"}, Map(TopKBy(people, 2, age), name), "TopKBy keeps the first of equal ages")
+		assert.Equal(t, []string{"Bob"}, Map(BottomKBy(people, 1, age), name), "BottomKBy keeps the first of equal ages")
+		assert.Equal(t, []string{"Bob", "Eve", "alice"}, Map(BottomKBy(people, 3, age), name), "BottomKBy keeps the input order of ties")
+	})
+
+	// Empty tests that an empty input gives nil.
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, TopK[int](nil, 3), "TopK of nil")
+		assert.Nil(t, BottomK([]int{}, 3), "BottomK of an empty slice")
+	})
+
+	// Laws tests that the selections agree with a stable sort followed by a truncation.
+	t.Run("Laws", func(t *testing.T) {
+		for _, k := range []int{1, 3, 50} {
+			truncate := func(e []int) []int {
+				if len(e) == 0 {
+					return nil
+				}
+				return e[:min(k, len(e))]
+			}
+			key := func(v int) int { return v % 5 }
+			proptest.Check(t, lawInts, proptest.All(
+				proptest.DoesNotMutate(func(e []int) []int { return TopK(e, k) }),
+				proptest.Equivalent(func(e []int) []int { return BottomKBy(e, k, key) }, func(e []int) []int {
+					return truncate(SortStableBy(e, key))
+				}),
+				proptest.Equivalent(func(e []int) []int { return TopKBy(e, k, key) }, func(e []int) []int {
+					return truncate(SortStableBy(e, func(v int) int { return -key(v) }))
+				}),
+				func(e []int) error {
+					if top, expected := TopK(e, k), truncate(Reverse(sortedInts(e))); !slices.Equal(top, expected) {
+						return fmt.Errorf("TopK(%v, %d) = %v, want %v", e, k, top, expected)
+					}
+					return nil
+				},
+			))
+		}
+	})
+}
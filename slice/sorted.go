@@ -0,0 +1,225 @@
+package slice
+
+import (
+	"cmp"
+	"container/heap"
+)
+
+// SortedDedup removes consecutive duplicate elements from a sorted slice and returns a new slice.
+// When the input is sorted every duplicate is consecutive, so the result holds the same elements as Unique
+// without allocating a map. The input slice is never modified.
+func SortedDedup[T cmp.Ordered](elements []T) []T {
+	return SortedDedupFunc(elements, cmp.Compare[T])
+}
+
+// SortedDedupFunc is like SortedDedup but uses a comparison function that returns a negative number
+// when a < b, zero when a == b and a positive number when a > b.
+func SortedDedupFunc[T any](elements []T, compare func(a, b T) int) []T {
+	var result []T
+
+	// Append every element that differs from the previously kept one.
+	for _, v := range elements {
+		result = appendDistinct(result, v, compare)
+	}
+
+	// Return the deduplicated elements.
+	return result
+}
+
+// SortedUnion merges two sorted slices into a new sorted slice holding every distinct element of either input.
+// Both inputs are walked once in parallel, so the union costs O(len(a)+len(b)) time and no hashing.
+// Duplicates, both within and across the inputs, are collapsed into a single element.
+func SortedUnion[T cmp.Ordered](a, b []T) []T {
+	return SortedUnionFunc(a, b, cmp.Compare[T])
+}
+
+// SortedUnionFunc is like SortedUnion but uses a comparison function to order the elements.
+func SortedUnionFunc[T any](a, b []T, compare func(a, b T) int) []T {
+	// Allocate the result for the worst case where the inputs do not overlap.
+	result := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+
+	// Walk both inputs in parallel, always taking the smaller head.
+	for i < len(a) && j < len(b) {
+		switch c := compare(a[i], b[j]); {
+		case c < 0:
+			result = appendDistinct(result, a[i], compare)
+			i++
+		case c > 0:
+			result = appendDistinct(result, b[j], compare)
+			j++
+		default:
+			// Equal heads are emitted once and both inputs advance.
+			result = appendDistinct(result, a[i], compare)
+			i++
+			j++
+		}
+	}
+
+	// Append the remaining tail of whichever input is not exhausted yet.
+	for ; i < len(a); i++ {
+		result = appendDistinct(result, a[i], compare)
+	}
+	for ; j < len(b); j++ {
+		result = appendDistinct(result, b[j], compare)
+	}
+
+	// Return the sorted union.
+	return result
+}
+
+// SortedIntersect returns a new sorted slice holding every distinct element present in both sorted inputs.
+// A nil slice is returned when the inputs share no element.
+func SortedIntersect[T cmp.Ordered](a, b []T) []T {
+	return SortedIntersectFunc(a, b, cmp.Compare[T])
+}
+
+// SortedIntersectFunc is like SortedIntersect but uses a comparison function to order the elements.
+func SortedIntersectFunc[T any](a, b []T, compare func(a, b T) int) []T {
+	var result []T
+	i, j := 0, 0
+
+	// Walk both inputs in parallel and keep the heads that are equal.
+	for i < len(a) && j < len(b) {
+		switch c := compare(a[i], b[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			result = appendDistinct(result, a[i], compare)
+			i++
+			j++
+		}
+	}
+
+	// Return the sorted intersection.
+	return result
+}
+
+// SortedDifference returns a new sorted slice holding every distinct element of a that is not present in b.
+// Both inputs must be sorted. A nil slice is returned when every element of a is present in b.
+func SortedDifference[T cmp.Ordered](a, b []T) []T {
+	return SortedDifferenceFunc(a, b, cmp.Compare[T])
+}
+
+// SortedDifferenceFunc is like SortedDifference but uses a comparison function to order the elements.
+func SortedDifferenceFunc[T any](a, b []T, compare func(a, b T) int) []T {
+	var result []T
+	j := 0
+
+	// Walk the first input and advance the second one up to the current element.
+	for _, v := range a {
+		for j < len(b) && compare(b[j], v) < 0 {
+			j++
+		}
+
+		// Keep the element when the second input does not hold it.
+		if j < len(b) && compare(b[j], v) == 0 {
+			continue
+		}
+		result = appendDistinct(result, v, compare)
+	}
+
+	// Return the sorted difference.
+	return result
+}
+
+// SortedMergeK merges any number of sorted slices into a single new sorted slice.
+// Unlike Merge, which only concatenates, the result is ordered, and unlike SortedUnion, duplicates are kept.
+// The merge uses a min-heap over the heads of the inputs, which costs O(n log k) time for n elements
+// spread over k inputs. Equal elements keep the order of the inputs they come from, so the merge is stable.
+func SortedMergeK[T cmp.Ordered](lists ...[]T) []T {
+	return SortedMergeKFunc(cmp.Compare[T], lists...)
+}
+
+// SortedMergeKFunc is like SortedMergeK but uses a comparison function to order the elements.
+func SortedMergeKFunc[T any](compare func(a, b T) int, lists ...[]T) []T {
+	// Count the elements of every input to allocate the result once.
+	total := 0
+	for _, list := range lists {
+		total += len(list)
+	}
+	result := make([]T, 0, total)
+
+	// Seed the heap with the first element of every non-empty input.
+	h := &mergeHeap[T]{compare: compare}
+	for i, list := range lists {
+		if len(list) > 0 {
+			h.cursors = append(h.cursors, mergeCursor[T]{list: list, source: i})
+		}
+	}
+	heap.Init(h)
+
+	// Repeatedly take the smallest head and advance the input it came from.
+	for h.Len() > 0 {
+		top := &h.cursors[0]
+		result = append(result, top.list[top.pos])
+		top.pos++
+
+		// Drop the exhausted input, or restore the heap order with the new head.
+		if top.pos == len(top.list) {
+			heap.Pop(h)
+		} else {
+			heap.Fix(h, 0)
+		}
+	}
+
+	// Return the merged elements.
+	return result
+}
+
+// appendDistinct appends v to the sorted result unless it equals the last element already there.
+func appendDistinct[T any](result []T, v T, compare func(a, b T) int) []T {
+	if len(result) > 0 && compare(result[len(result)-1], v) == 0 {
+		return result
+	}
+	return append(result, v)
+}
+
+// mergeCursor tracks the read position inside one of the inputs of a k-way merge.
+type mergeCursor[T any] struct {
+	// list is the sorted input being merged.
+	list []T
+	// pos is the index of the current head of the input.
+	pos int
+	// source is the position of the input among all inputs, used to keep the merge stable.
+	source int
+}
+
+// mergeHeap is a min-heap of cursors ordered by their current head, implementing heap.Interface.
+type mergeHeap[T any] struct {
+	cursors []mergeCursor[T]
+	compare func(a, b T) int
+}
+
+// Len returns the number of inputs that still have elements.
+func (h *mergeHeap[T]) Len() int {
+	return len(h.cursors)
+}
+
+// Less orders the cursors by their head, and by input position when the heads are equal.
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.cursors[i], h.cursors[j]
+	if c := h.compare(a.list[a.pos], b.list[b.pos]); c != 0 {
+		return c < 0
+	}
+	return a.source < b.source
+}
+
+// Swap exchanges two cursors.
+func (h *mergeHeap[T]) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+// Push adds a cursor to the heap.
+func (h *mergeHeap[T]) Push(x any) {
+	h.cursors = append(h.cursors, x.(mergeCursor[T]))
+}
+
+// Pop removes the last cursor from the heap.
+func (h *mergeHeap[T]) Pop() any {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
@@ -0,0 +1,88 @@
+package slice
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SortedIndex is a sorted, read-only copy of a slice that answers repeated membership and ordering queries
+// by binary search. Building the index costs O(n log n) time and O(n) memory once, after which every query
+// costs O(log n), so it pays off over Contains when the same slice is searched many times.
+// The zero value is an empty index that is ready to use.
+type SortedIndex[T constraints.Ordered] struct {
+	// elements holds a sorted copy of the indexed slice.
+	elements []T
+}
+
+// NewSortedIndex builds an index over a copy of the provided elements, so later changes to the input slice
+// do not affect the index. Duplicate elements are kept.
+func NewSortedIndex[T constraints.Ordered](elements []T) *SortedIndex[T] {
+	// Create a copy of the input slice to avoid modifying the original slice.
+	copiedElements := make([]T, len(elements))
+	copy(copiedElements, elements)
+
+	// Sort the copy in ascending order once, so every query can use binary search.
+	sort.Slice(copiedElements, func(i, j int) bool {
+		return copiedElements[i] < copiedElements[j]
+	})
+
+	// Return the index over the sorted copy.
+	return &SortedIndex[T]{elements: copiedElements}
+}
+
+// Len returns the number of indexed elements, including duplicates.
+func (s *SortedIndex[T]) Len() int {
+	return len(s.elements)
+}
+
+// Values returns the indexed elements in ascending order.
+// The returned slice is shared with the index and must not be modified.
+func (s *SortedIndex[T]) Values() []T {
+	return s.elements
+}
+
+// Has reports whether the element is present in the index.
+func (s *SortedIndex[T]) Has(element T) bool {
+	_, ok := s.IndexOf(element)
+	return ok
+}
+
+// IndexOf returns the position of the first occurrence of the element in the sorted order.
+// The boolean result is false when the element is not present, in which case the position is -1.
+func (s *SortedIndex[T]) IndexOf(element T) (int, bool) {
+	// Find the first position holding a value greater than or equal to the element.
+	index := s.Rank(element)
+
+	// Validate the index and check whether the element at the found position matches the search element.
+	if index < len(s.elements) && s.elements[index] == element {
+		return index, true
+	}
+
+	// The element is not present in the index.
+	return -1, false
+}
+
+// Rank returns the number of indexed elements strictly less than the provided element.
+// It is also the position at which the element would be inserted to keep the index sorted.
+func (s *SortedIndex[T]) Rank(element T) int {
+	// The lower bound is the index of the first element greater than or equal to `element`,
+	// which is exactly the number of elements that are strictly less than it.
+	return LowerBound(s.elements, element)
+}
+
+// Range returns the indexed elements in the half-open interval [from, to), in ascending order.
+// The returned slice is shared with the index and must not be modified.
+// An empty interval, including one where from is not less than to, returns an empty slice.
+func (s *SortedIndex[T]) Range(from, to T) []T {
+	// Locate both bounds by binary search.
+	lo, hi := s.Rank(from), s.Rank(to)
+
+	// An inverted interval contains no element.
+	if hi < lo {
+		hi = lo
+	}
+
+	// Return a view over the matching part of the sorted elements.
+	return s.elements[lo:hi:hi]
+}
@@ -0,0 +1,99 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedIndex(t *testing.T) {
+	t.Parallel()
+
+	// Queries tests the Has, IndexOf and Rank queries against an unsorted input with duplicates.
+	// The sorted order of the input is [1 2 3 3 5 8], which determines the expected positions.
+	t.Run("Queries", func(t *testing.T) {
+		index := NewSortedIndex([]int{5, 3, 8, 1, 3, 2})
+
+		cases := []struct {
+			name          string
+			element       int
+			expectedHas   bool
+			expectedIndex int
+			expectedRank  int
+		}{
+			{name: "Smallest element", element: 1, expectedHas: true, expectedIndex: 0, expectedRank: 0},
+			{name: "Duplicated element", element: 3, expectedHas: true, expectedIndex: 2, expectedRank: 2},
+			{name: "Largest element", element: 8, expectedHas: true, expectedIndex: 5, expectedRank: 5},
+			{name: "Missing element in the middle", element: 4, expectedHas: false, expectedIndex: -1, expectedRank: 4},
+			{name: "Missing element below", element: 0, expectedHas: false, expectedIndex: -1, expectedRank: 0},
+			{name: "Missing element above", element: 9, expectedHas: false, expectedIndex: -1, expectedRank: 6},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				// Verify the membership query.
+				assert.Equal(t, tt.expectedHas, index.Has(tt.element), "Has result for case %q", tt.name)
+
+				// Verify the position query.
+				position, ok := index.IndexOf(tt.element)
+				assert.Equal(t, tt.expectedHas, ok, "IndexOf presence for case %q", tt.name)
+				assert.Equal(t, tt.expectedIndex, position, "IndexOf position for case %q", tt.name)
+
+				// Verify the rank query.
+				assert.Equal(t, tt.expectedRank, index.Rank(tt.element), "Rank result for case %q", tt.name)
+			})
+		}
+	})
+
+	// Range tests the half-open interval query.
+	t.Run("Range", func(t *testing.T) {
+		index := NewSortedIndex([]string{"delta", "alpha", "charlie", "bravo", "echo"})
+
+		cases := []struct {
+			name     string
+			from, to string
+			expected []string
+		}{
+			{name: "Inner interval", from: "b", to: "d", expected: []string{"bravo", "charlie"}},
+			{name: "Inclusive lower bound", from: "bravo", to: "delta", expected: []string{"bravo", "charlie"}},
+			{name: "Whole index", from: "", to: "z", expected: []string{"alpha", "bravo", "charlie", "delta", "echo"}},
+			{name: "Empty interval", from: "x", to: "z", expected: []string{}},
+			{name: "Inverted interval", from: "d", to: "b", expected: []string{}},
+		}
+
+		// Iterate over the defined test cases, executing each one as a subtest.
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, index.Range(tt.from, tt.to), "Range result for case %q", tt.name)
+			})
+		}
+	})
+
+	// Isolation tests that the index works on its own copy of the input and that range results cannot
+	// be used to overwrite indexed elements through append.
+	t.Run("Isolation", func(t *testing.T) {
+		input := []int{3, 1, 2}
+		index := NewSortedIndex(input)
+
+		// Modifying the input after building the index must not affect it, and the input must not be sorted in place.
+		input[0] = 100
+		assert.False(t, index.Has(100), "The index should not observe changes to the input")
+		assert.Equal(t, []int{100, 1, 2}, input, "Building the index should not reorder the input")
+
+		// Appending to a range result must not overwrite the element that follows it in the index.
+		head := index.Range(1, 2)
+		_ = append(head, 42)
+		assert.Equal(t, []int{1, 2, 3}, index.Values(), "Appending to a range should not modify the index")
+	})
+
+	// Empty tests both an index built from a nil slice and the zero value.
+	t.Run("Empty", func(t *testing.T) {
+		for _, index := range []*SortedIndex[int]{NewSortedIndex[int](nil), {}} {
+			assert.Zero(t, index.Len(), "An empty index should have no elements")
+			assert.False(t, index.Has(0), "An empty index should not contain anything")
+			assert.Zero(t, index.Rank(10), "Every rank in an empty index should be zero")
+			assert.Empty(t, index.Range(0, 10), "Every range in an empty index should be empty")
+		}
+	})
+}
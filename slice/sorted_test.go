@@ -0,0 +1,145 @@
+package slice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedDedup(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for sorted inputs with and without duplicates.
+	cases := []struct {
+		name     string
+		elements []int
+		expected []int
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "No duplicates", elements: []int{1, 2, 3}, expected: []int{1, 2, 3}},
+		{name: "Runs of duplicates", elements: []int{1, 1, 2, 3, 3, 3, 4}, expected: []int{1, 2, 3, 4}},
+		{name: "All equal", elements: []int{7, 7, 7}, expected: []int{7}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// The result must match Unique, which is the hash-based equivalent for sorted input.
+			result := SortedDedup(tt.elements)
+			assert.Equal(t, tt.expected, result, "SortedDedup result for case %q", tt.name)
+			assert.Equal(t, Unique(tt.elements), result, "SortedDedup should agree with Unique for case %q", tt.name)
+		})
+	}
+
+	// Func tests the comparator variant with a case-insensitive comparison.
+	t.Run("Func", func(t *testing.T) {
+		result := SortedDedupFunc([]string{"a", "A", "b", "B", "b"}, func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+		assert.Equal(t, []string{"a", "b"}, result, "The first element of every run should be kept")
+	})
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases covering disjoint, overlapping, nested and duplicated sorted inputs.
+	cases := []struct {
+		name              string
+		a, b              []int
+		expectedUnion     []int
+		expectedIntersect []int
+		expectedDiff      []int
+	}{
+		{name: "Both nil", a: nil, b: nil, expectedUnion: []int{}, expectedIntersect: nil, expectedDiff: nil},
+		{name: "First nil", a: nil, b: []int{1, 2}, expectedUnion: []int{1, 2}, expectedIntersect: nil, expectedDiff: nil},
+		{name: "Second nil", a: []int{1, 2}, b: nil, expectedUnion: []int{1, 2}, expectedIntersect: nil, expectedDiff: []int{1, 2}},
+		{name: "Disjoint", a: []int{1, 3, 5}, b: []int{2, 4, 6}, expectedUnion: []int{1, 2, 3, 4, 5, 6}, expectedIntersect: nil, expectedDiff: []int{1, 3, 5}},
+		{name: "Overlapping", a: []int{1, 2, 3, 4}, b: []int{3, 4, 5}, expectedUnion: []int{1, 2, 3, 4, 5}, expectedIntersect: []int{3, 4}, expectedDiff: []int{1, 2}},
+		{name: "Nested", a: []int{1, 2, 3}, b: []int{2}, expectedUnion: []int{1, 2, 3}, expectedIntersect: []int{2}, expectedDiff: []int{1, 3}},
+		{name: "Duplicates collapse", a: []int{1, 1, 2, 2, 3}, b: []int{2, 2, 3, 3, 4}, expectedUnion: []int{1, 2, 3, 4}, expectedIntersect: []int{2, 3}, expectedDiff: []int{1}},
+		{name: "Identical", a: []int{1, 2}, b: []int{1, 2}, expectedUnion: []int{1, 2}, expectedIntersect: []int{1, 2}, expectedDiff: nil},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedUnion, SortedUnion(tt.a, tt.b), "SortedUnion result for case %q", tt.name)
+			assert.Equal(t, tt.expectedIntersect, SortedIntersect(tt.a, tt.b), "SortedIntersect result for case %q", tt.name)
+			assert.Equal(t, tt.expectedDiff, SortedDifference(tt.a, tt.b), "SortedDifference result for case %q", tt.name)
+		})
+	}
+
+	// GeneratedData compares the merge-based operations with the hash-based helpers on larger inputs.
+	t.Run("GeneratedData", func(t *testing.T) {
+		// Build two sorted inputs that overlap on the multiples of three.
+		a := Filter(createSequenceWithoutRepeats(3000), func(n int) bool { return n%2 == 0 || n%3 == 0 })
+		b := Filter(createSequenceWithoutRepeats(3000), func(n int) bool { return n%3 == 0 || n%5 == 0 })
+
+		// The union must match deduplicating the concatenation and sorting it.
+		expectedUnion := Unique(Merge(a, b))
+		sort.Ints(expectedUnion)
+		assert.Equal(t, expectedUnion, SortedUnion(a, b), "SortedUnion should match Unique over Merge")
+
+		// The intersection and the difference must match filtering with Contains.
+		assert.Equal(t, Filter(a, func(n int) bool { return Contains(b, n) }), SortedIntersect(a, b), "SortedIntersect should match filtering")
+		assert.Equal(t, Filter(a, func(n int) bool { return !Contains(b, n) }), SortedDifference(a, b), "SortedDifference should match filtering")
+	})
+
+	// Func tests the comparator variants with inputs sorted in descending order.
+	t.Run("Func", func(t *testing.T) {
+		descending := func(a, b int) int { return b - a }
+		a, b := []int{5, 4, 2}, []int{4, 3, 2, 1}
+
+		assert.Equal(t, []int{5, 4, 3, 2, 1}, SortedUnionFunc(a, b, descending), "SortedUnionFunc should follow the comparator")
+		assert.Equal(t, []int{4, 2}, SortedIntersectFunc(a, b, descending), "SortedIntersectFunc should follow the comparator")
+		assert.Equal(t, []int{5}, SortedDifferenceFunc(a, b, descending), "SortedDifferenceFunc should follow the comparator")
+	})
+}
+
+func TestSortedMergeK(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for merging any number of sorted inputs.
+	cases := []struct {
+		name     string
+		lists    [][]int
+		expected []int
+	}{
+		{name: "No inputs", lists: nil, expected: []int{}},
+		{name: "Only empty inputs", lists: [][]int{nil, {}}, expected: []int{}},
+		{name: "Single input", lists: [][]int{{1, 2, 3}}, expected: []int{1, 2, 3}},
+		{name: "Two inputs", lists: [][]int{{1, 4, 7}, {2, 3, 8}}, expected: []int{1, 2, 3, 4, 7, 8}},
+		{name: "Duplicates are kept", lists: [][]int{{1, 2, 2}, {2, 3}, {1}}, expected: []int{1, 1, 2, 2, 2, 3}},
+		{name: "Uneven lengths", lists: [][]int{{10}, {}, {1, 2, 3, 4, 5}, {6, 11}}, expected: []int{1, 2, 3, 4, 5, 6, 10, 11}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SortedMergeK(tt.lists...), "SortedMergeK result for case %q", tt.name)
+		})
+	}
+
+	// Stable tests that equal elements keep the order of the inputs they come from.
+	t.Run("Stable", func(t *testing.T) {
+		type item struct {
+			key    int
+			source string
+		}
+		byKey := func(a, b item) int { return a.key - b.key }
+
+		result := SortedMergeKFunc(byKey,
+			[]item{{1, "a"}, {2, "a"}},
+			[]item{{1, "b"}, {2, "b"}},
+			[]item{{1, "c"}},
+		)
+
+		// Collect the sources to check the order in which equal keys were emitted.
+		sources := Map(result, func(i item) string { return fmt.Sprintf("%d%s", i.key, i.source) })
+		assert.Equal(t, []string{"1a", "1b", "1c", "2a", "2b"}, sources, "Equal keys should keep the input order")
+	})
+}
@@ -0,0 +1,137 @@
+package slice
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// SyncSlice is a slice that is safe for concurrent use by multiple goroutines.
+// Writers take an exclusive lock and modify the elements in place. Readers work on an immutable snapshot that is
+// copied once after every write and then shared, copy-on-write style, so read-heavy workloads read without any
+// lock while the snapshot is current. Callbacks of the read operations run on the snapshot without holding the
+// lock, so they may freely call back into the SyncSlice. The zero value is an empty slice that is ready to use.
+// A SyncSlice must not be copied after first use.
+type SyncSlice[T any] struct {
+	// mu guards elements.
+	mu sync.RWMutex
+	// elements holds the current elements, modified in place by the writers.
+	elements []T
+	// snapshot caches an immutable copy of elements, or nil when a write happened since it was taken.
+	snapshot atomic.Pointer[[]T]
+}
+
+// NewSyncSlice creates a SyncSlice holding a copy of the elements.
+func NewSyncSlice[T any](elements []T) *SyncSlice[T] {
+	return &SyncSlice[T]{elements: Merge(elements, nil)}
+}
+
+// Snapshot returns the elements at the time of the call. The returned slice is shared with other readers and
+// must not be modified; use Slice to get a private copy. Later writes never show up in a returned snapshot.
+func (s *SyncSlice[T]) Snapshot() []T {
+	// Serve the cached snapshot without locking when no write happened since it was taken.
+	if p := s.snapshot.Load(); p != nil {
+		return *p
+	}
+
+	// Copy the elements under the read lock, which keeps the writers out until the copy is published.
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot := Merge(s.elements, nil)
+	s.snapshot.Store(&snapshot)
+	return snapshot
+}
+
+// Slice returns a private copy of the elements, which the caller may modify.
+func (s *SyncSlice[T]) Slice() []T {
+	return Merge(s.Snapshot(), nil)
+}
+
+// Len returns the number of elements.
+func (s *SyncSlice[T]) Len() int {
+	if p := s.snapshot.Load(); p != nil {
+		return len(*p)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.elements)
+}
+
+// Get returns the element at the index and true, or the zero value and false when the index is out of range.
+func (s *SyncSlice[T]) Get(index int) (T, bool) {
+	elements := s.Snapshot()
+	if index < 0 || index >= len(elements) {
+		var zero T
+		return zero, false
+	}
+	return elements[index], true
+}
+
+// All returns a sequence over the indices and elements of a snapshot taken when the iteration starts.
+// The lock is not held while the loop body runs, so the body may modify the SyncSlice without deadlocking;
+// such writes are not visible to the ongoing iteration.
+func (s *SyncSlice[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range s.Snapshot() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Append adds the elements at the end.
+func (s *SyncSlice[T]) Append(elements ...T) {
+	s.Update(func(current []T) []T { return append(current, elements...) })
+}
+
+// Clear removes every element.
+func (s *SyncSlice[T]) Clear() {
+	s.Update(func(current []T) []T {
+		// Zero the elements, so the backing array kept for reuse does not retain them.
+		clear(current)
+		return current[:0]
+	})
+}
+
+// Update replaces the elements with the result of fn, atomically: no other write can happen between the call of fn
+// and the replacement. fn receives the current elements and may modify them in place or return a new slice, but
+// must not keep a reference to them after returning. It runs under the exclusive lock, so it must not call any
+// method of the SyncSlice.
+func (s *SyncSlice[T]) Update(fn func([]T) []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.elements = fn(s.elements)
+
+	// Invalidate the snapshot, so the next read copies the new elements.
+	s.snapshot.Store(nil)
+}
+
+// Filter returns a new slice holding the elements of a snapshot that satisfy the predicate, like Filter.
+// The predicate runs without holding the lock.
+func (s *SyncSlice[T]) Filter(fn func(T) bool) []T {
+	return Filter(s.Snapshot(), fn)
+}
+
+// FilterInPlace atomically removes the elements that do not satisfy the predicate, like FilterInPlace.
+// The predicate runs under the exclusive lock, so it must not call any method of the SyncSlice.
+func (s *SyncSlice[T]) FilterInPlace(fn func(T) bool) {
+	s.Update(func(current []T) []T { return FilterInPlace(current, fn) })
+}
+
+// SyncMap returns a new slice holding the result of the transformation function applied to every element of a
+// snapshot of the SyncSlice, like Map. The transformation runs without holding the lock.
+func SyncMap[A, B any](s *SyncSlice[A], fn func(A) B) []B {
+	return Map(s.Snapshot(), fn)
+}
+
+// SyncUnique returns a new slice holding the distinct elements of a snapshot of the SyncSlice, like Unique.
+func SyncUnique[T comparable](s *SyncSlice[T]) []T {
+	return Unique(s.Snapshot())
+}
+
+// SyncUniqueInPlace atomically removes the duplicate elements of the SyncSlice, keeping the first occurrence of
+// every element, like UniqueInPlace.
+func SyncUniqueInPlace[T comparable](s *SyncSlice[T]) {
+	s.Update(UniqueInPlace[T])
+}
@@ -0,0 +1,157 @@
+package slice
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncSlice(t *testing.T) {
+	t.Parallel()
+
+	// Basics tests the single-goroutine behaviour, starting from the zero value.
+	t.Run("Basics", func(t *testing.T) {
+		var s SyncSlice[int]
+		assert.Zero(t, s.Len(), "The zero value should be empty")
+		_, ok := s.Get(0)
+		assert.False(t, ok, "Get on the zero value")
+
+		s.Append(1, 2, 2, 3)
+		s.Append(4)
+		assert.Equal(t, 5, s.Len(), "Length after Append")
+		v, ok := s.Get(2)
+		assert.True(t, ok, "Get in range")
+		assert.Equal(t, 2, v, "Get in range")
+		_, ok = s.Get(-1)
+		assert.False(t, ok, "Get out of range")
+
+		assert.Equal(t, []int{2, 2, 4}, s.Filter(func(v int) bool { return v%2 == 0 }), "Filter")
+		assert.Equal(t, []string{"1", "2", "2", "3", "4"}, SyncMap(&s, func(v int) string { return string(rune('0' + v)) }), "SyncMap")
+		assert.Equal(t, []int{1, 2, 3, 4}, SyncUnique(&s), "SyncUnique")
+		assert.Equal(t, []int{1, 2, 2, 3, 4}, s.Snapshot(), "The read operations should not modify the elements")
+
+		SyncUniqueInPlace(&s)
+		assert.Equal(t, []int{1, 2, 3, 4}, s.Snapshot(), "SyncUniqueInPlace")
+		s.FilterInPlace(func(v int) bool { return v > 1 })
+		assert.Equal(t, []int{2, 3, 4}, s.Snapshot(), "FilterInPlace")
+
+		s.Clear()
+		assert.Zero(t, s.Len(), "Clear")
+	})
+
+	// Snapshot tests that a snapshot is immutable and shared until the next write.
+	t.Run("Snapshot", func(t *testing.T) {
+		s := NewSyncSlice([]int{1, 2, 3})
+		first := s.Snapshot()
+		assert.Same(t, &first[0], &s.Snapshot()[0], "Snapshots should be shared between writes")
+
+		s.Append(4)
+		assert.Equal(t, []int{1, 2, 3}, first, "A snapshot should not see later writes")
+		assert.Equal(t, []int{1, 2, 3, 4}, s.Snapshot(), "A new snapshot should see the write")
+
+		private := s.Slice()
+		private[0] = 100
+		assert.Equal(t, []int{1, 2, 3, 4}, s.Snapshot(), "Modifying the private copy should not leak")
+	})
+
+	// ReentrantIteration tests that the iteration and the read callbacks do not hold the lock, so they can write
+	// to the SyncSlice without deadlocking.
+	t.Run("ReentrantIteration", func(t *testing.T) {
+		s := NewSyncSlice([]int{1, 2, 3})
+		var visited []int
+		for i, v := range s.All() {
+			visited = append(visited, v)
+			s.Append(v * 10)
+			if i == 1 {
+				break
+			}
+		}
+		assert.Equal(t, []int{1, 2}, visited, "The iteration should stop at the break and ignore the appended elements")
+
+		s.Filter(func(v int) bool { s.Append(0); return true })
+		assert.Equal(t, 10, s.Len(), "Every call of the Filter predicate should append")
+	})
+
+	// Update tests that a read-modify-write is atomic under contention: no increment may be lost.
+	t.Run("Update", func(t *testing.T) {
+		s := NewSyncSlice([]int{0})
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 500 {
+					s.Update(func(e []int) []int { e[0]++; return e })
+				}
+			}()
+		}
+		wg.Wait()
+		v, _ := s.Get(0)
+		assert.Equal(t, 4000, v, "Atomic increments")
+	})
+}
+
+// TestSyncSliceStress hammers a SyncSlice from concurrent writers and readers. It checks invariants that only
+// hold if every operation is atomic, and is meant to run with the race detector: go test -race -run Stress.
+func TestSyncSliceStress(t *testing.T) {
+	t.Parallel()
+
+	const writers, readers, rounds = 4, 4, 300
+	s := NewSyncSlice[int](nil)
+	var wg sync.WaitGroup
+
+	// Writers append pairs of equal elements and remove the odd ones, so the elements always come in pairs.
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				v := w*rounds + i
+				s.Update(func(e []int) []int { return append(e, v, v) })
+				if i%10 == 0 {
+					s.FilterInPlace(func(v int) bool { return v%2 == 0 })
+				}
+			}
+		}()
+	}
+
+	// Readers check that every snapshot, iteration and derived result sees whole pairs only.
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range rounds {
+				snapshot := s.Snapshot()
+				if len(snapshot)%2 != 0 {
+					t.Errorf("snapshot of odd length %d", len(snapshot))
+					return
+				}
+				counts := make(map[int]int)
+				for _, v := range s.All() {
+					counts[v]++
+				}
+				for v, n := range counts {
+					if n != 2 {
+						t.Errorf("element %d seen %d times in one iteration", v, n)
+						return
+					}
+				}
+				if unique := Unique(snapshot); len(unique)*2 != len(snapshot) {
+					t.Errorf("snapshot of %d elements holds %d distinct ones", len(snapshot), len(unique))
+					return
+				}
+				_ = SyncUnique(s)
+				_ = SyncMap(s, func(v int) int { return v * 2 })
+				_ = s.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// After a last filter, every pair of even elements and none of the odd ones must be left.
+	s.FilterInPlace(func(v int) bool { return v%2 == 0 })
+	result := s.Slice()
+	assert.Len(t, result, writers*rounds, "Remaining elements")
+	assert.Equal(t, writers*rounds/2, len(Unique(result)), "Distinct elements")
+}
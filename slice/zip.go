@@ -0,0 +1,191 @@
+package slice
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLengthMismatch is reported by the zipping functions under the RequireEqualLength policy
+// when the input slices do not have the same length.
+var ErrLengthMismatch = errors.New("length mismatch")
+
+// LengthPolicy controls how the zipping functions handle input slices of different lengths.
+type LengthPolicy int
+
+const (
+	// TruncateToShortest stops at the end of the shortest input and ignores the remaining elements.
+	TruncateToShortest LengthPolicy = iota
+	// RequireEqualLength fails with ErrLengthMismatch when the inputs do not have the same length.
+	RequireEqualLength
+)
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	// First is the value taken from the first slice.
+	First A
+	// Second is the value taken from the second slice.
+	Second B
+}
+
+// Triple holds three values of possibly different types.
+type Triple[A, B, C any] struct {
+	// First is the value taken from the first slice.
+	First A
+	// Second is the value taken from the second slice.
+	Second B
+	// Third is the value taken from the third slice.
+	Third C
+}
+
+// Zip combines the elements at the same position of two slices into pairs.
+// The policy decides what happens when the slices have different lengths; under RequireEqualLength
+// a nil slice is returned together with an error wrapping ErrLengthMismatch.
+func Zip[A, B any](first []A, second []B, policy LengthPolicy) ([]Pair[A, B], error) {
+	return ZipWith(first, second, policy, func(a A, b B) Pair[A, B] { return Pair[A, B]{First: a, Second: b} })
+}
+
+// ZipWith combines the elements at the same position of two slices using the provided function.
+// It behaves like Zip followed by Map, without allocating the intermediate pairs.
+func ZipWith[A, B, C any](first []A, second []B, policy LengthPolicy, fn func(A, B) C) ([]C, error) {
+	// Determine how many positions can be combined under the requested policy.
+	n, err := zipLength(policy, len(first), len(second))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the result with one element per combined position, exactly like Map.
+	result := make([]C, n)
+
+	// Combine the elements at every position.
+	for i := 0; i < n; i++ {
+		result[i] = fn(first[i], second[i])
+	}
+
+	// Return the combined elements.
+	return result, nil
+}
+
+// Zip3 combines the elements at the same position of three slices into triples.
+// The policy applies to all three lengths at once.
+func Zip3[A, B, C any](first []A, second []B, third []C, policy LengthPolicy) ([]Triple[A, B, C], error) {
+	// Determine how many positions can be combined under the requested policy.
+	n, err := zipLength(policy, len(first), len(second), len(third))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the result with one triple per combined position.
+	result := make([]Triple[A, B, C], n)
+
+	// Combine the elements at every position.
+	for i := 0; i < n; i++ {
+		result[i] = Triple[A, B, C]{First: first[i], Second: second[i], Third: third[i]}
+	}
+
+	// Return the combined triples.
+	return result, nil
+}
+
+// ZipLongest combines the elements at the same position of two slices into pairs, continuing up to the end
+// of the longest slice. Positions missing from the shorter slice are filled with the provided fill values.
+func ZipLongest[A, B any](first []A, second []B, fillFirst A, fillSecond B) []Pair[A, B] {
+	// Create the result with one pair per position of the longest slice.
+	result := make([]Pair[A, B], max(len(first), len(second)))
+
+	// Combine the elements at every position, falling back to the fill values past the end of a slice.
+	for i := range result {
+		result[i] = Pair[A, B]{First: fillFirst, Second: fillSecond}
+		if i < len(first) {
+			result[i].First = first[i]
+		}
+		if i < len(second) {
+			result[i].Second = second[i]
+		}
+	}
+
+	// Return the combined pairs.
+	return result
+}
+
+// Unzip splits a slice of pairs into two slices holding the first and the second values respectively.
+// Both results have the same length as the input.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	// Create both results with the same length as the input.
+	first, second := make([]A, len(pairs)), make([]B, len(pairs))
+
+	// Split every pair into its two values.
+	for i, p := range pairs {
+		first[i], second[i] = p.First, p.Second
+	}
+
+	// Return both halves.
+	return first, second
+}
+
+// Unzip3 splits a slice of triples into three slices holding the first, second and third values respectively.
+func Unzip3[A, B, C any](triples []Triple[A, B, C]) ([]A, []B, []C) {
+	// Create every result with the same length as the input.
+	first, second, third := make([]A, len(triples)), make([]B, len(triples)), make([]C, len(triples))
+
+	// Split every triple into its three values.
+	for i, t := range triples {
+		first[i], second[i], third[i] = t.First, t.Second, t.Third
+	}
+
+	// Return the three parts.
+	return first, second, third
+}
+
+// CartesianProduct returns every pair made of one element of the first slice and one element of the second slice.
+// The pairs are ordered by the position in the first slice, then by the position in the second slice.
+// The result is empty when either slice is empty.
+func CartesianProduct[A, B any](first []A, second []B) []Pair[A, B] {
+	// Create the result with room for every combination.
+	result := make([]Pair[A, B], 0, len(first)*len(second))
+
+	// Combine every element of the first slice with every element of the second slice.
+	for _, a := range first {
+		for _, b := range second {
+			result = append(result, Pair[A, B]{First: a, Second: b})
+		}
+	}
+
+	// Return the combinations.
+	return result
+}
+
+// Enumerate pairs every element of a slice with its position.
+func Enumerate[T any](elements []T) []Pair[int, T] {
+	// Create the result with the same length as the input, exactly like Map.
+	result := make([]Pair[int, T], len(elements))
+
+	// Pair every element with its index.
+	for i, v := range elements {
+		result[i] = Pair[int, T]{First: i, Second: v}
+	}
+
+	// Return the enumerated elements.
+	return result
+}
+
+// zipLength returns how many positions can be combined from inputs of the provided lengths under the policy.
+// Under RequireEqualLength it fails when any length differs from the first one.
+func zipLength(policy LengthPolicy, lengths ...int) (int, error) {
+	// Find the shortest input.
+	n := lengths[0]
+	for _, l := range lengths[1:] {
+		n = min(n, l)
+	}
+
+	// Reject inputs of different lengths when the policy requires them to be equal.
+	if policy == RequireEqualLength {
+		for _, l := range lengths[1:] {
+			if l != lengths[0] {
+				return 0, fmt.Errorf("%w: %v", ErrLengthMismatch, lengths)
+			}
+		}
+	}
+
+	// Return the number of positions that can be combined.
+	return n, nil
+}
@@ -0,0 +1,154 @@
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases combining equal and different lengths with both policies.
+	cases := []struct {
+		name        string
+		keys        []string
+		values      []int
+		policy      LengthPolicy
+		expected    []Pair[string, int]
+		expectedErr string
+	}{
+		{name: "Both nil", keys: nil, values: nil, policy: RequireEqualLength, expected: []Pair[string, int]{}},
+		{name: "Equal lengths", keys: []string{"a", "b"}, values: []int{1, 2}, policy: RequireEqualLength, expected: []Pair[string, int]{{"a", 1}, {"b", 2}}},
+		{name: "Truncate longer second", keys: []string{"a"}, values: []int{1, 2, 3}, policy: TruncateToShortest, expected: []Pair[string, int]{{"a", 1}}},
+		{name: "Truncate longer first", keys: []string{"a", "b", "c"}, values: []int{1, 2}, policy: TruncateToShortest, expected: []Pair[string, int]{{"a", 1}, {"b", 2}}},
+		{name: "Truncate with empty", keys: []string{"a"}, values: nil, policy: TruncateToShortest, expected: []Pair[string, int]{}},
+		{name: "Reject different lengths", keys: []string{"a", "b", "c"}, values: []int{1, 2}, policy: RequireEqualLength, expected: nil, expectedErr: "length mismatch: [3 2]"},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Zip(tt.keys, tt.values, tt.policy)
+			assert.Equal(t, tt.expected, result, "Zip result for case %q", tt.name)
+
+			// Verify the reported mismatch, if one is expected.
+			if tt.expectedErr == "" {
+				assert.NoError(t, err, "Zip should not fail for case %q", tt.name)
+				return
+			}
+			assert.ErrorIs(t, err, ErrLengthMismatch, "Zip should report the mismatch for case %q", tt.name)
+			assert.EqualError(t, err, tt.expectedErr, "Zip error for case %q", tt.name)
+		})
+	}
+
+	// RoundTrip tests that unzipping zipped slices of equal length restores both inputs.
+	t.Run("RoundTrip", func(t *testing.T) {
+		keys, values := []string{"x", "y", "z"}, []int{7, 8, 9}
+		pairs, err := Zip(keys, values, RequireEqualLength)
+		assert.NoError(t, err, "Zip should not fail")
+
+		unzippedKeys, unzippedValues := Unzip(pairs)
+		assert.Equal(t, keys, unzippedKeys, "Unzip should restore the first slice")
+		assert.Equal(t, values, unzippedValues, "Unzip should restore the second slice")
+	})
+}
+
+func TestZipWith(t *testing.T) {
+	t.Parallel()
+
+	// Combine requests and responses into log lines.
+	format := func(req string, status int) string { return fmt.Sprintf("%s=%d", req, status) }
+
+	lines, err := ZipWith([]string{"GET /", "POST /a"}, []int{200, 201}, RequireEqualLength, format)
+	assert.NoError(t, err, "ZipWith should not fail for equal lengths")
+	assert.Equal(t, []string{"GET /=200", "POST /a=201"}, lines, "ZipWith should combine every position")
+
+	// The same lengths rules as Zip apply.
+	lines, err = ZipWith([]string{"GET /"}, []int{200, 201}, RequireEqualLength, format)
+	assert.ErrorIs(t, err, ErrLengthMismatch, "ZipWith should reject different lengths")
+	assert.Nil(t, lines, "ZipWith should not return a partial result")
+
+	lines, err = ZipWith([]string{"GET /"}, []int{200, 201}, TruncateToShortest, format)
+	assert.NoError(t, err, "ZipWith should truncate when asked to")
+	assert.Equal(t, []string{"GET /=200"}, lines, "ZipWith should stop at the shortest input")
+}
+
+func TestZip3(t *testing.T) {
+	t.Parallel()
+
+	// Equal lengths produce one triple per position and round-trip through Unzip3.
+	triples, err := Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false}, RequireEqualLength)
+	assert.NoError(t, err, "Zip3 should not fail for equal lengths")
+	assert.Equal(t, []Triple[int, string, bool]{{1, "a", true}, {2, "b", false}}, triples, "Zip3 should combine every position")
+
+	first, second, third := Unzip3(triples)
+	assert.Equal(t, []int{1, 2}, first, "Unzip3 should restore the first slice")
+	assert.Equal(t, []string{"a", "b"}, second, "Unzip3 should restore the second slice")
+	assert.Equal(t, []bool{true, false}, third, "Unzip3 should restore the third slice")
+
+	// A mismatch in any of the three lengths is rejected, or truncated when asked to.
+	_, err = Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true}, RequireEqualLength)
+	assert.ErrorIs(t, err, ErrLengthMismatch, "Zip3 should reject a shorter third slice")
+
+	triples, err = Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true}, TruncateToShortest)
+	assert.NoError(t, err, "Zip3 should truncate when asked to")
+	assert.Equal(t, []Triple[int, string, bool]{{1, "a", true}}, triples, "Zip3 should stop at the shortest input")
+}
+
+func TestZipLongest(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases where either slice may be the longest.
+	cases := []struct {
+		name     string
+		first    []string
+		second   []int
+		expected []Pair[string, int]
+	}{
+		{name: "Both nil", first: nil, second: nil, expected: []Pair[string, int]{}},
+		{name: "Equal lengths", first: []string{"a"}, second: []int{1}, expected: []Pair[string, int]{{"a", 1}}},
+		{name: "First longer", first: []string{"a", "b"}, second: []int{1}, expected: []Pair[string, int]{{"a", 1}, {"b", -1}}},
+		{name: "Second longer", first: []string{"a"}, second: []int{1, 2, 3}, expected: []Pair[string, int]{{"a", 1}, {"?", 2}, {"?", 3}}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ZipLongest(tt.first, tt.second, "?", -1), "ZipLongest result for case %q", tt.name)
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases covering empty inputs and the combination order.
+	cases := []struct {
+		name     string
+		first    []string
+		second   []int
+		expected []Pair[string, int]
+	}{
+		{name: "First empty", first: nil, second: []int{1}, expected: []Pair[string, int]{}},
+		{name: "Second empty", first: []string{"a"}, second: nil, expected: []Pair[string, int]{}},
+		{name: "Single combination", first: []string{"a"}, second: []int{1}, expected: []Pair[string, int]{{"a", 1}}},
+		{name: "Row-major order", first: []string{"a", "b"}, second: []int{1, 2, 3}, expected: []Pair[string, int]{{"a", 1}, {"a", 2}, {"a", 3}, {"b", 1}, {"b", 2}, {"b", 3}}},
+	}
+
+	// Iterate over the defined test cases, executing each one as a subtest.
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CartesianProduct(tt.first, tt.second), "CartesianProduct result for case %q", tt.name)
+		})
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	t.Parallel()
+
+	// Every element is paired with its position.
+	assert.Equal(t, []Pair[int, string]{{0, "a"}, {1, "b"}}, Enumerate([]string{"a", "b"}), "Enumerate should pair elements with their index")
+	assert.Equal(t, []Pair[int, string]{}, Enumerate([]string(nil)), "Enumerate of an empty slice should be empty")
+}